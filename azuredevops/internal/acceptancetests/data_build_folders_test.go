@@ -0,0 +1,49 @@
+//go:build (all || data_sources || data_build_folders) && (!exclude_data_sources || !exclude_data_build_folders)
+// +build all data_sources data_build_folders
+// +build !exclude_data_sources !exclude_data_build_folders
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+func TestAccBuildFolders_DataSource(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	config := fmt.Sprintf(`
+%s
+
+resource "azuredevops_build_folder" "app" {
+  project_id     = azuredevops_project.project.id
+  path           = "\\teams\\app"
+  description    = "app team"
+  create_parents = true
+}
+
+data "azuredevops_build_folders" "teams" {
+  project_id = azuredevops_project.project.id
+  path       = "\\teams"
+  depends_on = [azuredevops_build_folder.app]
+}
+`, testutils.HclProjectResource(projectName))
+
+	tfNode := "data.azuredevops_build_folders.teams"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testutils.PreCheck(t, nil) },
+		Providers: testutils.GetProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "folders.#", "1"),
+					resource.TestCheckResourceAttr(tfNode, "folders.0.path", `\teams\app`),
+					resource.TestCheckResourceAttr(tfNode, "folders.0.description", "app team"),
+				),
+			},
+		},
+	})
+}
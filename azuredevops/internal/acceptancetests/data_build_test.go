@@ -0,0 +1,48 @@
+//go:build (all || data_sources || data_build) && (!exclude_data_sources || !exclude_data_build)
+// +build all data_sources data_build
+// +build !exclude_data_sources !exclude_data_build
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+func TestAccBuild_DataSource(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	repoName := testutils.GenerateResourceName()
+	definitionName := testutils.GenerateResourceName()
+	config := fmt.Sprintf(`
+%s
+
+data "azuredevops_build" "build" {
+  project_id    = azuredevops_project.project.id
+  definition_id = azuredevops_pipeline_run.run.definition_id
+  branch        = "master"
+  result        = "succeeded"
+}
+`, testutils.HclPipelineRunResource(projectName, repoName, definitionName, "build"))
+
+	tfNode := "data.azuredevops_build.build"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testutils.PreCheck(t, nil) },
+		Providers: testutils.GetProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(tfNode, "id", "azuredevops_pipeline_run.run", "id"),
+					resource.TestCheckResourceAttr(tfNode, "status", "completed"),
+					resource.TestCheckResourceAttr(tfNode, "source_branch", "refs/heads/master"),
+					resource.TestCheckResourceAttrSet(tfNode, "build_number"),
+					resource.TestCheckResourceAttrSet(tfNode, "source_version"),
+					resource.TestCheckResourceAttrSet(tfNode, "finish_time"),
+				),
+			},
+		},
+	})
+}
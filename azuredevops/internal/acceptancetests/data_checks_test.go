@@ -0,0 +1,64 @@
+//go:build (all || data_sources || data_checks) && (!exclude_data_sources || !exclude_data_checks)
+// +build all data_sources data_checks
+// +build !exclude_data_sources !exclude_data_checks
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+func TestAccChecks_DataSource(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+
+	checksResources := `
+resource "azuredevops_check_exclusive_lock" "lock" {
+  project_id           = azuredevops_project.project.id
+  target_resource_id   = azuredevops_environment.environment.id
+  target_resource_type = "environment"
+  timeout              = 60
+}
+
+resource "azuredevops_check_business_hours" "hours" {
+  project_id           = azuredevops_project.project.id
+  display_name         = "Business hours"
+  target_resource_id   = azuredevops_environment.environment.id
+  target_resource_type = "environment"
+  start_time           = "07:00"
+  end_time             = "15:30"
+  time_zone            = "UTC"
+  monday               = true
+}`
+	checksData := `
+data "azuredevops_checks" "checks" {
+  project_id           = azuredevops_project.project.id
+  target_resource_id   = azuredevops_environment.environment.id
+  target_resource_type = "environment"
+
+  depends_on = [
+    azuredevops_check_exclusive_lock.lock,
+    azuredevops_check_business_hours.hours,
+  ]
+}`
+	environmentResource := testutils.HclEnvironmentResource(projectName, "environment_test")
+
+	tfNode := "data.azuredevops_checks.checks"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testutils.PreCheck(t, nil) },
+		Providers: testutils.GetProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf("%s\n%s\n%s", environmentResource, checksResources, checksData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "checks.#", "2"),
+					testutils.CheckNestedKeyExistsWithValue(tfNode, "type_name", "ExclusiveLock"),
+					testutils.CheckNestedKeyExistsWithValue(tfNode, "display_name", "Business hours"),
+				),
+			},
+		},
+	})
+}
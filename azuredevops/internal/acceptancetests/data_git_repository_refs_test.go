@@ -0,0 +1,46 @@
+//go:build (all || data_sources || git || data_git_repository_refs) && (!exclude_data_sources || !exclude_git || !exclude_data_git_repository_refs)
+// +build all data_sources git data_git_repository_refs
+// +build !exclude_data_sources !exclude_git !exclude_data_git_repository_refs
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// TestAccGitRepositoryRefs_DataSource verifies that the refs of a repository
+// can be read and filtered
+func TestAccGitRepositoryRefs_DataSource(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	gitRepoName := testutils.GenerateResourceName()
+	tfNode := "data.azuredevops_git_repository_refs.branches"
+
+	hcl := fmt.Sprintf(`
+%s
+
+data "azuredevops_git_repository_refs" "branches" {
+  repository_id = azuredevops_git_repository.repository.id
+  filter        = "refs/heads/"
+}
+`, testutils.HclGitRepoResource(projectName, gitRepoName, "Clean"))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testutils.PreCheck(t, nil) },
+		Providers: testutils.GetProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: hcl,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "refs.#", "1"),
+					resource.TestCheckResourceAttr(tfNode, "refs.0.name", "refs/heads/master"),
+					resource.TestCheckResourceAttrSet(tfNode, "refs.0.object_id"),
+					resource.TestCheckResourceAttrPair(tfNode, "refs.0.commit_id", tfNode, "refs.0.object_id"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,60 @@
+//go:build (all || resource_build_definition_tags) && !exclude_resource_build_definition_tags
+// +build all resource_build_definition_tags
+// +build !exclude_resource_build_definition_tags
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+func hclBuildDefinitionTagsResource(projectName string, repoName string, buildDefinitionName string, tags string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuredevops_build_definition_tags" "tags" {
+  project_id    = azuredevops_project.project.id
+  definition_id = azuredevops_build_definition.build.id
+  tags          = %s
+}
+`, testutils.HclBuildDefinitionResourceTfsGit(projectName, repoName, buildDefinitionName, `\\`), tags)
+}
+
+// validates that tags can be added to and removed from a build definition
+func TestAccBuildDefinitionTags_CreateAndUpdate(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	repoName := testutils.GenerateResourceName()
+	buildDefinitionName := testutils.GenerateResourceName()
+
+	tfNode := "azuredevops_build_definition_tags.tags"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: hclBuildDefinitionTagsResource(projectName, repoName, buildDefinitionName, `["compliance"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "definition_id"),
+					resource.TestCheckResourceAttr(tfNode, "tags.#", "1"),
+				),
+			},
+			{
+				Config: hclBuildDefinitionTagsResource(projectName, repoName, buildDefinitionName, `["compliance", "reporting"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "tags.#", "2"),
+					resource.TestCheckTypeSetElemAttr(tfNode, "tags.*", "reporting"),
+				),
+			},
+			{
+				ResourceName:      tfNode,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
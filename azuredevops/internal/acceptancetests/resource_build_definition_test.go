@@ -7,7 +7,9 @@ package acceptancetests
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -180,6 +182,128 @@ func TestAccBuildDefinition_Schedules(t *testing.T) {
 	})
 }
 
+func TestAccBuildDefinition_DesignerProcess(t *testing.T) {
+	name := testutils.GenerateResourceName()
+	tfNode := "azuredevops_build_definition.build"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: checkBuildDefinitionDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: hclBuildDefinitionDesignerProcess(name, map[string]string{"script": "make", "workingDirectory": "src"}),
+				Check: resource.ComposeTestCheckFunc(
+					checkBuildDefinitionExists(name),
+					resource.TestCheckResourceAttr(tfNode, "agent_specification", "ubuntu-latest"),
+					resource.TestCheckResourceAttr(tfNode, "phase.#", "1"),
+					resource.TestCheckResourceAttrSet(tfNode, "phase.0.ref_name"),
+					resource.TestCheckResourceAttr(tfNode, "phase.0.job_timeout_in_minutes", "30"),
+					resource.TestCheckResourceAttr(tfNode, "phase.0.step.#", "1"),
+					resource.TestCheckResourceAttr(tfNode, "phase.0.step.0.inputs.%", "2"),
+					resource.TestCheckResourceAttr(tfNode, "phase.0.step.0.inputs.script", "make"),
+					resource.TestCheckResourceAttr(tfNode, "phase.0.step.0.inputs.workingDirectory", "src"),
+				),
+			},
+			{
+				Config: hclBuildDefinitionDesignerProcess(name, map[string]string{"script": "make all"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "phase.0.step.0.inputs.%", "1"),
+					resource.TestCheckResourceAttr(tfNode, "phase.0.step.0.inputs.script", "make all"),
+				),
+			},
+			{
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testutils.ComputeProjectQualifiedResourceImportID(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the imported state replaces the state, so that the next step plans against it
+				ImportStatePersist: true,
+			},
+			{
+				Config:   hclBuildDefinitionDesignerProcess(name, map[string]string{"script": "make all"}),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccBuildDefinition_Options(t *testing.T) {
+	name := testutils.GenerateResourceName()
+	tfNode := "azuredevops_build_definition.build"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: checkBuildDefinitionDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: hclBuildDefinitionOptions(name, "enabled", 30),
+				Check: resource.ComposeTestCheckFunc(
+					checkBuildDefinitionExists(name),
+					resource.TestCheckResourceAttr(tfNode, "build_number_format", "$(date:yyyyMMdd)$(rev:.r)"),
+					resource.TestCheckResourceAttr(tfNode, "badge_enabled", "true"),
+					resource.TestCheckResourceAttr(tfNode, "queue_status", "enabled"),
+					resource.TestCheckResourceAttr(tfNode, "job_authorization_scope", "project"),
+					resource.TestCheckResourceAttr(tfNode, "job_timeout_in_minutes", "30"),
+					resource.TestCheckResourceAttr(tfNode, "retention_rule.#", "1"),
+					resource.TestCheckResourceAttr(tfNode, "retention_rule.0.days_to_keep", "30"),
+					resource.TestCheckResourceAttr(tfNode, "repository.0.clean", "true"),
+					resource.TestCheckResourceAttr(tfNode, "repository.0.fetch_depth", "1"),
+					resource.TestCheckResourceAttr(tfNode, "repository.0.checkout_submodules", "true"),
+					resource.TestCheckResourceAttr(tfNode, "repository.0.git_lfs", "true"),
+					resource.TestCheckResourceAttr(tfNode, "repository.0.tag_sources", "OnSuccess"),
+					resource.TestCheckResourceAttr(tfNode, "repository.0.tag_format", "v$(build.buildNumber)"),
+				),
+			},
+			{
+				Config: hclBuildDefinitionOptions(name, "paused", 90),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "queue_status", "paused"),
+					resource.TestCheckResourceAttr(tfNode, "job_timeout_in_minutes", "90"),
+				),
+			},
+			{
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testutils.ComputeProjectQualifiedResourceImportID(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// validates that the tags of a build definition are added and removed
+func TestAccBuildDefinition_Tags(t *testing.T) {
+	name := testutils.GenerateResourceName()
+	tfNode := "azuredevops_build_definition.build"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: checkBuildDefinitionDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: hclBuildDefinitionTags(name, `["reporting", "compliance"]`),
+				Check: resource.ComposeTestCheckFunc(
+					checkBuildDefinitionExists(name),
+					resource.TestCheckResourceAttr(tfNode, "tags.#", "2"),
+				),
+			},
+			{
+				Config: hclBuildDefinitionTags(name, `["reporting"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr(tfNode, "tags.*", "reporting"),
+				),
+			},
+			{
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testutils.ComputeProjectQualifiedResourceImportID(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 // Checks that the expected variable values exist in the state
 func checkForVariableValues(tfNode string, expectedVals ...string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
@@ -324,3 +448,137 @@ resource "azuredevops_build_definition" "build" {
 }
 `, name)
 }
+
+func hclBuildDefinitionDesignerProcess(name string, inputs map[string]string) string {
+	var inputsHCL []string
+	for input, value := range inputs {
+		inputsHCL = append(inputsHCL, fmt.Sprintf("        %s = %q", input, value))
+	}
+	sort.Strings(inputsHCL)
+
+	return fmt.Sprintf(`
+resource "azuredevops_project" "test" {
+  name               = "%[1]s"
+  description        = "%[1]s-description"
+  visibility         = "private"
+  version_control    = "Git"
+  work_item_template = "Agile"
+}
+
+resource "azuredevops_git_repository" "test" {
+  project_id = azuredevops_project.test.id
+  name       = "acc-%[1]s"
+  initialization {
+    init_type = "Clean"
+  }
+}
+
+resource "azuredevops_build_definition" "build" {
+  project_id          = azuredevops_project.test.id
+  name                = "%[1]s"
+  agent_specification = "ubuntu-latest"
+
+  repository {
+    repo_type   = "TfsGit"
+    repo_id     = azuredevops_git_repository.test.id
+    branch_name = azuredevops_git_repository.test.default_branch
+  }
+
+  phase {
+    name                   = "Agent job 1"
+    job_timeout_in_minutes = 30
+
+    step {
+      task_id      = "d9bafed4-0b18-4f58-968d-86655b4d2ce9"
+      version      = "2.*"
+      display_name = "Build"
+      inputs = {
+%[2]s
+      }
+    }
+  }
+}
+`, name, strings.Join(inputsHCL, "\n"))
+}
+
+func hclBuildDefinitionOptions(name string, queueStatus string, jobTimeout int) string {
+	return fmt.Sprintf(`
+resource "azuredevops_project" "test" {
+  name               = "%[1]s"
+  description        = "%[1]s-description"
+  visibility         = "private"
+  version_control    = "Git"
+  work_item_template = "Agile"
+}
+
+resource "azuredevops_git_repository" "test" {
+  project_id = azuredevops_project.test.id
+  name       = "acc-%[1]s"
+  initialization {
+    init_type = "Clean"
+  }
+}
+
+resource "azuredevops_build_definition" "build" {
+  project_id              = azuredevops_project.test.id
+  name                    = "%[1]s"
+  build_number_format     = "$(date:yyyyMMdd)$(rev:.r)"
+  badge_enabled           = true
+  queue_status            = "%[2]s"
+  job_authorization_scope = "project"
+  job_timeout_in_minutes  = %[3]d
+
+  retention_rule {
+    branches        = ["+refs/heads/main"]
+    days_to_keep    = 30
+    minimum_to_keep = 5
+  }
+
+  repository {
+    repo_type           = "TfsGit"
+    repo_id             = azuredevops_git_repository.test.id
+    branch_name         = azuredevops_git_repository.test.default_branch
+    yml_path            = "azure-pipelines.yml"
+    clean               = true
+    fetch_depth         = 1
+    checkout_submodules = true
+    git_lfs             = true
+    tag_sources         = "OnSuccess"
+    tag_format          = "v$(build.buildNumber)"
+  }
+}
+`, name, queueStatus, jobTimeout)
+}
+
+func hclBuildDefinitionTags(name string, tags string) string {
+	return fmt.Sprintf(`
+resource "azuredevops_project" "test" {
+  name               = "%[1]s"
+  description        = "%[1]s-description"
+  visibility         = "private"
+  version_control    = "Git"
+  work_item_template = "Agile"
+}
+
+resource "azuredevops_git_repository" "test" {
+  project_id = azuredevops_project.test.id
+  name       = "acc-%[1]s"
+  initialization {
+    init_type = "Clean"
+  }
+}
+
+resource "azuredevops_build_definition" "build" {
+  project_id = azuredevops_project.test.id
+  name       = "%[1]s"
+  tags       = %[2]s
+
+  repository {
+    repo_type   = "TfsGit"
+    repo_id     = azuredevops_git_repository.test.id
+    branch_name = azuredevops_git_repository.test.default_branch
+    yml_path    = "azure-pipelines.yml"
+  }
+}
+`, name, tags)
+}
@@ -69,6 +69,31 @@ func TestAccBuildFolder_update(t *testing.T) {
 	})
 }
 
+func TestAccBuildFolder_createParentsAndMove(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+
+	tfNode := "azuredevops_build_folder.test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: buildFolderWithParents(projectName, "\\\\team\\\\app"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "path", `\team\app`),
+				),
+			},
+			{
+				Config: buildFolderWithParents(projectName, "\\\\other team\\\\app"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "path", `\other team\app`),
+				),
+			},
+		},
+	})
+}
+
 func TestAccBuildFolder_requiresImportErrorStep(t *testing.T) {
 	projectName := testutils.GenerateResourceName()
 
@@ -128,3 +153,21 @@ resource "azuredevops_build_folder" "import" {
 }
 `, basicConfig)
 }
+
+func buildFolderWithParents(projectName, path string) string {
+	return fmt.Sprintf(`
+resource "azuredevops_project" "project" {
+  name               = "%[1]s"
+  description        = "%[1]s-description"
+  visibility         = "private"
+  version_control    = "Git"
+  work_item_template = "Agile"
+}
+
+resource "azuredevops_build_folder" "test" {
+  project_id     = azuredevops_project.project.id
+  path           = "%[2]s"
+  create_parents = true
+}
+`, projectName, path)
+}
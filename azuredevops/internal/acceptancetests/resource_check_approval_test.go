@@ -0,0 +1,112 @@
+//go:build (all || resource_check_approval) && !exclude_approvalsandchecks
+// +build all resource_check_approval
+// +build !exclude_approvalsandchecks
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+func TestAccApprovalCheck_basic(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+
+	resourceType := "azuredevops_check_approval"
+	tfCheckNode := resourceType + ".test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckPipelineCheckDestroyed(resourceType),
+		Steps: []resource.TestStep{
+			{
+				Config: hclApprovalCheckResourceBasic(projectName),
+				Check: resource.ComposeTestCheckFunc(
+					testutils.CheckPipelineCheckExists(tfCheckNode),
+					resource.TestCheckResourceAttrSet(tfCheckNode, "project_id"),
+					resource.TestCheckResourceAttr(tfCheckNode, "approvers.#", "1"),
+					resource.TestCheckResourceAttrPair(tfCheckNode, "approvers.0", "data.azuredevops_group.group", "descriptor"),
+					resource.TestCheckResourceAttr(tfCheckNode, "timeout", "43200"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccApprovalCheck_update(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+
+	resourceType := "azuredevops_check_approval"
+	tfCheckNode := resourceType + ".test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckPipelineCheckDestroyed(resourceType),
+		Steps: []resource.TestStep{
+			{
+				Config: hclApprovalCheckResourceBasic(projectName),
+				Check: resource.ComposeTestCheckFunc(
+					testutils.CheckPipelineCheckExists(tfCheckNode),
+					resource.TestCheckResourceAttr(tfCheckNode, "sequential_approval", "false"),
+					resource.TestCheckResourceAttr(tfCheckNode, "requester_can_approve", "false"),
+				),
+			},
+			{
+				Config: hclApprovalCheckResourceComplete(projectName),
+				Check: resource.ComposeTestCheckFunc(
+					testutils.CheckPipelineCheckExists(tfCheckNode),
+					resource.TestCheckResourceAttr(tfCheckNode, "approvers.#", "1"),
+					resource.TestCheckResourceAttr(tfCheckNode, "minimum_required_approvers", "1"),
+					resource.TestCheckResourceAttr(tfCheckNode, "sequential_approval", "true"),
+					resource.TestCheckResourceAttr(tfCheckNode, "requester_can_approve", "true"),
+					resource.TestCheckResourceAttr(tfCheckNode, "instructions", "Approval instructions"),
+					resource.TestCheckResourceAttr(tfCheckNode, "timeout", "1440"),
+				),
+			},
+		},
+	})
+}
+
+func hclApprovalCheckResourceBasic(projectName string) string {
+	checkResource := `
+resource "azuredevops_check_approval" "test" {
+  project_id           = azuredevops_project.project.id
+  target_resource_id   = azuredevops_environment.environment.id
+  target_resource_type = "environment"
+  approvers            = [data.azuredevops_group.group.descriptor]
+}`
+
+	environmentResource := testutils.HclEnvironmentResource(projectName, "environment_test")
+	groupDataSource := hclApprovalCheckGroupDataSource()
+	return fmt.Sprintf("%s\n%s\n%s", environmentResource, groupDataSource, checkResource)
+}
+
+func hclApprovalCheckResourceComplete(projectName string) string {
+	checkResource := `
+resource "azuredevops_check_approval" "test" {
+  project_id                 = azuredevops_project.project.id
+  target_resource_id         = azuredevops_environment.environment.id
+  target_resource_type       = "environment"
+  approvers                  = [data.azuredevops_group.group.descriptor]
+  minimum_required_approvers = 1
+  sequential_approval        = true
+  requester_can_approve      = true
+  instructions               = "Approval instructions"
+  timeout                    = 1440
+}`
+
+	environmentResource := testutils.HclEnvironmentResource(projectName, "environment_test")
+	groupDataSource := hclApprovalCheckGroupDataSource()
+	return fmt.Sprintf("%s\n%s\n%s", environmentResource, groupDataSource, checkResource)
+}
+
+func hclApprovalCheckGroupDataSource() string {
+	return `
+data "azuredevops_group" "group" {
+  project_id = azuredevops_project.project.id
+  name       = "Contributors"
+}`
+}
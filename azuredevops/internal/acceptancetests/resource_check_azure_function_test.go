@@ -0,0 +1,104 @@
+//go:build (all || resource_check_azure_function) && !exclude_approvalsandchecks
+// +build all resource_check_azure_function
+// +build !exclude_approvalsandchecks
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+func TestAccCheckAzureFunction_basic(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	checkName := testutils.GenerateResourceName()
+
+	resourceType := "azuredevops_check_azure_function"
+	tfCheckNode := resourceType + ".test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckPipelineCheckDestroyed(resourceType),
+		Steps: []resource.TestStep{
+			{
+				Config: hclCheckAzureFunctionResourceBasic(projectName, checkName),
+				Check: resource.ComposeTestCheckFunc(
+					testutils.CheckPipelineCheckExistsWithName(tfCheckNode, checkName),
+					resource.TestCheckResourceAttrSet(tfCheckNode, "project_id"),
+					resource.TestCheckResourceAttr(tfCheckNode, "function_url", "https://example.azurewebsites.net/api/gate"),
+					resource.TestCheckResourceAttr(tfCheckNode, "completion_event", "Callback"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCheckAzureFunction_update(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	checkName := testutils.GenerateResourceName()
+
+	resourceType := "azuredevops_check_azure_function"
+	tfCheckNode := resourceType + ".test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckPipelineCheckDestroyed(resourceType),
+		Steps: []resource.TestStep{
+			{
+				Config: hclCheckAzureFunctionResourceBasic(projectName, checkName),
+				Check: resource.ComposeTestCheckFunc(
+					testutils.CheckPipelineCheckExistsWithName(tfCheckNode, checkName),
+				),
+			},
+			{
+				Config: hclCheckAzureFunctionResourceComplete(projectName, checkName),
+				Check: resource.ComposeTestCheckFunc(
+					testutils.CheckPipelineCheckExistsWithName(tfCheckNode, checkName),
+					resource.TestCheckResourceAttr(tfCheckNode, "query_parameters", "stage=production"),
+					resource.TestCheckResourceAttr(tfCheckNode, "completion_event", "ApiResponse"),
+					resource.TestCheckResourceAttr(tfCheckNode, "success_criteria", "eq(root['approved'], true)"),
+					resource.TestCheckResourceAttr(tfCheckNode, "retry_interval", "10"),
+					resource.TestCheckResourceAttr(tfCheckNode, "timeout", "60"),
+				),
+			},
+		},
+	})
+}
+
+func hclCheckAzureFunctionResourceBasic(projectName string, checkName string) string {
+	checkResource := fmt.Sprintf(`
+resource "azuredevops_check_azure_function" "test" {
+  project_id           = azuredevops_project.project.id
+  display_name         = "%s"
+  target_resource_id   = azuredevops_environment.environment.id
+  target_resource_type = "environment"
+  function_url         = "https://example.azurewebsites.net/api/gate"
+  function_key         = "function-key"
+}`, checkName)
+
+	environmentResource := testutils.HclEnvironmentResource(projectName, "environment_test")
+	return fmt.Sprintf("%s\n%s", environmentResource, checkResource)
+}
+
+func hclCheckAzureFunctionResourceComplete(projectName string, checkName string) string {
+	checkResource := fmt.Sprintf(`
+resource "azuredevops_check_azure_function" "test" {
+  project_id           = azuredevops_project.project.id
+  display_name         = "%s"
+  target_resource_id   = azuredevops_environment.environment.id
+  target_resource_type = "environment"
+  function_url         = "https://example.azurewebsites.net/api/gate"
+  function_key         = "function-key"
+  query_parameters     = "stage=production"
+  completion_event     = "ApiResponse"
+  success_criteria     = "eq(root['approved'], true)"
+  retry_interval       = 10
+  timeout              = 60
+}`, checkName)
+
+	environmentResource := testutils.HclEnvironmentResource(projectName, "environment_test")
+	return fmt.Sprintf("%s\n%s", environmentResource, checkResource)
+}
@@ -0,0 +1,55 @@
+//go:build (all || resource_check_exclusive_lock) && !exclude_approvalsandchecks
+// +build all resource_check_exclusive_lock
+// +build !exclude_approvalsandchecks
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+func TestAccCheckExclusiveLock_basic(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+
+	resourceType := "azuredevops_check_exclusive_lock"
+	tfCheckNode := resourceType + ".test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckPipelineCheckDestroyed(resourceType),
+		Steps: []resource.TestStep{
+			{
+				Config: hclCheckExclusiveLockResource(projectName, 43200),
+				Check: resource.ComposeTestCheckFunc(
+					testutils.CheckPipelineCheckExists(tfCheckNode),
+					resource.TestCheckResourceAttrSet(tfCheckNode, "project_id"),
+					resource.TestCheckResourceAttr(tfCheckNode, "timeout", "43200"),
+				),
+			},
+			{
+				Config: hclCheckExclusiveLockResource(projectName, 60),
+				Check: resource.ComposeTestCheckFunc(
+					testutils.CheckPipelineCheckExists(tfCheckNode),
+					resource.TestCheckResourceAttr(tfCheckNode, "timeout", "60"),
+				),
+			},
+		},
+	})
+}
+
+func hclCheckExclusiveLockResource(projectName string, timeout int) string {
+	checkResource := fmt.Sprintf(`
+resource "azuredevops_check_exclusive_lock" "test" {
+  project_id           = azuredevops_project.project.id
+  target_resource_id   = azuredevops_environment.environment.id
+  target_resource_type = "environment"
+  timeout              = %d
+}`, timeout)
+
+	environmentResource := testutils.HclEnvironmentResource(projectName, "environment_test")
+	return fmt.Sprintf("%s\n%s", environmentResource, checkResource)
+}
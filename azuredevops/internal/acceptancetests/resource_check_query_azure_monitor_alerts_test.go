@@ -0,0 +1,94 @@
+//go:build (all || resource_check_query_azure_monitor_alerts) && !exclude_approvalsandchecks
+// +build all resource_check_query_azure_monitor_alerts
+// +build !exclude_approvalsandchecks
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+func TestAccCheckQueryAzureMonitorAlerts_basic(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	checkName := testutils.GenerateResourceName()
+
+	resourceType := "azuredevops_check_query_azure_monitor_alerts"
+	tfCheckNode := resourceType + ".test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckPipelineCheckDestroyed(resourceType),
+		Steps: []resource.TestStep{
+			{
+				Config: hclCheckQueryAzureMonitorAlertsResourceBasic(projectName, checkName),
+				Check: resource.ComposeTestCheckFunc(
+					testutils.CheckPipelineCheckExistsWithName(tfCheckNode, checkName),
+					resource.TestCheckResourceAttrSet(tfCheckNode, "project_id"),
+					resource.TestCheckResourceAttrPair(tfCheckNode, "subscription", "azuredevops_serviceendpoint_azurerm.serviceendpointrm", "azurerm_subscription_id"),
+					resource.TestCheckResourceAttr(tfCheckNode, "resource_group_name", "production-rg"),
+					resource.TestCheckResourceAttr(tfCheckNode, "severities.#", "5"),
+				),
+			},
+			{
+				Config: hclCheckQueryAzureMonitorAlertsResourceComplete(projectName, checkName),
+				Check: resource.ComposeTestCheckFunc(
+					testutils.CheckPipelineCheckExistsWithName(tfCheckNode, checkName),
+					resource.TestCheckResourceAttr(tfCheckNode, "alert_rules.#", "2"),
+					resource.TestCheckResourceAttr(tfCheckNode, "severities.#", "2"),
+					resource.TestCheckResourceAttr(tfCheckNode, "delay", "5"),
+					resource.TestCheckResourceAttr(tfCheckNode, "retry_interval", "10"),
+					resource.TestCheckResourceAttr(tfCheckNode, "timeout", "120"),
+				),
+			},
+		},
+	})
+}
+
+func hclCheckQueryAzureMonitorAlertsResourceBasic(projectName string, checkName string) string {
+	checkResource := fmt.Sprintf(`
+resource "azuredevops_check_query_azure_monitor_alerts" "test" {
+  project_id                    = azuredevops_project.project.id
+  display_name                  = "%s"
+  target_resource_id            = azuredevops_environment.environment.id
+  target_resource_type          = "environment"
+  azurerm_service_connection_id = azuredevops_serviceendpoint_azurerm.serviceendpointrm.id
+  subscription                  = azuredevops_serviceendpoint_azurerm.serviceendpointrm.azurerm_subscription_id
+  resource_group_name           = "production-rg"
+}`, checkName)
+
+	return fmt.Sprintf("%s\n%s", hclCheckQueryAzureMonitorAlertsDependencies(projectName), checkResource)
+}
+
+func hclCheckQueryAzureMonitorAlertsResourceComplete(projectName string, checkName string) string {
+	checkResource := fmt.Sprintf(`
+resource "azuredevops_check_query_azure_monitor_alerts" "test" {
+  project_id                    = azuredevops_project.project.id
+  display_name                  = "%s"
+  target_resource_id            = azuredevops_environment.environment.id
+  target_resource_type          = "environment"
+  azurerm_service_connection_id = azuredevops_serviceendpoint_azurerm.serviceendpointrm.id
+  subscription                  = azuredevops_serviceendpoint_azurerm.serviceendpointrm.azurerm_subscription_id
+  resource_group_name           = "production-rg"
+  alert_rules                   = ["cpu-alert", "availability-alert"]
+  severities                    = ["Sev0", "Sev1"]
+  delay                         = 5
+  retry_interval                = 10
+  timeout                       = 120
+}`, checkName)
+
+	return fmt.Sprintf("%s\n%s", hclCheckQueryAzureMonitorAlertsDependencies(projectName), checkResource)
+}
+
+func hclCheckQueryAzureMonitorAlertsDependencies(projectName string) string {
+	serviceEndpointResource := testutils.HclServiceEndpointAzureRMResource(projectName, "serviceendpointrm", "e318e66b-ec4b-4dff-9124-41129b9d7150", "d9d210dd-f9f0-4176-afb8-a4df60e1ae72")
+	environmentResource := `
+resource "azuredevops_environment" "environment" {
+  project_id = azuredevops_project.project.id
+  name       = "environment_test"
+}`
+	return fmt.Sprintf("%s\n%s", serviceEndpointResource, environmentResource)
+}
@@ -0,0 +1,89 @@
+//go:build (all || resource_check_required_template) && !exclude_approvalsandchecks
+// +build all resource_check_required_template
+// +build !exclude_approvalsandchecks
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+func TestAccCheckRequiredTemplate_basic(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+
+	resourceType := "azuredevops_check_required_template"
+	tfCheckNode := resourceType + ".test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckPipelineCheckDestroyed(resourceType),
+		Steps: []resource.TestStep{
+			{
+				Config: hclCheckRequiredTemplateResourceBasic(projectName),
+				Check: resource.ComposeTestCheckFunc(
+					testutils.CheckPipelineCheckExists(tfCheckNode),
+					resource.TestCheckResourceAttrSet(tfCheckNode, "project_id"),
+					resource.TestCheckResourceAttr(tfCheckNode, "required_template.#", "1"),
+					resource.TestCheckResourceAttr(tfCheckNode, "required_template.0.repository_type", "azuregit"),
+					resource.TestCheckResourceAttr(tfCheckNode, "required_template.0.template_path", "deploy.yml"),
+				),
+			},
+			{
+				Config: hclCheckRequiredTemplateResourceUpdate(projectName),
+				Check: resource.ComposeTestCheckFunc(
+					testutils.CheckPipelineCheckExists(tfCheckNode),
+					resource.TestCheckResourceAttr(tfCheckNode, "required_template.#", "2"),
+					resource.TestCheckResourceAttr(tfCheckNode, "required_template.1.repository_type", "github"),
+					resource.TestCheckResourceAttr(tfCheckNode, "required_template.1.repository_ref", "refs/tags/v1"),
+				),
+			},
+		},
+	})
+}
+
+func hclCheckRequiredTemplateResourceBasic(projectName string) string {
+	checkResource := fmt.Sprintf(`
+resource "azuredevops_check_required_template" "test" {
+  project_id           = azuredevops_project.project.id
+  target_resource_id   = azuredevops_environment.environment.id
+  target_resource_type = "environment"
+
+  required_template {
+    repository_name = "%s/templates"
+    repository_ref  = "refs/heads/main"
+    template_path   = "deploy.yml"
+  }
+}`, projectName)
+
+	environmentResource := testutils.HclEnvironmentResource(projectName, "environment_test")
+	return fmt.Sprintf("%s\n%s", environmentResource, checkResource)
+}
+
+func hclCheckRequiredTemplateResourceUpdate(projectName string) string {
+	checkResource := fmt.Sprintf(`
+resource "azuredevops_check_required_template" "test" {
+  project_id           = azuredevops_project.project.id
+  target_resource_id   = azuredevops_environment.environment.id
+  target_resource_type = "environment"
+
+  required_template {
+    repository_name = "%s/templates"
+    repository_ref  = "refs/heads/main"
+    template_path   = "deploy.yml"
+  }
+
+  required_template {
+    repository_type = "github"
+    repository_name = "microsoft/azure-pipelines-yaml"
+    repository_ref  = "refs/tags/v1"
+    template_path   = "templates/deploy.yml"
+  }
+}`, projectName)
+
+	environmentResource := testutils.HclEnvironmentResource(projectName, "environment_test")
+	return fmt.Sprintf("%s\n%s", environmentResource, checkResource)
+}
@@ -0,0 +1,114 @@
+//go:build (all || resource_check_rest_api) && !exclude_approvalsandchecks
+// +build all resource_check_rest_api
+// +build !exclude_approvalsandchecks
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+func TestAccCheckRestAPI_basic(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	checkName := testutils.GenerateResourceName()
+
+	resourceType := "azuredevops_check_rest_api"
+	tfCheckNode := resourceType + ".test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckPipelineCheckDestroyed(resourceType),
+		Steps: []resource.TestStep{
+			{
+				Config: hclCheckRestAPIResourceBasic(projectName, checkName),
+				Check: resource.ComposeTestCheckFunc(
+					testutils.CheckPipelineCheckExistsWithName(tfCheckNode, checkName),
+					resource.TestCheckResourceAttrSet(tfCheckNode, "project_id"),
+					resource.TestCheckResourceAttrPair(tfCheckNode, "service_connection_id", "azuredevops_serviceendpoint_generic.test", "id"),
+					resource.TestCheckResourceAttr(tfCheckNode, "method", "POST"),
+					resource.TestCheckResourceAttr(tfCheckNode, "completion_event", "Callback"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCheckRestAPI_update(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	checkName := testutils.GenerateResourceName()
+
+	resourceType := "azuredevops_check_rest_api"
+	tfCheckNode := resourceType + ".test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckPipelineCheckDestroyed(resourceType),
+		Steps: []resource.TestStep{
+			{
+				Config: hclCheckRestAPIResourceBasic(projectName, checkName),
+				Check: resource.ComposeTestCheckFunc(
+					testutils.CheckPipelineCheckExistsWithName(tfCheckNode, checkName),
+				),
+			},
+			{
+				Config: hclCheckRestAPIResourceComplete(projectName, checkName),
+				Check: resource.ComposeTestCheckFunc(
+					testutils.CheckPipelineCheckExistsWithName(tfCheckNode, checkName),
+					resource.TestCheckResourceAttr(tfCheckNode, "method", "GET"),
+					resource.TestCheckResourceAttr(tfCheckNode, "url_suffix", "/api/health"),
+					resource.TestCheckResourceAttr(tfCheckNode, "completion_event", "ApiResponse"),
+					resource.TestCheckResourceAttr(tfCheckNode, "success_criteria", "eq(root['status'], 'healthy')"),
+					resource.TestCheckResourceAttr(tfCheckNode, "retry_interval", "10"),
+					resource.TestCheckResourceAttr(tfCheckNode, "timeout", "60"),
+				),
+			},
+		},
+	})
+}
+
+func hclCheckRestAPIResourceBasic(projectName string, checkName string) string {
+	checkResource := fmt.Sprintf(`
+resource "azuredevops_check_rest_api" "test" {
+  project_id            = azuredevops_project.project.id
+  display_name          = "%s"
+  target_resource_id    = azuredevops_environment.environment.id
+  target_resource_type  = "environment"
+  service_connection_id = azuredevops_serviceendpoint_generic.test.id
+}`, checkName)
+
+	return fmt.Sprintf("%s\n%s", hclCheckRestAPIDependencies(projectName), checkResource)
+}
+
+func hclCheckRestAPIResourceComplete(projectName string, checkName string) string {
+	checkResource := fmt.Sprintf(`
+resource "azuredevops_check_rest_api" "test" {
+  project_id            = azuredevops_project.project.id
+  display_name          = "%s"
+  target_resource_id    = azuredevops_environment.environment.id
+  target_resource_type  = "environment"
+  service_connection_id = azuredevops_serviceendpoint_generic.test.id
+  method                = "GET"
+  url_suffix            = "/api/health"
+  headers               = "{\"Content-Type\":\"application/json\"}"
+  completion_event      = "ApiResponse"
+  success_criteria      = "eq(root['status'], 'healthy')"
+  retry_interval        = 10
+  timeout               = 60
+}`, checkName)
+
+	return fmt.Sprintf("%s\n%s", hclCheckRestAPIDependencies(projectName), checkResource)
+}
+
+func hclCheckRestAPIDependencies(projectName string) string {
+	serviceEndpointResource := testutils.HclServiceEndpointGenericResource(projectName, "serviceendpoint", "https://health.example.com", "username", "password")
+	environmentResource := `
+resource "azuredevops_environment" "environment" {
+  project_id = azuredevops_project.project.id
+  name       = "environment_test"
+}`
+	return fmt.Sprintf("%s\n%s", serviceEndpointResource, environmentResource)
+}
@@ -5,6 +5,7 @@
 package acceptancetests
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -33,3 +34,54 @@ func TestAccGitPermissions_SetPermissions(t *testing.T) {
 		},
 	})
 }
+
+func TestAccGitPermissions_SetRefFolderPermissions(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	gitRepoName := testutils.GenerateResourceName()
+	config := fmt.Sprintf(`
+%s
+
+data "azuredevops_group" "project-contributors" {
+	project_id = azuredevops_project.project.id
+	name       = "Contributors"
+}
+
+resource "azuredevops_git_permissions" "release-branches" {
+	project_id    = azuredevops_project.project.id
+	repository_id = azuredevops_git_repository.repository.id
+	branch_folder = "refs/heads/release/*"
+	principal     = data.azuredevops_group.project-contributors.id
+	permissions   = {
+		GenericContribute = "Deny"
+		ForcePush         = "Deny"
+	}
+}
+
+resource "azuredevops_git_permissions" "tags" {
+	project_id    = azuredevops_project.project.id
+	repository_id = azuredevops_git_repository.repository.id
+	tag_folder    = "refs/tags/"
+	principal     = data.azuredevops_group.project-contributors.id
+	permissions   = {
+		CreateTag = "Deny"
+	}
+}
+`, testutils.HclGitRepoResource(projectName, gitRepoName, "Clean"))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("azuredevops_git_permissions.release-branches", "branch_folder", "refs/heads/release/*"),
+					resource.TestCheckResourceAttr("azuredevops_git_permissions.release-branches", "permissions.%", "2"),
+					resource.TestCheckResourceAttr("azuredevops_git_permissions.tags", "tag_folder", "refs/tags/"),
+					resource.TestCheckResourceAttr("azuredevops_git_permissions.tags", "permissions.%", "1"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,90 @@
+//go:build (all || core || resource_git_repository_files) && !exclude_resource_git_repository_files
+// +build all core resource_git_repository_files
+// +build !exclude_resource_git_repository_files
+
+package acceptancetests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+)
+
+func hclGitRepositoryFilesResource(projectName string, gitRepoName string, files string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuredevops_git_repository_files" "files" {
+  repository_id  = azuredevops_git_repository.repository.id
+  branch         = "refs/heads/master"
+  commit_message = "Scaffold repository"
+  files          = %s
+}
+`, testutils.HclGitRepoResource(projectName, gitRepoName, "Clean"), files)
+}
+
+// TestAccGitRepoFiles_CreateAndUpdate verifies that multiple files can be added to a repository,
+// that their contents can be updated and that files removed from the map are deleted
+func TestAccGitRepoFiles_CreateAndUpdate(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	gitRepoName := testutils.GenerateResourceName()
+	tfNode := "azuredevops_git_repository_files.files"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testutils.PreCheck(t, nil) },
+		Providers: testutils.GetProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: hclGitRepositoryFilesResource(projectName, gitRepoName, `{
+    "foo.txt"     = "foo"
+    "src/bar.txt" = "bar"
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "files.%", "2"),
+					resource.TestCheckResourceAttr(tfNode, "files.foo.txt", "foo"),
+					resource.TestCheckResourceAttr(tfNode, "files.src/bar.txt", "bar"),
+				),
+			},
+			{
+				Config: hclGitRepositoryFilesResource(projectName, gitRepoName, `{
+    "foo.txt" = "baz"
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "files.%", "1"),
+					resource.TestCheckResourceAttr(tfNode, "files.foo.txt", "baz"),
+					checkGitRepoFilesDeleted("src/bar.txt"),
+				),
+			},
+		},
+	})
+}
+
+func checkGitRepoFilesDeleted(fileName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		clients := testutils.GetProvider().Meta().(*client.AggregatedClient)
+
+		repo, ok := s.RootModule().Resources["azuredevops_git_repository.repository"]
+		if !ok {
+			return fmt.Errorf("Did not find a repo definition in the TF state")
+		}
+
+		_, err := clients.GitReposClient.GetItem(context.Background(), git.GetItemArgs{
+			RepositoryId: &repo.Primary.ID,
+			Path:         &fileName,
+		})
+		if err == nil {
+			return fmt.Errorf("File %s should have been deleted", fileName)
+		}
+		if !utils.ResponseWasNotFound(err) {
+			return err
+		}
+		return nil
+	}
+}
@@ -0,0 +1,112 @@
+//go:build (all || core || resource_git_repository_settings) && !exclude_resource_git_repository_settings
+// +build all core resource_git_repository_settings
+// +build !exclude_resource_git_repository_settings
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+func hclGitRepositorySettingsResource(projectName string, gitRepoName string, disabled bool, inheritPermissions bool) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuredevops_git_repository_settings" "settings" {
+  project_id          = azuredevops_project.project.id
+  repository_id       = azuredevops_git_repository.repository.id
+  disabled            = %t
+  inherit_permissions = %t
+}
+`, testutils.HclGitRepoResource(projectName, gitRepoName, "Clean"), disabled, inheritPermissions)
+}
+
+// TestAccGitRepositorySettings_CreateAndUpdate verifies that a repository can be disabled
+// and that the permission inheritance can be switched off and on again
+func TestAccGitRepositorySettings_CreateAndUpdate(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	gitRepoName := testutils.GenerateResourceName()
+
+	tfNode := "azuredevops_git_repository_settings.settings"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: hclGitRepositorySettingsResource(projectName, gitRepoName, false, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "repository_id"),
+					resource.TestCheckResourceAttr(tfNode, "disabled", "false"),
+					resource.TestCheckResourceAttr(tfNode, "inherit_permissions", "false"),
+				),
+			},
+			{
+				Config: hclGitRepositorySettingsResource(projectName, gitRepoName, true, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "disabled", "true"),
+					resource.TestCheckResourceAttr(tfNode, "inherit_permissions", "true"),
+				),
+			},
+			{
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testutils.ComputeProjectQualifiedResourceImportID(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func hclGitRepositorySettingsPolicyResource(projectName string, gitRepoName string, enabled bool) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuredevops_git_repository_settings" "settings" {
+  project_id                          = azuredevops_project.project.id
+  repository_id                       = azuredevops_git_repository.repository.id
+  forks_allowed                       = %[2]t
+  create_pull_request_suggestion      = %[2]t
+  commit_mention_linking              = %[2]t
+  commit_mention_work_item_resolution = %[2]t
+}
+`, testutils.HclGitRepoResource(projectName, gitRepoName, "Clean"), enabled)
+}
+
+// TestAccGitRepositorySettings_SettingsPolicy verifies that the settings stored in the repository settings policy
+// can be switched off and on again
+func TestAccGitRepositorySettings_SettingsPolicy(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	gitRepoName := testutils.GenerateResourceName()
+
+	tfNode := "azuredevops_git_repository_settings.settings"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: hclGitRepositorySettingsPolicyResource(projectName, gitRepoName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "forks_allowed", "false"),
+					resource.TestCheckResourceAttr(tfNode, "create_pull_request_suggestion", "false"),
+					resource.TestCheckResourceAttr(tfNode, "commit_mention_linking", "false"),
+					resource.TestCheckResourceAttr(tfNode, "commit_mention_work_item_resolution", "false"),
+				),
+			},
+			{
+				Config: hclGitRepositorySettingsPolicyResource(projectName, gitRepoName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "forks_allowed", "true"),
+					resource.TestCheckResourceAttr(tfNode, "create_pull_request_suggestion", "true"),
+					resource.TestCheckResourceAttr(tfNode, "commit_mention_linking", "true"),
+					resource.TestCheckResourceAttr(tfNode, "commit_mention_work_item_resolution", "true"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,87 @@
+//go:build (all || core || resource_git_repository_tag) && !exclude_resource_git_repository_tag
+// +build all core resource_git_repository_tag
+// +build !exclude_resource_git_repository_tag
+
+package acceptancetests
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// TestAccGitRepoTag_CreateAndImport verifies that annotated and lightweight tags
+// can be added to a repository and imported
+func TestAccGitRepoTag_CreateAndImport(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	gitRepoName := testutils.GenerateResourceName()
+	tagName := testutils.GenerateResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testutils.PreCheck(t, nil) },
+		Providers: testutils.GetProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: hclGitRepoTags(projectName, gitRepoName, tagName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("azuredevops_git_repository_tag.annotated", "name", fmt.Sprintf("annotated-%s", tagName)),
+					resource.TestCheckResourceAttr("azuredevops_git_repository_tag.annotated", "message", "Annotated tag"),
+					resource.TestCheckResourceAttr("azuredevops_git_repository_tag.annotated", "lightweight", "false"),
+					resource.TestCheckResourceAttrSet("azuredevops_git_repository_tag.annotated", "object_id"),
+					resource.TestCheckResourceAttrSet("azuredevops_git_repository_tag.annotated", "commit_id"),
+					resource.TestCheckResourceAttr("azuredevops_git_repository_tag.lightweight", "name", fmt.Sprintf("lightweight-%s", tagName)),
+					resource.TestCheckResourceAttr("azuredevops_git_repository_tag.lightweight", "lightweight", "true"),
+					resource.TestCheckResourceAttrPair("azuredevops_git_repository_tag.lightweight", "commit_id", "azuredevops_git_repository_tag.annotated", "commit_id"),
+				),
+			},
+			{
+				ResourceName:            "azuredevops_git_repository_tag.annotated",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ref_branch", "ref_commit_id"},
+			},
+			{
+				ResourceName:            "azuredevops_git_repository_tag.lightweight",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"ref_tag", "ref_commit_id"},
+			},
+			{
+				Config: fmt.Sprintf(`
+%s
+
+resource "azuredevops_git_repository_tag" "without_message" {
+  repository_id = azuredevops_git_repository.repository.id
+  name          = "without-message-%s"
+  ref_branch    = "master"
+}
+`, hclGitRepoTags(projectName, gitRepoName, tagName), tagName),
+				ExpectError: regexp.MustCompile(`A message is required for the annotated tag`),
+			},
+		},
+	})
+}
+
+func hclGitRepoTags(projectName, gitRepoName, tagName string) string {
+	gitRepoResource := testutils.HclGitRepoResource(projectName, gitRepoName, "Clean")
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuredevops_git_repository_tag" "annotated" {
+  repository_id = azuredevops_git_repository.repository.id
+  name          = "annotated-%[2]s"
+  ref_branch    = "master"
+  message       = "Annotated tag"
+}
+
+resource "azuredevops_git_repository_tag" "lightweight" {
+  repository_id = azuredevops_git_repository.repository.id
+  name          = "lightweight-%[2]s"
+  ref_tag       = azuredevops_git_repository_tag.annotated.name
+  lightweight   = true
+}
+`, gitRepoResource, tagName)
+}
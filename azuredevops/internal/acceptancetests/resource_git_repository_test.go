@@ -6,6 +6,7 @@ package acceptancetests
 
 import (
 	"fmt"
+	"os"
 	"regexp"
 	"testing"
 
@@ -232,6 +233,58 @@ func TestAccGitRepo_PrivateImport_BranchNotEmpty(t *testing.T) {
 	})
 }
 
+// TestAccGitRepo_PrivateImportCredentials_BranchNotEmpty verifies that a private repository can be imported
+// with inline credentials and that the import is completed when the resource is created
+func TestAccGitRepo_PrivateImportCredentials_BranchNotEmpty(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	gitRepoName := testutils.GenerateResourceName()
+	gitImportRepoName := testutils.GenerateResourceName()
+
+	tfImportRepoNode := "azuredevops_git_repository.import"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testutils.PreCheck(t, &[]string{
+				"AZDO_GENERIC_GIT_SERVICE_CONNECTION_USERNAME",
+				"AZDO_GENERIC_GIT_SERVICE_CONNECTION_PASSWORD",
+			})
+		},
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: checkGitRepoDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.HclProjectGitRepoImportCredentials(projectName, gitRepoName, gitImportRepoName, "",
+					os.Getenv("AZDO_GENERIC_GIT_SERVICE_CONNECTION_USERNAME"),
+					os.Getenv("AZDO_GENERIC_GIT_SERVICE_CONNECTION_PASSWORD")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfImportRepoNode, "name", gitImportRepoName),
+					resource.TestCheckResourceAttr(tfImportRepoNode, "default_branch", "refs/heads/master"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccGitRepo_Import_SurfacesFailure verifies that a failed import fails the creation of the repository
+func TestAccGitRepo_Import_SurfacesFailure(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	gitRepoName := testutils.GenerateResourceName()
+	gitImportRepoName := testutils.GenerateResourceName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: checkGitRepoDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.HclProjectGitRepoImportCredentials(projectName, gitRepoName, gitImportRepoName,
+					"https://dev.azure.com/does-not-exist/does-not-exist/_git/does-not-exist", "username", "password"),
+				ExpectError: regexp.MustCompile(`Import request \d+ has status failed`),
+			},
+		},
+	})
+}
+
 // or not the definition (1) exists in the state and (2) exist in AzDO and (3) has the correct name
 func checkGitRepoExists(expectedName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
@@ -0,0 +1,85 @@
+//go:build (all || resource_pipeline_authorization) && !exclude_resource_pipeline_authorization
+// +build all resource_pipeline_authorization
+// +build !exclude_resource_pipeline_authorization
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+func TestAccPipelineAuthorization_AllPipelines(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+
+	tfNode := "azuredevops_pipeline_authorization.test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testutils.PreCheck(t, nil) },
+		Providers: testutils.GetProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: hclPipelineAuthorizationAllPipelines(projectName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "project_id"),
+					resource.TestCheckResourceAttrSet(tfNode, "resource_id"),
+					resource.TestCheckResourceAttr(tfNode, "type", "environment"),
+					resource.TestCheckNoResourceAttr(tfNode, "pipeline_id"),
+				),
+			},
+			{
+				ResourceName:      tfNode,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccPipelineAuthorization_SinglePipeline(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	repositoryName := testutils.GenerateResourceName()
+	buildDefinitionName := testutils.GenerateResourceName()
+
+	tfNode := "azuredevops_pipeline_authorization.test"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testutils.PreCheck(t, nil) },
+		Providers: testutils.GetProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: hclPipelineAuthorizationSinglePipeline(projectName, repositoryName, buildDefinitionName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "type", "repository"),
+					resource.TestCheckResourceAttrPair(tfNode, "resource_id", "azuredevops_git_repository.repository", "id"),
+					resource.TestCheckResourceAttrPair(tfNode, "pipeline_id", "azuredevops_build_definition.build", "id"),
+				),
+			},
+		},
+	})
+}
+
+func hclPipelineAuthorizationAllPipelines(projectName string) string {
+	authorization := `
+resource "azuredevops_pipeline_authorization" "test" {
+  project_id  = azuredevops_project.project.id
+  resource_id = azuredevops_environment.environment.id
+  type        = "environment"
+}`
+
+	return fmt.Sprintf("%s\n%s", testutils.HclEnvironmentResource(projectName, "environment_test"), authorization)
+}
+
+func hclPipelineAuthorizationSinglePipeline(projectName string, repositoryName string, buildDefinitionName string) string {
+	authorization := `
+resource "azuredevops_pipeline_authorization" "test" {
+  project_id  = azuredevops_project.project.id
+  resource_id = azuredevops_git_repository.repository.id
+  type        = "repository"
+  pipeline_id = azuredevops_build_definition.build.id
+}`
+
+	buildDefinition := testutils.HclBuildDefinitionResourceTfsGit(projectName, repositoryName, buildDefinitionName, `\`)
+	return fmt.Sprintf("%s\n%s", buildDefinition, authorization)
+}
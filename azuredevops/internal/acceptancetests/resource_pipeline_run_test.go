@@ -0,0 +1,55 @@
+//go:build (all || resource_pipeline_run) && !exclude_resource_pipeline_run
+// +build all resource_pipeline_run
+// +build !exclude_resource_pipeline_run
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+// validates that a pipeline run is queued, waited for and queued again when its parameters change
+func TestAccPipelineRun_CreateAndUpdate(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	repoName := testutils.GenerateResourceName()
+	definitionName := testutils.GenerateResourceName()
+
+	tfNode := "azuredevops_pipeline_run.run"
+	var firstRunID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.HclPipelineRunResource(projectName, repoName, definitionName, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "name"),
+					resource.TestCheckResourceAttr(tfNode, "state", "completed"),
+					resource.TestCheckResourceAttr(tfNode, "result", "succeeded"),
+					func(s *terraform.State) error {
+						firstRunID = s.RootModule().Resources[tfNode].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: testutils.HclPipelineRunResource(projectName, repoName, definitionName, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "result", "succeeded"),
+					func(s *terraform.State) error {
+						if runID := s.RootModule().Resources[tfNode].Primary.ID; runID == firstRunID {
+							return fmt.Errorf("expected a new pipeline run, got run %s again", runID)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,132 @@
+//go:build (all || resource_release_definition) && !exclude_resource_release_definition
+// +build all resource_release_definition
+// +build !exclude_resource_release_definition
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+func hclReleaseDefinition(projectName string, repoName string, buildDefinitionName string, releaseDefinitionName string, stages string) string {
+	return fmt.Sprintf(`
+%s
+
+data "azuredevops_agent_queue" "queue" {
+  project_id = azuredevops_project.project.id
+  name       = "Azure Pipelines"
+}
+
+resource "azuredevops_release_definition" "release" {
+  project_id = azuredevops_project.project.id
+  name       = "%s"
+  path       = "\\"
+
+  variable {
+    name  = "environment"
+    value = "test"
+  }
+
+  artifact {
+    alias      = "_build"
+    type       = "Build"
+    is_primary = true
+    definition_reference = {
+      project    = azuredevops_project.project.id
+      definition = azuredevops_build_definition.build.id
+    }
+  }
+
+  artifact_trigger {
+    artifact_alias = "_build"
+    branch_filter {
+      branch = "refs/heads/master"
+    }
+  }
+
+%s
+}
+`, testutils.HclBuildDefinitionResourceTfsGit(projectName, repoName, buildDefinitionName, `\\`), releaseDefinitionName, stages)
+}
+
+const hclReleaseDefinitionDevStage = `
+  stage {
+    name     = "dev"
+    queue_id = data.azuredevops_agent_queue.queue.id
+
+    task {
+      task_id      = "d9bafed4-0b18-4f58-968d-86655b4d2ce9"
+      version      = "2.*"
+      display_name = "Deploy"
+      inputs = {
+        script = "echo deploy"
+      }
+    }
+  }
+`
+
+const hclReleaseDefinitionProdStage = `
+  stage {
+    name         = "prod"
+    queue_id     = data.azuredevops_agent_queue.queue.id
+    after_stages = ["dev"]
+
+    schedule {
+      days_to_release = ["Mon", "Wed"]
+      start_hours     = 4
+    }
+
+    retention_policy {
+      days_to_keep     = 60
+      releases_to_keep = 5
+      retain_build     = true
+    }
+  }
+`
+
+func TestAccReleaseDefinition_CreateAndUpdate(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	repoName := testutils.GenerateResourceName()
+	buildDefinitionName := testutils.GenerateResourceName()
+	releaseDefinitionName := testutils.GenerateResourceName()
+
+	tfNode := "azuredevops_release_definition.release"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: hclReleaseDefinition(projectName, repoName, buildDefinitionName, releaseDefinitionName, hclReleaseDefinitionDevStage),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(tfNode, "revision"),
+					resource.TestCheckResourceAttr(tfNode, "name", releaseDefinitionName),
+					resource.TestCheckResourceAttr(tfNode, "artifact.#", "1"),
+					resource.TestCheckResourceAttr(tfNode, "artifact_trigger.#", "1"),
+					resource.TestCheckResourceAttr(tfNode, "stage.#", "1"),
+					resource.TestCheckResourceAttrSet(tfNode, "stage.0.id"),
+					resource.TestCheckResourceAttr(tfNode, "stage.0.task.#", "1"),
+				),
+			},
+			{
+				Config: hclReleaseDefinition(projectName, repoName, buildDefinitionName, releaseDefinitionName, hclReleaseDefinitionDevStage+hclReleaseDefinitionProdStage),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "stage.#", "2"),
+					resource.TestCheckResourceAttr(tfNode, "stage.1.after_stages.0", "dev"),
+					resource.TestCheckResourceAttr(tfNode, "stage.1.schedule.0.days_to_release.#", "2"),
+					resource.TestCheckResourceAttr(tfNode, "stage.1.retention_policy.0.days_to_keep", "60"),
+				),
+			},
+			{
+				ResourceName:      tfNode,
+				ImportStateIdFunc: testutils.ComputeProjectQualifiedResourceImportID(tfNode),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
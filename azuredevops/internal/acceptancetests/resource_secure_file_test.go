@@ -0,0 +1,91 @@
+//go:build (all || resource_secure_file) && !exclude_resource_secure_file
+// +build all resource_secure_file
+// +build !exclude_resource_secure_file
+
+package acceptancetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/acceptancetests/testutils"
+)
+
+func hclSecureFile(projectName string, secureFileName string, content string, allowAccess bool) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azuredevops_secure_file" "secure_file" {
+  project_id     = azuredevops_project.project.id
+  name           = "%s"
+  content_base64 = base64encode("%s")
+  allow_access   = %t
+
+  properties = {
+    environment = "test"
+  }
+}
+
+data "azuredevops_secure_file" "secure_file" {
+  project_id = azuredevops_project.project.id
+  name       = azuredevops_secure_file.secure_file.name
+}
+
+data "azuredevops_group" "readers" {
+  project_id = azuredevops_project.project.id
+  name       = "Readers"
+}
+
+resource "azuredevops_secure_file_permissions" "permissions" {
+  project_id     = azuredevops_project.project.id
+  secure_file_id = azuredevops_secure_file.secure_file.id
+  principal      = data.azuredevops_group.readers.id
+  permissions = {
+    View = "allow"
+    Use  = "allow"
+  }
+}
+`, testutils.HclProjectResource(projectName), secureFileName, content, allowAccess)
+}
+
+func TestAccSecureFile_CreateAndUpdate(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	secureFileName := testutils.GenerateResourceName()
+
+	tfNode := "azuredevops_secure_file.secure_file"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: hclSecureFile(projectName, secureFileName, "content", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "name", secureFileName),
+					resource.TestCheckResourceAttr(tfNode, "allow_access", "false"),
+					resource.TestCheckResourceAttr(tfNode, "properties.environment", "test"),
+					// sha256 of "content"
+					resource.TestCheckResourceAttr(tfNode, "content_sha256", "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73"),
+					resource.TestCheckResourceAttrPair("data.azuredevops_secure_file.secure_file", "id", tfNode, "id"),
+					resource.TestCheckResourceAttr("azuredevops_secure_file_permissions.permissions", "permissions.%", "2"),
+				),
+			},
+			{
+				Config: hclSecureFile(projectName, secureFileName, "changed content", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(tfNode, "allow_access", "true"),
+					// sha256 of "changed content"
+					resource.TestCheckResourceAttr(tfNode, "content_sha256", "b92d13bbe02db7ca7686a8e7b854de49c7455948c05cf91a47044278395e212e"),
+				),
+			},
+			{
+				ResourceName:            tfNode,
+				ImportStateIdFunc:       testutils.ComputeProjectQualifiedResourceImportID(tfNode),
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"content_base64"},
+			},
+		},
+	})
+}
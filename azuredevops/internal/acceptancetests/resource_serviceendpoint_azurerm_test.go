@@ -134,3 +134,39 @@ func TestAccServiceEndpointAzureRm_AutomaticCreateAndUpdate(t *testing.T) {
 		},
 	})
 }
+
+// validates that a secret based service endpoint can be converted to workload identity federation in place
+func TestAccServiceEndpointAzureRm_WorkloadIdentityFederationConversion(t *testing.T) {
+	projectName := testutils.GenerateResourceName()
+	serviceEndpointName := testutils.GenerateResourceName()
+	serviceprincipalid := uuid.New().String()
+	serviceprincipalkey := uuid.New().String()
+
+	resourceType := "azuredevops_serviceendpoint_azurerm"
+	tfSvcEpNode := resourceType + ".serviceendpointrm"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testutils.PreCheck(t, nil) },
+		Providers:    testutils.GetProviders(),
+		CheckDestroy: testutils.CheckServiceEndpointDestroyed(resourceType),
+		Steps: []resource.TestStep{
+			{
+				Config: testutils.HclServiceEndpointAzureRMResource(projectName, serviceEndpointName, serviceprincipalid, serviceprincipalkey),
+				Check: resource.ComposeTestCheckFunc(
+					testutils.CheckServiceEndpointExistsWithName(tfSvcEpNode, serviceEndpointName),
+					resource.TestCheckResourceAttr(tfSvcEpNode, "service_endpoint_authentication_scheme", "ServicePrincipal"),
+					resource.TestCheckResourceAttr(tfSvcEpNode, "workload_identity_federation_issuer", ""),
+				),
+			},
+			{
+				Config: testutils.HclServiceEndpointAzureRMWorkloadIdentityFederationResource(projectName, serviceEndpointName, serviceprincipalid),
+				Check: resource.ComposeTestCheckFunc(
+					testutils.CheckServiceEndpointExistsWithName(tfSvcEpNode, serviceEndpointName),
+					resource.TestCheckResourceAttr(tfSvcEpNode, "service_endpoint_authentication_scheme", "WorkloadIdentityFederation"),
+					resource.TestCheckResourceAttr(tfSvcEpNode, "credentials.0.serviceprincipalid", serviceprincipalid),
+					resource.TestCheckResourceAttrSet(tfSvcEpNode, "workload_identity_federation_issuer"),
+					resource.TestCheckResourceAttrSet(tfSvcEpNode, "workload_identity_federation_subject"),
+				),
+			},
+		},
+	})
+}
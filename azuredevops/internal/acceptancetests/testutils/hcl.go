@@ -228,6 +228,27 @@ func HclProjectGitRepoImportPrivate(projectName, gitRepoName, gitImportRepoName,
 	return fmt.Sprintf("%s\n%s\n%s", gitRepoResource, serviceEndpointResource, importGitRepoResource)
 }
 
+// HclProjectGitRepoImportCredentials HCL describing an AzDO GIT repository imported with inline credentials
+func HclProjectGitRepoImportCredentials(projectName, gitRepoName, gitImportRepoName, sourceURL, username, password string) string {
+	gitRepoResource := HclGitRepoResource(projectName, gitRepoName, "Clean")
+	if sourceURL == "" {
+		sourceURL = "${azuredevops_git_repository.repository.remote_url}"
+	}
+	importGitRepoResource := fmt.Sprintf(`
+	resource "azuredevops_git_repository" "import" {
+		project_id      = azuredevops_project.project.id
+		name            = "%s"
+		initialization {
+		   init_type   = "Import"
+		   source_type = "Git"
+		   source_url  = "%s"
+		   username    = "%s"
+		   password    = "%s"
+		 }
+	}`, gitImportRepoName, sourceURL, username, password)
+	return fmt.Sprintf("%s\n%s", gitRepoResource, importGitRepoResource)
+}
+
 // HclUserEntitlementResource HCL describing an AzDO UserEntitlement
 func HclUserEntitlementResource(principalName string) string {
 	return fmt.Sprintf(`
@@ -466,6 +487,25 @@ resource "azuredevops_serviceendpoint_azurerm" "serviceendpointrm" {
 	return fmt.Sprintf("%s\n%s", projectResource, serviceEndpointResource)
 }
 
+// HclServiceEndpointAzureRMWorkloadIdentityFederationResource HCL describing an AzDO service endpoint using workload identity federation
+func HclServiceEndpointAzureRMWorkloadIdentityFederationResource(projectName string, serviceEndpointName string, serviceprincipalid string) string {
+	serviceEndpointResource := fmt.Sprintf(`
+resource "azuredevops_serviceendpoint_azurerm" "serviceendpointrm" {
+  project_id                             = azuredevops_project.project.id
+  service_endpoint_name                  = "%s"
+  service_endpoint_authentication_scheme = "WorkloadIdentityFederation"
+  credentials {
+    serviceprincipalid = "%s"
+  }
+  azurerm_spn_tenantid      = "9c59cbe5-2ca1-4516-b303-8968a070edd2"
+  azurerm_subscription_id   = "3b0fee91-c36d-4d70-b1e9-fc4b9d608c3d"
+  azurerm_subscription_name = "Microsoft Azure DEMO"
+}`, serviceEndpointName, serviceprincipalid)
+
+	projectResource := HclProjectResource(projectName)
+	return fmt.Sprintf("%s\n%s", projectResource, serviceEndpointResource)
+}
+
 // HclServiceEndpointAzureRMResourceMG HCL describing an AzDO service endpoint
 func HclServiceEndpointAzureRMResourceWithMG(projectName string, serviceEndpointName string, serviceprincipalid string, serviceprincipalkey string) string {
 	serviceEndpointResource := fmt.Sprintf(`
@@ -1067,3 +1107,35 @@ func HclEnvironmentResource(projectName string, environmentName string) string {
 	projectResource := HclProjectResource(projectName)
 	return fmt.Sprintf("%s\n%s", projectResource, azureEnvironmentResource)
 }
+
+// HclPipelineRunResource HCL describing a YAML pipeline which is run until it completes
+func HclPipelineRunResource(projectName string, repoName string, definitionName string, trigger string) string {
+	pipelineYaml := `trigger: none\nsteps:\n- script: echo run`
+	gitRepoFile := HclGitRepoFileResource(projectName, repoName, "Clean", "refs/heads/master", "azure-pipelines.yml", pipelineYaml)
+	return fmt.Sprintf(`
+%s
+
+resource "azuredevops_build_definition" "build" {
+  project_id = azuredevops_project.project.id
+  name       = "%s"
+
+  repository {
+    repo_type   = "TfsGit"
+    repo_id     = azuredevops_git_repository.repository.id
+    branch_name = azuredevops_git_repository.repository.default_branch
+    yml_path    = azuredevops_git_repository_file.file.file
+  }
+}
+
+resource "azuredevops_pipeline_run" "run" {
+  project_id          = azuredevops_project.project.id
+  definition_id       = azuredevops_build_definition.build.id
+  branch              = "master"
+  wait_for_completion = true
+
+  triggers = {
+    trigger = "%s"
+  }
+}
+`, gitRepoFile, definitionName, trigger)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/pipelineschecks"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
 )
 
 // CheckServiceEndpointExistsWithName verifies that a service endpoint of a particular type exists in the state,
@@ -36,6 +37,20 @@ func CheckPipelineCheckExistsWithName(tfNode string, expectedName string) resour
 	}
 }
 
+// CheckPipelineCheckExists verifies that a check exists in the state,
+// and that it can be found in Azure DevOps.
+func CheckPipelineCheckExists(tfNode string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resourceState, ok := s.RootModule().Resources[tfNode]
+		if !ok {
+			return fmt.Errorf("Did not find a check in the state")
+		}
+
+		_, err := getCheckFromState(resourceState)
+		return err
+	}
+}
+
 // CheckPipelineCheckDestroyed verifies that all checks of the given type in the state are destroyed.
 // This will be invoked *after* terraform destroys the resource but *before* the state is wiped clean.
 func CheckPipelineCheckDestroyed(resourceType string) resource.TestCheckFunc {
@@ -56,7 +71,7 @@ func CheckPipelineCheckDestroyed(resourceType string) resource.TestCheckFunc {
 }
 
 // given a resource from the state, return a check (and error)
-func getCheckFromState(resource *terraform.ResourceState) (*pipelineschecks.CheckConfiguration, error) {
+func getCheckFromState(resource *terraform.ResourceState) (*pipelineschecksextras.CheckConfiguration, error) {
 	branchControlCheckID, err := strconv.Atoi(resource.Primary.ID)
 	if err != nil {
 		return nil, err
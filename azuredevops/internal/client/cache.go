@@ -0,0 +1,267 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/core"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/graph"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/identity"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/operations"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/security"
+)
+
+// ReadCache caches lookups whose results are immutable or change rarely for the lifetime of a provider
+// instance: project name to id, subject descriptor to storage key and identity, and security namespace definitions.
+// Entries are invalidated by writes through the same AggregatedClient.
+//
+// A nil *ReadCache is valid and caches nothing.
+type ReadCache struct {
+	lock        sync.RWMutex
+	projectIDs  map[string]string
+	storageKeys map[string]uuid.UUID
+	descriptors map[uuid.UUID]string
+	identities  map[string]identity.Identity
+	namespaces  map[securityNamespaceKey][]security.SecurityNamespaceDescription
+}
+
+type securityNamespaceKey struct {
+	id        uuid.UUID
+	localOnly bool
+}
+
+// NewReadCache creates an empty ReadCache
+func NewReadCache() *ReadCache {
+	return &ReadCache{
+		projectIDs:  map[string]string{},
+		storageKeys: map[string]uuid.UUID{},
+		descriptors: map[uuid.UUID]string{},
+		identities:  map[string]identity.Identity{},
+		namespaces:  map[securityNamespaceKey][]security.SecurityNamespaceDescription{},
+	}
+}
+
+// ProjectID returns the cached id of the project with the given name
+func (c *ReadCache) ProjectID(projectName string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	id, ok := c.projectIDs[strings.ToLower(projectName)]
+	return id, ok
+}
+
+// SetProjectID caches the id of the project with the given name. Project names are case insensitive.
+func (c *ReadCache) SetProjectID(projectName string, projectID string) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.projectIDs[strings.ToLower(projectName)] = projectID
+}
+
+// InvalidateProject removes all names cached for the project with the given id
+func (c *ReadCache) InvalidateProject(projectID string) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for name, id := range c.projectIDs {
+		if strings.EqualFold(id, projectID) {
+			delete(c.projectIDs, name)
+		}
+	}
+}
+
+func (c *ReadCache) storageKey(descriptor string) (uuid.UUID, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	key, ok := c.storageKeys[descriptor]
+	return key, ok
+}
+
+func (c *ReadCache) descriptor(storageKey uuid.UUID) (string, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	descriptor, ok := c.descriptors[storageKey]
+	return descriptor, ok
+}
+
+func (c *ReadCache) setSubject(descriptor string, storageKey uuid.UUID) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.storageKeys[descriptor] = storageKey
+	c.descriptors[storageKey] = descriptor
+}
+
+func (c *ReadCache) invalidateSubject(descriptor string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if key, ok := c.storageKeys[descriptor]; ok {
+		delete(c.descriptors, key)
+	}
+	delete(c.storageKeys, descriptor)
+	delete(c.identities, descriptor)
+}
+
+// identitiesOf returns the cached identities of all given subject descriptors, in the same order
+func (c *ReadCache) identitiesOf(descriptors []string) ([]identity.Identity, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	identities := make([]identity.Identity, 0, len(descriptors))
+	for _, descriptor := range descriptors {
+		id, ok := c.identities[descriptor]
+		if !ok {
+			return nil, false
+		}
+		identities = append(identities, id)
+	}
+	return identities, true
+}
+
+func (c *ReadCache) setIdentities(identities []identity.Identity) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, id := range identities {
+		if id.SubjectDescriptor != nil {
+			c.identities[*id.SubjectDescriptor] = id
+		}
+	}
+}
+
+func (c *ReadCache) securityNamespaces(key securityNamespaceKey) ([]security.SecurityNamespaceDescription, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	namespaces, ok := c.namespaces[key]
+	return namespaces, ok
+}
+
+func (c *ReadCache) setSecurityNamespaces(key securityNamespaceKey, namespaces []security.SecurityNamespaceDescription) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.namespaces[key] = namespaces
+}
+
+// cachingCoreClient invalidates cached project ids when a project is changed
+type cachingCoreClient struct {
+	core.Client
+	cache *ReadCache
+}
+
+func (c *cachingCoreClient) UpdateProject(ctx context.Context, args core.UpdateProjectArgs) (*operations.OperationReference, error) {
+	if args.ProjectId != nil {
+		defer c.cache.InvalidateProject(args.ProjectId.String())
+	}
+	return c.Client.UpdateProject(ctx, args)
+}
+
+func (c *cachingCoreClient) QueueDeleteProject(ctx context.Context, args core.QueueDeleteProjectArgs) (*operations.OperationReference, error) {
+	if args.ProjectId != nil {
+		defer c.cache.InvalidateProject(args.ProjectId.String())
+	}
+	return c.Client.QueueDeleteProject(ctx, args)
+}
+
+// cachingGraphClient caches the mapping between subject descriptors and storage keys
+type cachingGraphClient struct {
+	graph.Client
+	cache *ReadCache
+}
+
+func (c *cachingGraphClient) GetStorageKey(ctx context.Context, args graph.GetStorageKeyArgs) (*graph.GraphStorageKeyResult, error) {
+	if args.SubjectDescriptor != nil {
+		if key, ok := c.cache.storageKey(*args.SubjectDescriptor); ok {
+			return &graph.GraphStorageKeyResult{Value: &key}, nil
+		}
+	}
+	result, err := c.Client.GetStorageKey(ctx, args)
+	if err == nil && result != nil && result.Value != nil && args.SubjectDescriptor != nil {
+		c.cache.setSubject(*args.SubjectDescriptor, *result.Value)
+	}
+	return result, err
+}
+
+func (c *cachingGraphClient) GetDescriptor(ctx context.Context, args graph.GetDescriptorArgs) (*graph.GraphDescriptorResult, error) {
+	if args.StorageKey != nil {
+		if descriptor, ok := c.cache.descriptor(*args.StorageKey); ok {
+			return &graph.GraphDescriptorResult{Value: &descriptor}, nil
+		}
+	}
+	result, err := c.Client.GetDescriptor(ctx, args)
+	if err == nil && result != nil && result.Value != nil && args.StorageKey != nil {
+		c.cache.setSubject(*result.Value, *args.StorageKey)
+	}
+	return result, err
+}
+
+func (c *cachingGraphClient) DeleteGroup(ctx context.Context, args graph.DeleteGroupArgs) error {
+	if args.GroupDescriptor != nil {
+		defer c.cache.invalidateSubject(*args.GroupDescriptor)
+	}
+	return c.Client.DeleteGroup(ctx, args)
+}
+
+func (c *cachingGraphClient) DeleteUser(ctx context.Context, args graph.DeleteUserArgs) error {
+	if args.UserDescriptor != nil {
+		defer c.cache.invalidateSubject(*args.UserDescriptor)
+	}
+	return c.Client.DeleteUser(ctx, args)
+}
+
+// cachingIdentityClient caches identities read by their subject descriptors
+type cachingIdentityClient struct {
+	identity.Client
+	cache *ReadCache
+}
+
+func (c *cachingIdentityClient) ReadIdentities(ctx context.Context, args identity.ReadIdentitiesArgs) (*[]identity.Identity, error) {
+	// only lookups by subject descriptors without membership or properties are cached
+	if args.SubjectDescriptors == nil || args != (identity.ReadIdentitiesArgs{SubjectDescriptors: args.SubjectDescriptors}) {
+		return c.Client.ReadIdentities(ctx, args)
+	}
+	if identities, ok := c.cache.identitiesOf(strings.Split(*args.SubjectDescriptors, ",")); ok {
+		return &identities, nil
+	}
+	result, err := c.Client.ReadIdentities(ctx, args)
+	if err == nil && result != nil {
+		c.cache.setIdentities(*result)
+	}
+	return result, err
+}
+
+// cachingSecurityClient caches security namespace definitions, which cannot be changed through the API
+type cachingSecurityClient struct {
+	security.Client
+	cache *ReadCache
+}
+
+func (c *cachingSecurityClient) QuerySecurityNamespaces(ctx context.Context, args security.QuerySecurityNamespacesArgs) (*[]security.SecurityNamespaceDescription, error) {
+	// only lookups of a single namespace are cached
+	if args.SecurityNamespaceId == nil {
+		return c.Client.QuerySecurityNamespaces(ctx, args)
+	}
+	key := securityNamespaceKey{id: *args.SecurityNamespaceId, localOnly: args.LocalOnly != nil && *args.LocalOnly}
+	if namespaces, ok := c.cache.securityNamespaces(key); ok {
+		result := append([]security.SecurityNamespaceDescription(nil), namespaces...)
+		return &result, nil
+	}
+	result, err := c.Client.QuerySecurityNamespaces(ctx, args)
+	if err == nil && result != nil {
+		c.cache.setSecurityNamespaces(key, append([]security.SecurityNamespaceDescription(nil), *result...))
+	}
+	return result, err
+}
+
+// withReadCache wraps the clients of an AggregatedClient so that they use and maintain the given cache
+func withReadCache(clients *AggregatedClient, cache *ReadCache) {
+	clients.ReadCache = cache
+	clients.CoreClient = &cachingCoreClient{Client: clients.CoreClient, cache: cache}
+	clients.GraphClient = &cachingGraphClient{Client: clients.GraphClient, cache: cache}
+	clients.IdentityClient = &cachingIdentityClient{Client: clients.IdentityClient, cache: cache}
+	clients.SecurityClient = &cachingSecurityClient{Client: clients.SecurityClient, cache: cache}
+}
@@ -0,0 +1,219 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/core"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/graph"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/identity"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/security"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+func newCachedTestClients(ctrl *gomock.Controller) (*AggregatedClient, *azdosdkmocks.MockCoreClient, *azdosdkmocks.MockGraphClient, *azdosdkmocks.MockSecurityClient) {
+	coreClient := azdosdkmocks.NewMockCoreClient(ctrl)
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	securityClient := azdosdkmocks.NewMockSecurityClient(ctrl)
+	clients := &AggregatedClient{
+		CoreClient:     coreClient,
+		GraphClient:    graphClient,
+		SecurityClient: securityClient,
+		Ctx:            context.Background(),
+	}
+	withReadCache(clients, NewReadCache())
+	return clients, coreClient, graphClient, securityClient
+}
+
+func TestReadCache_NilCacheCachesNothing(t *testing.T) {
+	var cache *ReadCache
+	cache.SetProjectID("project", uuid.New().String())
+	_, ok := cache.ProjectID("project")
+	require.False(t, ok)
+	cache.InvalidateProject(uuid.New().String())
+}
+
+func TestReadCache_ProjectIDsAreInvalidatedByProjectWrites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clients, coreClient, _, _ := newCachedTestClients(ctrl)
+	projectID := uuid.New()
+	clients.ReadCache.SetProjectID("Project", projectID.String())
+
+	id, ok := clients.ReadCache.ProjectID("project")
+	require.True(t, ok)
+	require.Equal(t, projectID.String(), id)
+
+	coreClient.
+		EXPECT().
+		UpdateProject(clients.Ctx, core.UpdateProjectArgs{ProjectId: &projectID}).
+		Return(nil, nil).
+		Times(1)
+	_, err := clients.CoreClient.UpdateProject(clients.Ctx, core.UpdateProjectArgs{ProjectId: &projectID})
+	require.Nil(t, err)
+
+	_, ok = clients.ReadCache.ProjectID("Project")
+	require.False(t, ok)
+
+	clients.ReadCache.SetProjectID("Project", projectID.String())
+	coreClient.
+		EXPECT().
+		QueueDeleteProject(clients.Ctx, core.QueueDeleteProjectArgs{ProjectId: &projectID}).
+		Return(nil, nil).
+		Times(1)
+	_, err = clients.CoreClient.QueueDeleteProject(clients.Ctx, core.QueueDeleteProjectArgs{ProjectId: &projectID})
+	require.Nil(t, err)
+
+	_, ok = clients.ReadCache.ProjectID("Project")
+	require.False(t, ok)
+}
+
+func TestReadCache_StorageKeysAndDescriptorsAreCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clients, _, graphClient, _ := newCachedTestClients(ctrl)
+	descriptor := "vssgp.Uy0xLTktMTU1MTM3NDI0NS0xMjA0NDAwOTY5"
+	storageKey := uuid.New()
+
+	graphClient.
+		EXPECT().
+		GetStorageKey(clients.Ctx, graph.GetStorageKeyArgs{SubjectDescriptor: &descriptor}).
+		Return(&graph.GraphStorageKeyResult{Value: &storageKey}, nil).
+		Times(1)
+
+	for i := 0; i < 3; i++ {
+		result, err := clients.GraphClient.GetStorageKey(clients.Ctx, graph.GetStorageKeyArgs{SubjectDescriptor: &descriptor})
+		require.Nil(t, err)
+		require.Equal(t, storageKey, *result.Value)
+	}
+
+	// the reverse lookup is served from the same entry
+	result, err := clients.GraphClient.GetDescriptor(clients.Ctx, graph.GetDescriptorArgs{StorageKey: &storageKey})
+	require.Nil(t, err)
+	require.Equal(t, descriptor, *result.Value)
+}
+
+func TestReadCache_SubjectsAreInvalidatedByGroupDeletion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clients, _, graphClient, _ := newCachedTestClients(ctrl)
+	descriptor := "vssgp.Uy0xLTktMTU1MTM3NDI0NS0xMjA0NDAwOTY5"
+	storageKey := uuid.New()
+
+	graphClient.
+		EXPECT().
+		GetDescriptor(clients.Ctx, graph.GetDescriptorArgs{StorageKey: &storageKey}).
+		Return(&graph.GraphDescriptorResult{Value: &descriptor}, nil).
+		Times(2)
+	graphClient.
+		EXPECT().
+		DeleteGroup(clients.Ctx, graph.DeleteGroupArgs{GroupDescriptor: &descriptor}).
+		Return(nil).
+		Times(1)
+
+	_, err := clients.GraphClient.GetDescriptor(clients.Ctx, graph.GetDescriptorArgs{StorageKey: &storageKey})
+	require.Nil(t, err)
+	err = clients.GraphClient.DeleteGroup(clients.Ctx, graph.DeleteGroupArgs{GroupDescriptor: &descriptor})
+	require.Nil(t, err)
+	_, err = clients.GraphClient.GetDescriptor(clients.Ctx, graph.GetDescriptorArgs{StorageKey: &storageKey})
+	require.Nil(t, err)
+}
+
+func TestReadCache_IdentitiesAreCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clients, _, graphClient, _ := newCachedTestClients(ctrl)
+	identityClient := azdosdkmocks.NewMockIdentityClient(ctrl)
+	clients.IdentityClient = &cachingIdentityClient{Client: identityClient, cache: clients.ReadCache}
+	group := identity.Identity{Id: &uuid.UUID{}, SubjectDescriptor: converter.String("vssgp.Uy0xLTktMTU1MTM3NDI0NS0x")}
+	user := identity.Identity{Id: &uuid.UUID{}, SubjectDescriptor: converter.String("aad.ZjM2MGQ4NjEtMjUxNy03")}
+
+	identityClient.
+		EXPECT().
+		ReadIdentities(clients.Ctx, identity.ReadIdentitiesArgs{SubjectDescriptors: converter.String(*group.SubjectDescriptor + "," + *user.SubjectDescriptor)}).
+		Return(&[]identity.Identity{group, user}, nil).
+		Times(1)
+
+	// every lookup of the descriptors is served from the cache, in the requested order
+	for _, descriptors := range []string{*group.SubjectDescriptor + "," + *user.SubjectDescriptor, *user.SubjectDescriptor} {
+		result, err := clients.IdentityClient.ReadIdentities(clients.Ctx, identity.ReadIdentitiesArgs{SubjectDescriptors: &descriptors})
+		require.Nil(t, err)
+		require.Equal(t, len(strings.Split(descriptors, ",")), len(*result))
+		require.Equal(t, *user.SubjectDescriptor, *(*result)[len(*result)-1].SubjectDescriptor)
+	}
+
+	// lookups with membership are not cached
+	membership := identity.QueryMembershipValues.Direct
+	identityClient.
+		EXPECT().
+		ReadIdentities(clients.Ctx, identity.ReadIdentitiesArgs{SubjectDescriptors: user.SubjectDescriptor, QueryMembership: &membership}).
+		Return(&[]identity.Identity{user}, nil).
+		Times(1)
+	_, err := clients.IdentityClient.ReadIdentities(clients.Ctx, identity.ReadIdentitiesArgs{SubjectDescriptors: user.SubjectDescriptor, QueryMembership: &membership})
+	require.Nil(t, err)
+
+	// the identity of a deleted group is read again
+	graphClient.
+		EXPECT().
+		DeleteGroup(clients.Ctx, graph.DeleteGroupArgs{GroupDescriptor: group.SubjectDescriptor}).
+		Return(nil).
+		Times(1)
+	identityClient.
+		EXPECT().
+		ReadIdentities(clients.Ctx, identity.ReadIdentitiesArgs{SubjectDescriptors: group.SubjectDescriptor}).
+		Return(&[]identity.Identity{group}, nil).
+		Times(1)
+	require.Nil(t, clients.GraphClient.DeleteGroup(clients.Ctx, graph.DeleteGroupArgs{GroupDescriptor: group.SubjectDescriptor}))
+	_, err = clients.IdentityClient.ReadIdentities(clients.Ctx, identity.ReadIdentitiesArgs{SubjectDescriptors: group.SubjectDescriptor})
+	require.Nil(t, err)
+}
+
+func TestReadCache_SecurityNamespacesAreCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clients, _, _, securityClient := newCachedTestClients(ctrl)
+	namespaceID := uuid.New()
+	name := "Git Repositories"
+	namespaces := []security.SecurityNamespaceDescription{{NamespaceId: &namespaceID, Name: &name}}
+
+	securityClient.
+		EXPECT().
+		QuerySecurityNamespaces(clients.Ctx, security.QuerySecurityNamespacesArgs{SecurityNamespaceId: &namespaceID}).
+		Return(&namespaces, nil).
+		Times(1)
+
+	for i := 0; i < 3; i++ {
+		result, err := clients.SecurityClient.QuerySecurityNamespaces(clients.Ctx, security.QuerySecurityNamespacesArgs{SecurityNamespaceId: &namespaceID})
+		require.Nil(t, err)
+		require.Equal(t, namespaces, *result)
+	}
+}
+
+func TestReadCache_FailedLookupsAreNotCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clients, _, graphClient, _ := newCachedTestClients(ctrl)
+	descriptor := "vssgp.Uy0xLTktMTU1MTM3NDI0NS0xMjA0NDAwOTY5"
+
+	graphClient.
+		EXPECT().
+		GetStorageKey(clients.Ctx, graph.GetStorageKeyArgs{SubjectDescriptor: &descriptor}).
+		Return(nil, context.DeadlineExceeded).
+		Times(2)
+
+	for i := 0; i < 2; i++ {
+		_, err := clients.GraphClient.GetStorageKey(clients.Ctx, graph.GetStorageKeyArgs{SubjectDescriptor: &descriptor})
+		require.NotNil(t, err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"strings"
 
@@ -20,13 +21,17 @@ import (
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/identity"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/memberentitlementmanagement"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/operations"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/pipelinepermissions"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/pipelines"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/policy"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/release"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/security"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/serviceendpoint"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/taskagent"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/workitemtracking"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/gitextras"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/taskagentextras"
 	"github.com/microsoft/terraform-provider-azuredevops/version"
 )
 
@@ -42,9 +47,12 @@ type AggregatedClient struct {
 	CoreClient                    core.Client
 	BuildClient                   build.Client
 	GitReposClient                git.Client
+	GitClientExtras               gitextras.Client
 	GraphClient                   graph.Client
 	V5GraphClient                 v5graph.Client
 	OperationsClient              operations.Client
+	PipelinePermissionsClient     pipelinepermissions.Client
+	PipelinesClient               pipelines.Client
 	V5PipelinesChecksClient       v5pipelineschecks.Client
 	V5PipelinesChecksClientExtras pipelineschecksextras.Client
 	PolicyClient                  policy.Client
@@ -52,16 +60,19 @@ type AggregatedClient struct {
 	ServiceEndpointClient         serviceendpoint.Client
 	TaskAgentClient               taskagent.Client
 	V5TaskAgentClient             v5taskagent.Client
+	TaskAgentClientExtras         taskagentextras.Client
 	MemberEntitleManagementClient memberentitlementmanagement.Client
 	FeatureManagementClient       featuremanagement.Client
 	SecurityClient                security.Client
 	IdentityClient                identity.Client
 	WorkItemTrackingClient        workitemtracking.Client
+	ReadCache                     *ReadCache
 	Ctx                           context.Context
 }
 
-// GetAzdoClient builds and provides a connection to the Azure DevOps API
-func GetAzdoClient(azdoPAT string, organizationURL string, tfVersion string) (*AggregatedClient, error) {
+// GetAzdoClient builds and provides a connection to the Azure DevOps API. Requests are retried and throttled
+// according to retryOptions, if set. Lookups are served from a ReadCache if enableReadCache is true.
+func GetAzdoClient(azdoPAT string, organizationURL string, tfVersion string, retryOptions *RetryOptions, enableReadCache bool) (*AggregatedClient, error) {
 	ctx := context.Background()
 
 	if strings.EqualFold(azdoPAT, "") {
@@ -77,6 +88,15 @@ func GetAzdoClient(azdoPAT string, organizationURL string, tfVersion string) (*A
 
 	v5Connection := v5api.NewPatConnection(organizationURL, azdoPAT)
 
+	// The transport is set on the organization clients first, so that the resource area and location lookups
+	// made while creating the other clients are retried as well
+	var transport http.RoundTripper
+	if retryOptions != nil {
+		transport = NewRetryTransport(nil, *retryOptions)
+		setClientTransport(connection.GetClientByUrl(connection.BaseUrl), transport)
+		setClientTransport(v5Connection.GetClientByUrl(v5Connection.BaseUrl), transport)
+	}
+
 	// client for these APIs (includes CRUD for AzDO projects...):
 	//	https://docs.microsoft.com/en-us/rest/api/azure/devops/core/?view=azure-devops-rest-5.1
 	coreClient, err := core.NewClient(ctx, connection)
@@ -117,6 +137,13 @@ func GetAzdoClient(azdoPAT string, organizationURL string, tfVersion string) (*A
 		log.Printf("getAzdoClient(): taskagent.NewClient failed.")
 		return nil, err
 	}
+	// client for these APIs (includes CRUD for AzDO secure files):
+	//	https://learn.microsoft.com/en-us/rest/api/azure/devops/distributedtask/securefiles?view=azure-devops-rest-6.0
+	taskAgentClientExtras, err := taskagentextras.NewClient(ctx, connection)
+	if err != nil {
+		log.Printf("getAzdoClient(): taskagentextras.NewClient failed.")
+		return nil, err
+	}
 
 	// client for these APIs:
 	//	https://docs.microsoft.com/en-us/rest/api/azure/devops/git/?view=azure-devops-rest-5.1
@@ -125,6 +152,13 @@ func GetAzdoClient(azdoPAT string, organizationURL string, tfVersion string) (*A
 		log.Printf("getAzdoClient(): git.NewClient failed.")
 		return nil, err
 	}
+	// client for the repository disabled state, which is not covered by the git client:
+	//	https://learn.microsoft.com/en-us/rest/api/azure/devops/git/repositories/update?view=azure-devops-rest-6.0
+	gitClientExtras, err := gitextras.NewClient(ctx, connection)
+	if err != nil {
+		log.Printf("getAzdoClient(): gitextras.NewClient failed.")
+		return nil, err
+	}
 
 	//  https://docs.microsoft.com/en-us/rest/api/azure/devops/graph/?view=azure-devops-rest-5.1
 	graphClient, err := graph.NewClient(ctx, connection)
@@ -176,6 +210,16 @@ func GetAzdoClient(azdoPAT string, organizationURL string, tfVersion string) (*A
 		return nil, err
 	}
 
+	// https://learn.microsoft.com/en-us/rest/api/azure/devops/approvalsandchecks/pipeline-permissions?view=azure-devops-rest-6.0
+	pipelinePermissionsClient, err := pipelinepermissions.NewClient(ctx, connection)
+	if err != nil {
+		log.Printf("getAzdoClient(): pipelinepermissions.NewClient failed.")
+		return nil, err
+	}
+
+	// https://learn.microsoft.com/en-us/rest/api/azure/devops/pipelines/runs?view=azure-devops-rest-6.0
+	pipelinesClient := pipelines.NewClient(ctx, connection)
+
 	v5PipelinesChecksClient, err := v5pipelineschecks.NewClient(ctx, v5Connection)
 	if err != nil {
 		log.Printf("getAzdoClient(): v5pipelineschecks.NewClient failed.")
@@ -193,9 +237,12 @@ func GetAzdoClient(azdoPAT string, organizationURL string, tfVersion string) (*A
 		CoreClient:                    coreClient,
 		BuildClient:                   buildClient,
 		GitReposClient:                gitReposClient,
+		GitClientExtras:               gitClientExtras,
 		GraphClient:                   graphClient,
 		V5GraphClient:                 v5GraphClient,
 		OperationsClient:              operationsClient,
+		PipelinePermissionsClient:     pipelinePermissionsClient,
+		PipelinesClient:               pipelinesClient,
 		V5PipelinesChecksClient:       v5PipelinesChecksClient,
 		V5PipelinesChecksClientExtras: v5PipelinesChecksClientExtras,
 		PolicyClient:                  policyClient,
@@ -203,6 +250,7 @@ func GetAzdoClient(azdoPAT string, organizationURL string, tfVersion string) (*A
 		ServiceEndpointClient:         serviceEndpointClient,
 		TaskAgentClient:               taskagentClient,
 		V5TaskAgentClient:             v5TaskAgentClient,
+		TaskAgentClientExtras:         taskAgentClientExtras,
 		MemberEntitleManagementClient: memberentitlementmanagementClient,
 		FeatureManagementClient:       featuremanagementClient,
 		SecurityClient:                securityClient,
@@ -211,6 +259,22 @@ func GetAzdoClient(azdoPAT string, organizationURL string, tfVersion string) (*A
 		Ctx:                           ctx,
 	}
 
+	if transport != nil {
+		for _, sdkClient := range []interface{}{
+			coreClient, buildClient, gitReposClient, gitClientExtras, graphClient, v5GraphClient, operationsClient,
+			pipelinePermissionsClient, pipelinesClient, v5PipelinesChecksClient, v5PipelinesChecksClientExtras,
+			policyClient, releaseClient, serviceEndpointClient, taskagentClient, v5TaskAgentClient,
+			taskAgentClientExtras, memberentitlementmanagementClient, featuremanagementClient, securityClient,
+			identityClient, workitemtrackingClient,
+		} {
+			setClientTransport(sdkClient, transport)
+		}
+	}
+
+	if enableReadCache {
+		withReadCache(aggregatedClient, NewReadCache())
+	}
+
 	log.Printf("getAzdoClient(): Created core, build, operations, and serviceendpoint clients successfully!")
 	return aggregatedClient, nil
 }
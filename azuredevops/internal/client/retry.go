@@ -0,0 +1,222 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"log"
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+// RetryOptions configures how requests to Azure DevOps are retried and throttled
+type RetryOptions struct {
+	// MaxRetries is the number of times a throttled or failed request is retried
+	MaxRetries int
+	// MinBackoff is the delay before the first retry when the service does not ask for a specific delay
+	MinBackoff time.Duration
+	// MaxBackoff caps the exponential backoff between retries
+	MaxBackoff time.Duration
+	// MaxConcurrentRequests limits the number of outstanding requests. 0 means unlimited.
+	MaxConcurrentRequests int
+}
+
+// retryTransport is a http.RoundTripper retrying requests that were throttled or failed on the server side
+type retryTransport struct {
+	next      http.RoundTripper
+	options   RetryOptions
+	semaphore chan struct{}
+	now       func() time.Time
+}
+
+// NewRetryTransport wraps a http.RoundTripper so that requests answered with 429, a TF400733 error, or a 5xx
+// error for idempotent methods are retried, honoring the Retry-After and X-RateLimit-Reset headers, and that no more than
+// MaxConcurrentRequests requests are outstanding at a time.
+func NewRetryTransport(next http.RoundTripper, options RetryOptions) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	t := &retryTransport{
+		next:    next,
+		options: options,
+		now:     time.Now,
+	}
+	if options.MaxConcurrentRequests > 0 {
+		t.semaphore = make(chan struct{}, options.MaxConcurrentRequests)
+	}
+	return t
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The request body is consumed by every attempt, so it has to be replayable
+	getBody := req.GetBody
+	if req.Body != nil && req.Body != http.NoBody && getBody == nil {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		getBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(body)), nil
+		}
+		req = req.Clone(req.Context())
+		req.Body, _ = getBody()
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.send(req)
+		if err != nil || attempt >= t.options.MaxRetries || !isRetryableResponse(req, resp) {
+			return resp, err
+		}
+
+		delay := t.retryDelay(resp, attempt)
+		log.Printf("[DEBUG] %s %s returned %s, retrying in %s (attempt %d of %d)", req.Method, req.URL.Redacted(), resp.Status, delay, attempt+1, t.options.MaxRetries)
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+
+		if err := sleepWithContext(req.Context(), delay); err != nil {
+			return nil, err
+		}
+
+		if getBody != nil {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// send performs a single attempt, holding a concurrency slot for the duration of the round trip
+func (t *retryTransport) send(req *http.Request) (*http.Response, error) {
+	if t.semaphore != nil {
+		select {
+		case t.semaphore <- struct{}{}:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		defer func() { <-t.semaphore }()
+	}
+	return t.next.RoundTrip(req)
+}
+
+// retryDelay returns how long to wait before the next attempt. A delay requested by the service takes
+// precedence over the exponential backoff.
+func (t *retryTransport) retryDelay(resp *http.Response, attempt int) time.Duration {
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return nonNegative(date.Sub(t.now()))
+		}
+	}
+	if reset := resp.Header.Get("X-RateLimit-Reset"); reset != "" {
+		if epoch, err := strconv.ParseInt(reset, 10, 64); err == nil {
+			return nonNegative(time.Unix(epoch, 0).Sub(t.now()))
+		}
+	}
+
+	backoff := float64(t.options.MinBackoff) * math.Pow(2, float64(attempt))
+	if t.options.MaxBackoff > 0 && backoff > float64(t.options.MaxBackoff) {
+		return t.options.MaxBackoff
+	}
+	return time.Duration(backoff)
+}
+
+// isRetryableResponse reports whether the request was throttled or failed in a way that is worth retrying. A
+// server error may have been raised after a POST or PATCH request was applied, so those requests are only retried
+// if the service asks for it.
+func isRetryableResponse(req *http.Request, resp *http.Response) bool {
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode == http.StatusNotImplemented || resp.StatusCode == http.StatusHTTPVersionNotSupported:
+		return false
+	case resp.StatusCode >= 500:
+		if isIdempotent(req.Method) {
+			return true
+		}
+		return resp.StatusCode == http.StatusServiceUnavailable && resp.Header.Get("Retry-After") != ""
+	case resp.StatusCode >= 400:
+		// TF400733 is returned for requests that were canceled before they were processed
+		return responseBodyContains(resp, "TF400733")
+	}
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// responseBodyContains checks the response body for a message without consuming it
+func responseBodyContains(resp *http.Response, message string) bool {
+	if resp.Body == nil {
+		return false
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return err == nil && strings.Contains(string(body), message)
+}
+
+func sleepWithContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// The Azure DevOps SDK creates an http.Client for every API area, which is shared by all clients of a
+// connection for that area, and does not allow a transport to be injected into it. setClientTransport looks up
+// that http.Client in an SDK client, or a client embedding one, and replaces its transport. This keeps the
+// retry options scoped to the clients of a single connection instead of every user of http.DefaultTransport.
+func setClientTransport(client interface{}, transport http.RoundTripper) {
+	setHTTPClientTransport(reflect.ValueOf(client), transport)
+}
+
+func setHTTPClientTransport(value reflect.Value, transport http.RoundTripper) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct || !value.CanAddr() {
+		return
+	}
+
+	if field := value.FieldByName("client"); field.IsValid() && field.Type() == reflect.TypeOf(&http.Client{}) {
+		httpClient := reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem().Interface().(*http.Client)
+		if httpClient != nil {
+			httpClient.Transport = transport
+		}
+		return
+	}
+	if field := value.FieldByName("Client"); field.IsValid() {
+		setHTTPClientTransport(field, transport)
+	}
+}
@@ -0,0 +1,288 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v5api "github.com/microsoft/azure-devops-go-api/azuredevops"
+	v5taskagent "github.com/microsoft/azure-devops-go-api/azuredevops/taskagent"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/core"
+	"github.com/stretchr/testify/require"
+)
+
+var testRetryOptions = RetryOptions{
+	MaxRetries: 3,
+	MinBackoff: time.Millisecond,
+	MaxBackoff: 5 * time.Millisecond,
+}
+
+// newFlakyServer returns a server answering the first failures requests with the given status and headers
+func newFlakyServer(t *testing.T, failures int32, status int, header http.Header, body string) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(status)
+			fmt.Fprint(w, body)
+			return
+		}
+		fmt.Fprint(w, "ok")
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestRetryTransport_RetriesThrottledRequests(t *testing.T) {
+	server, calls := newFlakyServer(t, 2, http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}}, "")
+	client := &http.Client{Transport: NewRetryTransport(http.DefaultTransport, testRetryOptions)}
+
+	resp, err := client.Get(server.URL)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
+
+func TestRetryTransport_RetriesServerErrors(t *testing.T) {
+	server, calls := newFlakyServer(t, 1, http.StatusServiceUnavailable, nil, "")
+	client := &http.Client{Transport: NewRetryTransport(http.DefaultTransport, testRetryOptions)}
+
+	resp, err := client.Get(server.URL)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestRetryTransport_RetriesTF400733(t *testing.T) {
+	server, calls := newFlakyServer(t, 1, http.StatusBadRequest, nil, `{"message":"TF400733: The request has been canceled"}`)
+	client := &http.Client{Transport: NewRetryTransport(http.DefaultTransport, testRetryOptions)}
+
+	resp, err := client.Get(server.URL)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestRetryTransport_GivesUpAfterMaxRetries(t *testing.T) {
+	server, calls := newFlakyServer(t, 100, http.StatusBadGateway, nil, "")
+	client := &http.Client{Transport: NewRetryTransport(http.DefaultTransport, testRetryOptions)}
+
+	resp, err := client.Get(server.URL)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	require.Equal(t, int32(testRetryOptions.MaxRetries+1), atomic.LoadInt32(calls))
+}
+
+func TestRetryTransport_DoesNotRetryClientErrors(t *testing.T) {
+	server, calls := newFlakyServer(t, 1, http.StatusNotFound, nil, "VS800075: not found")
+	client := &http.Client{Transport: NewRetryTransport(http.DefaultTransport, testRetryOptions)}
+
+	resp, err := client.Get(server.URL)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	require.Equal(t, int32(1), atomic.LoadInt32(calls))
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.Nil(t, err)
+	require.Equal(t, "VS800075: not found", string(body))
+}
+
+// verifies that a POST request is not sent again after a server error, as it may have been applied
+func TestRetryTransport_DoesNotRetryServerErrorsOfPostRequests(t *testing.T) {
+	server, calls := newFlakyServer(t, 1, http.StatusInternalServerError, nil, "")
+	client := &http.Client{Transport: NewRetryTransport(http.DefaultTransport, testRetryOptions)}
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader("{}"))
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	require.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+// verifies that a POST request is retried if the service asks for it
+func TestRetryTransport_RetriesPostRequestsWhenAskedTo(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Status int
+		Header http.Header
+	}{
+		{"TooManyRequests", http.StatusTooManyRequests, nil},
+		{"ServiceUnavailableWithRetryAfter", http.StatusServiceUnavailable, http.Header{"Retry-After": {"0"}}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			server, calls := newFlakyServer(t, 1, c.Status, c.Header, "")
+			client := &http.Client{Transport: NewRetryTransport(http.DefaultTransport, testRetryOptions)}
+
+			resp, err := client.Post(server.URL, "application/json", strings.NewReader("{}"))
+			require.Nil(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			require.Equal(t, int32(2), atomic.LoadInt32(calls))
+		})
+	}
+}
+
+func TestRetryTransport_ReplaysRequestBody(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		require.Equal(t, "payload", string(body))
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: NewRetryTransport(http.DefaultTransport, testRetryOptions)}
+
+	// a reader without GetBody support forces the transport to buffer the body
+	req, err := http.NewRequest("PUT", server.URL, ioutil.NopCloser(strings.NewReader("payload")))
+	require.Nil(t, err)
+	resp, err := client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestRetryTransport_LimitsConcurrentRequests(t *testing.T) {
+	var outstanding, maxOutstanding int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&outstanding, 1)
+		defer atomic.AddInt32(&outstanding, -1)
+		for {
+			observed := atomic.LoadInt32(&maxOutstanding)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxOutstanding, observed, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	options := testRetryOptions
+	options.MaxConcurrentRequests = 2
+	client := &http.Client{Transport: NewRetryTransport(http.DefaultTransport, options)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			require.Nil(t, err)
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+	require.LessOrEqual(t, atomic.LoadInt32(&maxOutstanding), int32(2))
+}
+
+func TestRetryTransport_StopsWhenContextIsCanceled(t *testing.T) {
+	server, calls := newFlakyServer(t, 100, http.StatusTooManyRequests, http.Header{"Retry-After": {"3600"}}, "")
+	client := &http.Client{Transport: NewRetryTransport(http.DefaultTransport, testRetryOptions)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", server.URL, nil)
+	require.Nil(t, err)
+
+	_, err = client.Do(req)
+	require.NotNil(t, err)
+	require.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestRetryTransport_RetryDelay(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	transport := &retryTransport{
+		options: RetryOptions{MinBackoff: time.Second, MaxBackoff: 10 * time.Second},
+		now:     func() time.Time { return now },
+	}
+
+	cases := []struct {
+		Name     string
+		Header   http.Header
+		Attempt  int
+		Expected time.Duration
+	}{
+		{"RetryAfterSeconds", http.Header{"Retry-After": {"7"}}, 0, 7 * time.Second},
+		{"RetryAfterDate", http.Header{"Retry-After": {now.Add(30 * time.Second).Format(http.TimeFormat)}}, 0, 30 * time.Second},
+		{"RateLimitReset", http.Header{"X-Ratelimit-Reset": {strconv.FormatInt(now.Add(20*time.Second).Unix(), 10)}}, 0, 20 * time.Second},
+		{"RateLimitResetInThePast", http.Header{"X-Ratelimit-Reset": {strconv.FormatInt(now.Add(-time.Minute).Unix(), 10)}}, 0, 0},
+		{"FirstBackoff", http.Header{}, 0, time.Second},
+		{"ExponentialBackoff", http.Header{}, 2, 4 * time.Second},
+		{"CappedBackoff", http.Header{}, 6, 10 * time.Second},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			delay := transport.retryDelay(&http.Response{Header: c.Header}, c.Attempt)
+			require.Equal(t, c.Expected, delay)
+		})
+	}
+}
+
+// verifies that the transport is set on the SDK clients it is applied to and not on clients of other connections
+func TestRetryTransport_AppliesToSDKClients(t *testing.T) {
+	sendRequest := func(sdkClient *azuredevops.Client, url string) error {
+		req, err := sdkClient.CreateRequestMessage(context.Background(), http.MethodGet, url, "6.0", nil, "", azuredevops.MediaTypeApplicationJson, nil)
+		require.Nil(t, err)
+		resp, err := sdkClient.SendRequest(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	server, calls := newFlakyServer(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}}, "")
+	connection := azuredevops.NewPatConnection(server.URL, "a-pat")
+	coreClient := &core.ClientImpl{Client: *connection.GetClientByUrl(server.URL)}
+	setClientTransport(coreClient, NewRetryTransport(nil, testRetryOptions))
+
+	require.Nil(t, sendRequest(&coreClient.Client, server.URL))
+	require.Equal(t, int32(2), atomic.LoadInt32(calls))
+
+	// clients of the same connection share the http.Client of the area
+	atomic.StoreInt32(calls, 0)
+	require.Nil(t, sendRequest(connection.GetClientByUrl(server.URL), server.URL))
+	require.Equal(t, int32(2), atomic.LoadInt32(calls))
+
+	// clients of other connections, even with the same personal access token, are left alone
+	atomic.StoreInt32(calls, 0)
+	otherConnection := azuredevops.NewPatConnection(server.URL, "a-pat")
+	require.NotNil(t, sendRequest(otherConnection.GetClientByUrl(server.URL), server.URL))
+	require.Equal(t, int32(1), atomic.LoadInt32(calls))
+	require.IsType(t, &http.Transport{}, http.DefaultTransport)
+}
+
+// verifies that the transport is also set on clients of the 5.1 SDK
+func TestRetryTransport_AppliesToV5SDKClients(t *testing.T) {
+	server, calls := newFlakyServer(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}}, "")
+	connection := v5api.NewPatConnection(server.URL, "a-pat")
+	taskAgentClient := &v5taskagent.ClientImpl{Client: *connection.GetClientByUrl(server.URL)}
+	setClientTransport(taskAgentClient, NewRetryTransport(nil, testRetryOptions))
+
+	req, err := taskAgentClient.Client.CreateRequestMessage(context.Background(), http.MethodGet, server.URL, "5.1", nil, "", v5api.MediaTypeApplicationJson, nil)
+	require.Nil(t, err)
+	resp, err := taskAgentClient.Client.SendRequest(req)
+	require.Nil(t, err)
+	resp.Body.Close()
+	require.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
@@ -8,6 +8,8 @@ type repoTypeValuesType struct {
 	TfsGit           RepoType
 	Bitbucket        RepoType
 	GitHubEnterprise RepoType
+	Git              RepoType
+	Svn              RepoType
 }
 
 // RepoTypeValues enum of the type of the repository
@@ -16,4 +18,6 @@ var RepoTypeValues = repoTypeValuesType{
 	TfsGit:           "TfsGit",
 	Bitbucket:        "Bitbucket",
 	GitHubEnterprise: "GitHubEnterprise",
+	Git:              "Git",
+	Svn:              "Svn",
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
 )
 
@@ -23,8 +24,16 @@ var taskCheckType = pipelineschecks.CheckType{
 // so it doesn't seem to work and the website UI doesn't have it available
 var targetResourceTypes = []string{"endpoint", "environment", "queue", "repository", "securefile", "variablegroup"}
 
-type flatFunc func(d *schema.ResourceData, check *pipelineschecks.CheckConfiguration, projectID string) error
-type expandFunc func(d *schema.ResourceData) (*pipelineschecks.CheckConfiguration, string, error)
+// completionEvents are the ways a task based check that calls out to an external service can complete
+var completionEvents = []string{"Callback", "ApiResponse"}
+
+var httpMethods = []string{"OPTIONS", "GET", "HEAD", "POST", "PUT", "DELETE", "TRACE", "PATCH"}
+
+// Checks are created, read and updated with V5PipelinesChecksClientExtras instead of V5PipelinesChecksClient,
+// because the 5.1 SDK model of a check configuration does not have the check timeout, which is used by the
+// approval and exclusive lock checks. All checks share the create and update functions below.
+type flatFunc func(d *schema.ResourceData, clients *client.AggregatedClient, check *pipelineschecksextras.CheckConfiguration, projectID string) error
+type expandFunc func(d *schema.ResourceData, clients *client.AggregatedClient) (*pipelineschecksextras.CheckConfiguration, string, error)
 
 // genBaseCheckResource creates a Resource with the common parts
 // that all checks require.
@@ -69,49 +78,168 @@ func genBaseCheckResource(f flatFunc, e expandFunc) *schema.Resource {
 	}
 }
 
-// doBaseExpansion performs the expansion for the 'base' attributes that are defined in the schema, above
-func doBaseExpansion(d *schema.ResourceData, inputs map[string]interface{}, definitionRef interface{}) (*pipelineschecks.CheckConfiguration, string, error) {
-	projectID := d.Get("project_id").(string)
+// addEvaluationSchema adds the evaluation options that are shared by the task based checks
+// which are evaluated repeatedly until they pass or time out
+func addEvaluationSchema(r *schema.Resource) {
+	r.Schema["retry_interval"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      5,
+		ValidateFunc: validation.IntAtLeast(0),
+	}
+	r.Schema["timeout"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      1440,
+		ValidateFunc: validation.IntBetween(1, 43200),
+	}
+}
 
-	taskCheck := pipelineschecks.CheckConfiguration{
-		Type: &taskCheckType,
-		Settings: map[string]interface{}{
-			"definitionRef": definitionRef,
-			"displayName":   d.Get("display_name").(string),
-			"inputs":        inputs,
-		},
-		Resource: &pipelineschecks.Resource{
-			Id:   converter.String(d.Get("target_resource_id").(string)),
-			Type: converter.String(d.Get("target_resource_type").(string)),
-		},
+// addHTTPRequestSchema adds the attributes shared by the checks that issue an HTTP request
+func addHTTPRequestSchema(r *schema.Resource) {
+	r.Schema["method"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "POST",
+		ValidateFunc: validation.StringInSlice(httpMethods, false),
+	}
+	r.Schema["headers"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "",
+		ValidateFunc: validation.StringIsJSON,
+	}
+	r.Schema["body"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "",
+	}
+	r.Schema["completion_event"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "Callback",
+		ValidateFunc: validation.StringInSlice(completionEvents, false),
+	}
+	r.Schema["success_criteria"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "",
 	}
+	r.Schema["variable_group_name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "",
+	}
+}
 
-	if d.Id() != "" {
-		taskCheckId, err := strconv.Atoi(d.Id())
+// expandHTTPRequestInputs expands the inputs shared by the checks that issue an HTTP request
+func expandHTTPRequestInputs(d *schema.ResourceData, inputs map[string]interface{}) {
+	inputs["method"] = d.Get("method").(string)
+	inputs["headers"] = d.Get("headers").(string)
+	inputs["body"] = d.Get("body").(string)
+	inputs["waitForCompletion"] = strconv.FormatBool(d.Get("completion_event").(string) == "Callback")
+	inputs["successCriteria"] = d.Get("success_criteria").(string)
+}
+
+// flattenHTTPRequestInputs flattens the inputs shared by the checks that issue an HTTP request
+func flattenHTTPRequestInputs(d *schema.ResourceData, inputs map[string]interface{}) error {
+	if method, found := inputs["method"]; found {
+		d.Set("method", method)
+	} else {
+		return fmt.Errorf("method input not found")
+	}
+	d.Set("headers", inputToString(inputs, "headers"))
+	d.Set("body", inputToString(inputs, "body"))
+	d.Set("success_criteria", inputToString(inputs, "successCriteria"))
+
+	if waitForCompletion, found := inputs["waitForCompletion"]; found {
+		value, err := strconv.ParseBool(waitForCompletion.(string))
 		if err != nil {
-			return nil, "", fmt.Errorf("Error parsing task check ID: (%+v)", err)
+			return err
+		}
+		if value {
+			d.Set("completion_event", "Callback")
+		} else {
+			d.Set("completion_event", "ApiResponse")
 		}
-		taskCheck.Id = &taskCheckId
+	} else {
+		return fmt.Errorf("waitForCompletion input not found")
 	}
+	return nil
+}
 
-	return &taskCheck, projectID, nil
+// expandLinkedVariableGroup links a variable group to the check so it can be used in the request
+func expandLinkedVariableGroup(d *schema.ResourceData, check *pipelineschecksextras.CheckConfiguration) {
+	if variableGroupName := d.Get("variable_group_name").(string); variableGroupName != "" {
+		check.Settings.(map[string]interface{})["linkedVariableGroup"] = variableGroupName
+	}
 }
 
-// doBaseFlattening performs the flattening for the 'base' attributes that are defined in the schema, above
-func doBaseFlattening(d *schema.ResourceData, check *pipelineschecks.CheckConfiguration, projectID string, definitionId string, definitionVersion string) error {
-	d.SetId(fmt.Sprintf("%d", *check.Id))
+// flattenLinkedVariableGroup flattens the variable group linked to the check
+func flattenLinkedVariableGroup(d *schema.ResourceData, check *pipelineschecksextras.CheckConfiguration) {
+	if linkedVariableGroup, found := check.Settings.(map[string]interface{})["linkedVariableGroup"]; found && linkedVariableGroup != nil {
+		d.Set("variable_group_name", linkedVariableGroup.(string))
+	} else {
+		d.Set("variable_group_name", "")
+	}
+}
 
-	d.Set("project_id", projectID)
+// doBaseExpansion performs the expansion for the 'base' attributes of task based checks
+func doBaseExpansion(d *schema.ResourceData, inputs map[string]interface{}, definitionRef interface{}) (*pipelineschecksextras.CheckConfiguration, string, error) {
+	settings := map[string]interface{}{
+		"definitionRef": definitionRef,
+		"displayName":   d.Get("display_name").(string),
+		"inputs":        inputs,
+	}
 
-	if check.Resource == nil {
-		return fmt.Errorf("Resource nil")
+	return doCheckExpansion(d, &taskCheckType, settings, nil)
+}
+
+// doEvaluationExpansion performs the expansion for task based checks that include the evaluation options
+func doEvaluationExpansion(d *schema.ResourceData, inputs map[string]interface{}, definitionRef interface{}) (*pipelineschecksextras.CheckConfiguration, string, error) {
+	check, projectID, err := doBaseExpansion(d, inputs, definitionRef)
+	if err != nil {
+		return nil, "", err
 	}
 
-	d.Set("target_resource_id", check.Resource.Id)
-	d.Set("target_resource_type", check.Resource.Type)
+	check.Settings.(map[string]interface{})["retryInterval"] = d.Get("retry_interval").(int)
+	check.Timeout = converter.Int(d.Get("timeout").(int))
 
-	if check.Settings == nil {
-		return fmt.Errorf("Settings nil")
+	return check, projectID, nil
+}
+
+// doCheckExpansion performs the expansion for the attributes that are shared by all check types
+func doCheckExpansion(d *schema.ResourceData, checkType *pipelineschecks.CheckType, settings map[string]interface{}, timeout *int) (*pipelineschecksextras.CheckConfiguration, string, error) {
+	projectID := d.Get("project_id").(string)
+
+	check := pipelineschecksextras.CheckConfiguration{
+		CheckConfiguration: pipelineschecks.CheckConfiguration{
+			Type:     checkType,
+			Settings: settings,
+			Resource: &pipelineschecks.Resource{
+				Id:   converter.String(d.Get("target_resource_id").(string)),
+				Type: converter.String(d.Get("target_resource_type").(string)),
+			},
+		},
+		Timeout: timeout,
+	}
+
+	if d.Id() != "" {
+		checkId, err := strconv.Atoi(d.Id())
+		if err != nil {
+			return nil, "", fmt.Errorf("Error parsing check ID: (%+v)", err)
+		}
+		check.Id = &checkId
+	}
+
+	return &check, projectID, nil
+}
+
+// doBaseFlattening performs the flattening for the 'base' attributes of task based checks
+func doBaseFlattening(d *schema.ResourceData, check *pipelineschecksextras.CheckConfiguration, projectID string, definitionId string, definitionVersion string) error {
+	err := doCheckFlattening(d, check, projectID)
+	if err != nil {
+		return err
 	}
 
 	if definitionRefMap, found := check.Settings.(map[string]interface{})["definitionRef"]; found {
@@ -143,15 +271,81 @@ func doBaseFlattening(d *schema.ResourceData, check *pipelineschecks.CheckConfig
 	return nil
 }
 
+// doEvaluationFlattening performs the flattening for task based checks that include the evaluation options
+func doEvaluationFlattening(d *schema.ResourceData, check *pipelineschecksextras.CheckConfiguration, projectID string, definitionId string, definitionVersion string) error {
+	err := doBaseFlattening(d, check, projectID, definitionId, definitionVersion)
+	if err != nil {
+		return err
+	}
+
+	if retryInterval, found := check.Settings.(map[string]interface{})["retryInterval"]; found {
+		value, err := settingToInt(retryInterval)
+		if err != nil {
+			return fmt.Errorf("retryInterval: %+v", err)
+		}
+		d.Set("retry_interval", value)
+	} else {
+		d.Set("retry_interval", 0)
+	}
+
+	if check.Timeout != nil {
+		d.Set("timeout", *check.Timeout)
+	}
+
+	return nil
+}
+
+// doCheckFlattening performs the flattening for the attributes that are shared by all check types
+func doCheckFlattening(d *schema.ResourceData, check *pipelineschecksextras.CheckConfiguration, projectID string) error {
+	d.SetId(fmt.Sprintf("%d", *check.Id))
+
+	d.Set("project_id", projectID)
+
+	if check.Resource == nil {
+		return fmt.Errorf("Resource nil")
+	}
+
+	d.Set("target_resource_id", check.Resource.Id)
+	d.Set("target_resource_type", check.Resource.Type)
+
+	if check.Settings == nil {
+		return fmt.Errorf("Settings nil")
+	}
+
+	return nil
+}
+
+// settingToInt converts a numeric setting that was either set by the provider or decoded from JSON
+func settingToInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case float64:
+		return int(v), nil
+	case string:
+		return strconv.Atoi(v)
+	default:
+		return 0, fmt.Errorf("unexpected type %T", value)
+	}
+}
+
+// inputToString reads a task input, inputs that were never set are returned as an empty string
+func inputToString(inputs map[string]interface{}, name string) string {
+	if value, found := inputs[name]; found && value != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return ""
+}
+
 func genCheckCreateFunc(flatFunc flatFunc, expandFunc expandFunc) func(d *schema.ResourceData, m interface{}) error {
 	return func(d *schema.ResourceData, m interface{}) error {
 		clients := m.(*client.AggregatedClient)
-		configuration, projectID, err := expandFunc(d)
+		configuration, projectID, err := expandFunc(d, clients)
 		if err != nil {
 			return fmt.Errorf(" failed in expandFunc. Error: %+v", err)
 		}
 
-		createdCheck, err := clients.V5PipelinesChecksClient.AddCheckConfiguration(clients.Ctx, pipelineschecks.AddCheckConfigurationArgs{
+		createdCheck, err := clients.V5PipelinesChecksClientExtras.AddCheckConfiguration(clients.Ctx, pipelineschecksextras.AddCheckConfigurationArgs{
 			Project:       &projectID,
 			Configuration: configuration,
 		})
@@ -159,7 +353,7 @@ func genCheckCreateFunc(flatFunc flatFunc, expandFunc expandFunc) func(d *schema
 			return fmt.Errorf(" failed creating check, project ID: %s. Error: %+v", projectID, err)
 		}
 
-		err = flatFunc(d, createdCheck, projectID)
+		err = flatFunc(d, clients, createdCheck, projectID)
 		if err != nil {
 			return err
 		}
@@ -188,20 +382,20 @@ func genCheckReadFunc(flatFunc flatFunc) func(d *schema.ResourceData, m interfac
 			return err
 		}
 
-		return flatFunc(d, taskCheck, projectID)
+		return flatFunc(d, clients, taskCheck, projectID)
 	}
 }
 
 func genCheckUpdateFunc(flatFunc flatFunc, expandFunc expandFunc) schema.UpdateFunc { //nolint:staticcheck
 	return func(d *schema.ResourceData, m interface{}) error {
 		clients := m.(*client.AggregatedClient)
-		taskCheck, projectID, err := expandFunc(d)
+		taskCheck, projectID, err := expandFunc(d, clients)
 		if err != nil {
 			return err
 		}
 
-		updatedBusinessHours, err := clients.V5PipelinesChecksClient.UpdateCheckConfiguration(clients.Ctx,
-			pipelineschecks.UpdateCheckConfigurationArgs{
+		updatedBusinessHours, err := clients.V5PipelinesChecksClientExtras.UpdateCheckConfiguration(clients.Ctx,
+			pipelineschecksextras.UpdateCheckConfigurationArgs{
 				Project:       &projectID,
 				Configuration: taskCheck,
 				Id:            taskCheck.Id,
@@ -211,7 +405,7 @@ func genCheckUpdateFunc(flatFunc flatFunc, expandFunc expandFunc) schema.UpdateF
 			return err
 		}
 
-		err = flatFunc(d, updatedBusinessHours, projectID)
+		err = flatFunc(d, clients, updatedBusinessHours, projectID)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,149 @@
+package approvalsandchecks
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/pipelineschecks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
+)
+
+// DataChecks schema and implementation for the checks data source
+func DataChecks() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceChecksRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"target_resource_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"target_resource_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(targetResourceTypes, false),
+			},
+			"checks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"type_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"timeout": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"settings": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceChecksRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	projectID := d.Get("project_id").(string)
+	resourceType := d.Get("target_resource_type").(string)
+	resourceID := d.Get("target_resource_id").(string)
+
+	checks, err := clients.V5PipelinesChecksClientExtras.GetCheckConfigurationsOnResource(clients.Ctx, pipelineschecks.GetCheckConfigurationsOnResourceArgs{
+		Project:      converter.String(projectID),
+		ResourceType: converter.String(resourceType),
+		ResourceId:   converter.String(resourceID),
+	})
+	if err != nil {
+		return fmt.Errorf(" failed to list checks on %s %s. Error: %+v", resourceType, resourceID, err)
+	}
+	log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Read [%d] checks", len(*checks))
+
+	results, err := flattenChecks(checks)
+	if err != nil {
+		return fmt.Errorf(" failed to flatten checks. Error: %+v", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", projectID, resourceType, resourceID))
+	err = d.Set("checks", results)
+	if err != nil {
+		d.SetId("")
+		return err
+	}
+	return nil
+}
+
+func flattenChecks(checks *[]pipelineschecksextras.CheckConfiguration) ([]interface{}, error) {
+	if checks == nil {
+		return []interface{}{}, nil
+	}
+
+	results := make([]interface{}, 0, len(*checks))
+	for _, check := range *checks {
+		output := make(map[string]interface{})
+		if check.Id != nil {
+			output["id"] = *check.Id
+		}
+		if check.Type != nil {
+			if check.Type.Id != nil {
+				output["type_id"] = check.Type.Id.String()
+			}
+			if check.Type.Name != nil {
+				output["type_name"] = *check.Type.Name
+			}
+		}
+		if check.Timeout != nil {
+			output["timeout"] = *check.Timeout
+		}
+
+		if check.Settings != nil {
+			// Only task based checks have a display name
+			if settings, ok := check.Settings.(map[string]interface{}); ok {
+				if displayName, found := settings["displayName"]; found && displayName != nil {
+					output["display_name"] = fmt.Sprintf("%v", displayName)
+				}
+			}
+
+			settingsJSON, err := json.Marshal(check.Settings)
+			if err != nil {
+				return nil, fmt.Errorf("Unable to marshal settings of check %d into JSON: %+v", *check.Id, err)
+			}
+			output["settings"] = string(settingsJSON)
+		}
+
+		results = append(results, output)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,119 @@
+//go:build (all || data_sources || data_checks) && (!exclude_data_sources || !exclude_data_checks)
+// +build all data_sources data_checks
+// +build !exclude_data_sources !exclude_data_checks
+
+package approvalsandchecks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/pipelineschecks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
+	mock_pipelineschecksextras "github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+var dataChecksProjectID = uuid.New().String()
+var dataChecksTaskCheckID = 1
+var dataChecksLockCheckID = 2
+var dataChecksTimeout = 60
+
+var dataChecksResource = pipelineschecks.Resource{
+	Id:   converter.String(uuid.New().String()),
+	Type: converter.String("environment"),
+}
+
+var dataChecksList = []pipelineschecksextras.CheckConfiguration{
+	{
+		CheckConfiguration: pipelineschecks.CheckConfiguration{
+			Id: &dataChecksTaskCheckID,
+			Type: &pipelineschecks.CheckType{
+				Id:   taskCheckType.Id,
+				Name: converter.String("Task Check"),
+			},
+			Settings: map[string]interface{}{
+				"displayName": "Business hours",
+				"inputs": map[string]interface{}{
+					"timeZone": "UTC",
+				},
+			},
+			Resource: &dataChecksResource,
+		},
+		Timeout: &dataChecksTimeout,
+	},
+	{
+		CheckConfiguration: pipelineschecks.CheckConfiguration{
+			Id:       &dataChecksLockCheckID,
+			Type:     &exclusiveLockCheckType,
+			Settings: map[string]interface{}{},
+			Resource: &dataChecksResource,
+		},
+	},
+}
+
+// verifies that every check returned by the service is flattened
+func TestDataSourceChecks_Read_ListsChecks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pipelinesChecksClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesChecksClient, Ctx: context.Background()}
+
+	expectedArgs := pipelineschecks.GetCheckConfigurationsOnResourceArgs{
+		Project:      &dataChecksProjectID,
+		ResourceType: dataChecksResource.Type,
+		ResourceId:   dataChecksResource.Id,
+	}
+	pipelinesChecksClient.
+		EXPECT().
+		GetCheckConfigurationsOnResource(clients.Ctx, expectedArgs).
+		Return(&dataChecksList, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, DataChecks().Schema, nil)
+	resourceData.Set("project_id", dataChecksProjectID)
+	resourceData.Set("target_resource_type", *dataChecksResource.Type)
+	resourceData.Set("target_resource_id", *dataChecksResource.Id)
+
+	err := dataSourceChecksRead(resourceData, clients)
+	require.Nil(t, err)
+	require.NotEmpty(t, resourceData.Id())
+	require.Equal(t, 2, resourceData.Get("checks.#"))
+	require.Equal(t, dataChecksTaskCheckID, resourceData.Get("checks.0.id"))
+	require.Equal(t, "Business hours", resourceData.Get("checks.0.display_name"))
+	require.Equal(t, dataChecksTimeout, resourceData.Get("checks.0.timeout"))
+	require.JSONEq(t, `{"displayName":"Business hours","inputs":{"timeZone":"UTC"}}`, resourceData.Get("checks.0.settings").(string))
+	require.Equal(t, "ExclusiveLock", resourceData.Get("checks.1.type_name"))
+	require.Equal(t, exclusiveLockCheckType.Id.String(), resourceData.Get("checks.1.type_id"))
+	require.Equal(t, "", resourceData.Get("checks.1.display_name"))
+}
+
+// verifies that if an error is produced on a read, it is not swallowed
+func TestDataSourceChecks_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pipelinesChecksClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesChecksClient, Ctx: context.Background()}
+
+	pipelinesChecksClient.
+		EXPECT().
+		GetCheckConfigurationsOnResource(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetCheckConfigurationsOnResource() Failed")).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, DataChecks().Schema, nil)
+	resourceData.Set("project_id", dataChecksProjectID)
+	resourceData.Set("target_resource_type", *dataChecksResource.Type)
+	resourceData.Set("target_resource_id", *dataChecksResource.Id)
+
+	err := dataSourceChecksRead(resourceData, clients)
+	require.Contains(t, err.Error(), "GetCheckConfigurationsOnResource() Failed")
+}
@@ -0,0 +1,174 @@
+package approvalsandchecks
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/pipelineschecks"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/graph"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
+)
+
+var approvalCheckType = pipelineschecks.CheckType{
+	Id:   converter.UUID("8c6f20a7-a545-4486-9777-f762fafe0d4d"),
+	Name: converter.String("Approval"),
+}
+
+const (
+	approvalExecutionOrderAnyOrder   = "anyOrder"
+	approvalExecutionOrderInSequence = "inSequence"
+)
+
+type approvalCheckApprover struct {
+	Id string `json:"id"`
+}
+
+type approvalCheckSettings struct {
+	Approvers                 []approvalCheckApprover `json:"approvers"`
+	ExecutionOrder            interface{}             `json:"executionOrder"`
+	Instructions              string                  `json:"instructions"`
+	MinRequiredApprovers      int                     `json:"minRequiredApprovers"`
+	RequesterCannotBeApprover bool                    `json:"requesterCannotBeApprover"`
+}
+
+// ResourceCheckApproval schema and implementation for manual approval check resources
+func ResourceCheckApproval() *schema.Resource {
+	r := genBaseCheckResource(flattenCheckApproval, expandCheckApproval)
+
+	// Approvals do not have a display name, the web UI always shows "Approvals"
+	delete(r.Schema, "display_name")
+
+	r.Schema["approvers"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		MinItems: 1,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringIsNotWhiteSpace,
+		},
+	}
+	r.Schema["minimum_required_approvers"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      0,
+		ValidateFunc: validation.IntAtLeast(0),
+	}
+	r.Schema["sequential_approval"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	r.Schema["requester_can_approve"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+	r.Schema["instructions"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "",
+	}
+	r.Schema["timeout"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      43200,
+		ValidateFunc: validation.IntBetween(1, 43200),
+	}
+
+	return r
+}
+
+func flattenCheckApproval(d *schema.ResourceData, clients *client.AggregatedClient, approvalCheck *pipelineschecksextras.CheckConfiguration, projectID string) error {
+	err := doCheckFlattening(d, approvalCheck, projectID)
+	if err != nil {
+		return err
+	}
+
+	if approvalCheck.Type == nil || approvalCheck.Type.Id == nil || *approvalCheck.Type.Id != *approvalCheckType.Id {
+		return fmt.Errorf("Check is not an approval check")
+	}
+
+	settingsJSON, err := json.Marshal(approvalCheck.Settings)
+	if err != nil {
+		return fmt.Errorf("Unable to marshal approval check settings into JSON: %+v", err)
+	}
+
+	var settings approvalCheckSettings
+	err = json.Unmarshal(settingsJSON, &settings)
+	if err != nil {
+		return fmt.Errorf("Unable to unmarshal approval check settings: %+v", err)
+	}
+
+	approvers := make([]string, len(settings.Approvers))
+	for i, approver := range settings.Approvers {
+		storageKey, err := uuid.Parse(approver.Id)
+		if err != nil {
+			return fmt.Errorf("Error parsing approver ID %s: %+v", approver.Id, err)
+		}
+		descriptor, err := clients.GraphClient.GetDescriptor(clients.Ctx, graph.GetDescriptorArgs{
+			StorageKey: &storageKey,
+		})
+		if err != nil {
+			return fmt.Errorf(" failed to get descriptor for approver %s. Error: %+v", approver.Id, err)
+		}
+		approvers[i] = *descriptor.Value
+	}
+	d.Set("approvers", approvers)
+
+	switch executionOrder := settings.ExecutionOrder.(type) {
+	case string:
+		d.Set("sequential_approval", executionOrder == approvalExecutionOrderInSequence)
+	case float64:
+		// The web UI stores the execution order as a number: 1 (any order) or 2 (in sequence)
+		d.Set("sequential_approval", executionOrder == 2)
+	default:
+		d.Set("sequential_approval", false)
+	}
+
+	d.Set("minimum_required_approvers", settings.MinRequiredApprovers)
+	d.Set("requester_can_approve", !settings.RequesterCannotBeApprover)
+	d.Set("instructions", settings.Instructions)
+
+	if approvalCheck.Timeout != nil {
+		d.Set("timeout", *approvalCheck.Timeout)
+	}
+
+	return nil
+}
+
+func expandCheckApproval(d *schema.ResourceData, clients *client.AggregatedClient) (*pipelineschecksextras.CheckConfiguration, string, error) {
+	descriptors := d.Get("approvers").([]interface{})
+	approvers := make([]interface{}, len(descriptors))
+	for i, descriptor := range descriptors {
+		storageKey, err := clients.GraphClient.GetStorageKey(clients.Ctx, graph.GetStorageKeyArgs{
+			SubjectDescriptor: converter.String(descriptor.(string)),
+		})
+		if err != nil {
+			return nil, "", fmt.Errorf(" failed to get storage key for approver %s. Error: %+v", descriptor, err)
+		}
+		approvers[i] = map[string]interface{}{
+			"id": storageKey.Value.String(),
+		}
+	}
+
+	executionOrder := approvalExecutionOrderAnyOrder
+	if d.Get("sequential_approval").(bool) {
+		executionOrder = approvalExecutionOrderInSequence
+	}
+
+	settings := map[string]interface{}{
+		"approvers":                 approvers,
+		"executionOrder":            executionOrder,
+		"instructions":              d.Get("instructions").(string),
+		"blockedApprovers":          []interface{}{},
+		"minRequiredApprovers":      d.Get("minimum_required_approvers").(int),
+		"requesterCannotBeApprover": !d.Get("requester_can_approve").(bool),
+	}
+
+	return doCheckExpansion(d, &approvalCheckType, settings, converter.Int(d.Get("timeout").(int)))
+}
@@ -0,0 +1,240 @@
+//go:build (all || resource_check_approval) && !exclude_approvalsandchecks
+// +build all resource_check_approval
+// +build !exclude_approvalsandchecks
+
+package approvalsandchecks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/pipelineschecks"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/graph"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
+	mock_pipelineschecksextras "github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+var approvalCheckID = 123456789
+var approvalCheckProjectID = uuid.New().String()
+var approvalCheckTimeout = 1440
+
+var approvalCheckApproverStorageKey = uuid.New()
+var approvalCheckApproverDescriptor = "vssgp.Uy0xLTktMTU1MTM3NDI0NS0xMjA0NDAwOTY5"
+
+var approvalCheckTestSettings = map[string]interface{}{
+	"approvers": []interface{}{
+		map[string]interface{}{
+			"id": approvalCheckApproverStorageKey.String(),
+		},
+	},
+	"executionOrder":            approvalExecutionOrderInSequence,
+	"instructions":              "Test Instructions",
+	"blockedApprovers":          []interface{}{},
+	"minRequiredApprovers":      1,
+	"requesterCannotBeApprover": false,
+}
+
+var approvalCheckTest = pipelineschecksextras.CheckConfiguration{
+	CheckConfiguration: pipelineschecks.CheckConfiguration{
+		Id:       &approvalCheckID,
+		Type:     &approvalCheckType,
+		Settings: approvalCheckTestSettings,
+		Resource: &endpointResource,
+	},
+	Timeout: &approvalCheckTimeout,
+}
+
+// verifies that the flatten/expand round trip yields the same approval check
+func TestCheckApproval_ExpandFlatten_Roundtrip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	clients := &client.AggregatedClient{GraphClient: graphClient, Ctx: context.Background()}
+
+	graphClient.
+		EXPECT().
+		GetDescriptor(clients.Ctx, graph.GetDescriptorArgs{StorageKey: &approvalCheckApproverStorageKey}).
+		Return(&graph.GraphDescriptorResult{Value: converter.String(approvalCheckApproverDescriptor)}, nil).
+		Times(1)
+	graphClient.
+		EXPECT().
+		GetStorageKey(clients.Ctx, graph.GetStorageKeyArgs{SubjectDescriptor: converter.String(approvalCheckApproverDescriptor)}).
+		Return(&graph.GraphStorageKeyResult{Value: &approvalCheckApproverStorageKey}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceCheckApproval().Schema, nil)
+	err := flattenCheckApproval(resourceData, clients, &approvalCheckTest, approvalCheckProjectID)
+	require.Nil(t, err)
+	require.Equal(t, approvalCheckApproverDescriptor, resourceData.Get("approvers.0"))
+	require.True(t, resourceData.Get("sequential_approval").(bool))
+	require.True(t, resourceData.Get("requester_can_approve").(bool))
+
+	approvalCheckAfterRoundTrip, projectID, err := expandCheckApproval(resourceData, clients)
+
+	require.Equal(t, approvalCheckTest, *approvalCheckAfterRoundTrip)
+	require.Equal(t, approvalCheckProjectID, projectID)
+	require.Nil(t, err)
+}
+
+// verifies that the numeric execution order used by the web UI is understood
+func TestCheckApproval_Flatten_NumericExecutionOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	clients := &client.AggregatedClient{GraphClient: graphClient, Ctx: context.Background()}
+
+	graphClient.
+		EXPECT().
+		GetDescriptor(clients.Ctx, gomock.Any()).
+		Return(&graph.GraphDescriptorResult{Value: converter.String(approvalCheckApproverDescriptor)}, nil).
+		Times(1)
+
+	check := approvalCheckTest
+	check.Settings = map[string]interface{}{
+		"approvers": []interface{}{
+			map[string]interface{}{
+				"id": approvalCheckApproverStorageKey.String(),
+			},
+		},
+		"executionOrder":            float64(2),
+		"minRequiredApprovers":      float64(0),
+		"requesterCannotBeApprover": true,
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceCheckApproval().Schema, nil)
+	err := flattenCheckApproval(resourceData, clients, &check, approvalCheckProjectID)
+	require.Nil(t, err)
+	require.True(t, resourceData.Get("sequential_approval").(bool))
+	require.False(t, resourceData.Get("requester_can_approve").(bool))
+}
+
+// verifies that a check of another type is not flattened into an approval check
+func TestCheckApproval_Flatten_RejectsOtherCheckTypes(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceCheckApproval().Schema, nil)
+	err := flattenCheckApproval(resourceData, nil, &branchControlCheckTest, approvalCheckProjectID)
+	require.NotNil(t, err)
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestCheckApproval_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckApproval()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	resourceData.Set("project_id", approvalCheckProjectID)
+	resourceData.Set("target_resource_id", *endpointResource.Id)
+	resourceData.Set("target_resource_type", *endpointResource.Type)
+	resourceData.Set("approvers", []interface{}{approvalCheckApproverDescriptor})
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	pipelinesChecksClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
+	clients := &client.AggregatedClient{
+		GraphClient:                   graphClient,
+		V5PipelinesChecksClientExtras: pipelinesChecksClient,
+		Ctx:                           context.Background(),
+	}
+
+	graphClient.
+		EXPECT().
+		GetStorageKey(clients.Ctx, gomock.Any()).
+		Return(&graph.GraphStorageKeyResult{Value: &approvalCheckApproverStorageKey}, nil).
+		Times(1)
+	pipelinesChecksClient.
+		EXPECT().
+		AddCheckConfiguration(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("AddCheckConfiguration() Failed")).
+		Times(1)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "AddCheckConfiguration() Failed")
+}
+
+// verifies that if an approver cannot be resolved, the error is not swallowed
+func TestCheckApproval_Create_DoesNotSwallowStorageKeyError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckApproval()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	resourceData.Set("project_id", approvalCheckProjectID)
+	resourceData.Set("approvers", []interface{}{approvalCheckApproverDescriptor})
+
+	graphClient := azdosdkmocks.NewMockGraphClient(ctrl)
+	clients := &client.AggregatedClient{GraphClient: graphClient, Ctx: context.Background()}
+
+	graphClient.
+		EXPECT().
+		GetStorageKey(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetStorageKey() Failed")).
+		Times(1)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "GetStorageKey() Failed")
+}
+
+// verifies that if an error is produced on a read, it is not swallowed
+func TestCheckApproval_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckApproval()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	resourceData.SetId("123456789")
+	resourceData.Set("project_id", approvalCheckProjectID)
+
+	pipelinesChecksClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesChecksClient, Ctx: context.Background()}
+
+	expectedArgs := pipelineschecks.GetCheckConfigurationArgs{
+		Id:      &approvalCheckID,
+		Project: &approvalCheckProjectID,
+	}
+
+	pipelinesChecksClient.
+		EXPECT().
+		GetCheckConfiguration(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("GetCheckConfiguration() Failed")).
+		Times(1)
+
+	err := r.Read(resourceData, clients)
+	require.Contains(t, err.Error(), "GetCheckConfiguration() Failed")
+}
+
+// verifies that if an error is produced on a delete, it is not swallowed
+func TestCheckApproval_Delete_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckApproval()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	resourceData.SetId("123456789")
+	resourceData.Set("project_id", approvalCheckProjectID)
+
+	pipelinesChecksClient := azdosdkmocks.NewPipelinesChecksClientV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClient: pipelinesChecksClient, Ctx: context.Background()}
+
+	expectedArgs := pipelineschecks.DeleteCheckConfigurationArgs{
+		Id:      &approvalCheckID,
+		Project: &approvalCheckProjectID,
+	}
+
+	pipelinesChecksClient.
+		EXPECT().
+		DeleteCheckConfiguration(clients.Ctx, expectedArgs).
+		Return(errors.New("DeleteCheckConfiguration() Failed")).
+		Times(1)
+
+	err := r.Delete(resourceData, clients)
+	require.Contains(t, err.Error(), "DeleteCheckConfiguration() Failed")
+}
@@ -0,0 +1,95 @@
+package approvalsandchecks
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
+)
+
+var invokeAzureFunctionDefVersion = "1.220.0"
+var invokeAzureFunctionDefId = "537fdb7a-a601-4537-aa70-92645a2b5ce4"
+
+var invokeAzureFunctionDef = map[string]interface{}{
+	"id":      invokeAzureFunctionDefId,
+	"name":    "AzureFunction",
+	"version": invokeAzureFunctionDefVersion,
+}
+
+// ResourceCheckAzureFunction schema and implementation for invoke Azure Function check resources
+func ResourceCheckAzureFunction() *schema.Resource {
+	r := genBaseCheckResource(flattenAzureFunctionCheck, expandAzureFunctionCheck)
+	addEvaluationSchema(r)
+
+	r.Schema["function_url"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+	}
+	r.Schema["function_key"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		Sensitive:    true,
+		ValidateFunc: validation.StringIsNotWhiteSpace,
+	}
+	r.Schema["query_parameters"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "",
+	}
+	addHTTPRequestSchema(r)
+
+	return r
+}
+
+func flattenAzureFunctionCheck(d *schema.ResourceData, clients *client.AggregatedClient, azureFunctionCheck *pipelineschecksextras.CheckConfiguration, projectID string) error {
+	err := doEvaluationFlattening(d, azureFunctionCheck, projectID, invokeAzureFunctionDefId, invokeAzureFunctionDefVersion)
+	if err != nil {
+		return err
+	}
+
+	flattenLinkedVariableGroup(d, azureFunctionCheck)
+
+	if inputMap, found := azureFunctionCheck.Settings.(map[string]interface{})["inputs"]; found {
+		inputs := inputMap.(map[string]interface{})
+		if function, found := inputs["function"]; found {
+			d.Set("function_url", function)
+		} else {
+			return fmt.Errorf("function input not found")
+		}
+		if key, found := inputs["key"]; found {
+			d.Set("function_key", key)
+		} else {
+			return fmt.Errorf("key input not found")
+		}
+		d.Set("query_parameters", inputToString(inputs, "queryParameters"))
+
+		err = flattenHTTPRequestInputs(d, inputs)
+		if err != nil {
+			return err
+		}
+	} else {
+		return fmt.Errorf("inputs not found")
+	}
+
+	return nil
+}
+
+func expandAzureFunctionCheck(d *schema.ResourceData, clients *client.AggregatedClient) (*pipelineschecksextras.CheckConfiguration, string, error) {
+	inputs := map[string]interface{}{
+		"function":        d.Get("function_url").(string),
+		"key":             d.Get("function_key").(string),
+		"queryParameters": d.Get("query_parameters").(string),
+	}
+	expandHTTPRequestInputs(d, inputs)
+
+	check, projectID, err := doEvaluationExpansion(d, inputs, invokeAzureFunctionDef)
+	if err != nil {
+		return nil, "", err
+	}
+	expandLinkedVariableGroup(d, check)
+
+	return check, projectID, nil
+}
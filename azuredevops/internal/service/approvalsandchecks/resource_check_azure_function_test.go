@@ -0,0 +1,180 @@
+//go:build (all || resource_check_azure_function) && !exclude_approvalsandchecks
+// +build all resource_check_azure_function
+// +build !exclude_approvalsandchecks
+
+package approvalsandchecks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/pipelineschecks"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
+	mock_pipelineschecksextras "github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+var CheckAzureFunctionID = 123456789
+var CheckAzureFunctionProjectID = uuid.New().String()
+var CheckAzureFunctionTimeout = 60
+
+var CheckAzureFunctionInputs = map[string]interface{}{
+	"function":          "https://example.azurewebsites.net/api/gate",
+	"key":               "function-key",
+	"queryParameters":   "stage=production",
+	"method":            "POST",
+	"headers":           "{\"Content-Type\":\"application/json\"}",
+	"body":              "{\"run\":\"$(Build.BuildId)\"}",
+	"waitForCompletion": "true",
+	"successCriteria":   "",
+}
+
+var CheckAzureFunctionSettings = map[string]interface{}{
+	"definitionRef":       invokeAzureFunctionDef,
+	"displayName":         "Test Azure Function",
+	"inputs":              CheckAzureFunctionInputs,
+	"retryInterval":       10,
+	"linkedVariableGroup": "Test Variable Group",
+}
+
+var CheckAzureFunctionTest = pipelineschecksextras.CheckConfiguration{
+	CheckConfiguration: pipelineschecks.CheckConfiguration{
+		Id:       &CheckAzureFunctionID,
+		Type:     &taskCheckType,
+		Settings: CheckAzureFunctionSettings,
+		Resource: &endpointResource,
+	},
+	Timeout: &CheckAzureFunctionTimeout,
+}
+
+// verifies that the flatten/expand round trip yields the same Azure Function check
+func TestCheckAzureFunction_ExpandFlatten_Roundtrip(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceCheckAzureFunction().Schema, nil)
+	err := flattenAzureFunctionCheck(resourceData, nil, &CheckAzureFunctionTest, CheckAzureFunctionProjectID)
+	require.Nil(t, err)
+	require.Equal(t, "Callback", resourceData.Get("completion_event"))
+
+	CheckAzureFunctionAfterRoundTrip, projectID, err := expandAzureFunctionCheck(resourceData, nil)
+
+	require.Equal(t, CheckAzureFunctionTest, *CheckAzureFunctionAfterRoundTrip)
+	require.Equal(t, CheckAzureFunctionProjectID, projectID)
+	require.Nil(t, err)
+}
+
+// verifies that a check using another task is not flattened into an Azure Function check
+func TestCheckAzureFunction_Flatten_RejectsOtherDefinitions(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceCheckAzureFunction().Schema, nil)
+	err := flattenAzureFunctionCheck(resourceData, nil, &branchControlCheckTest, CheckAzureFunctionProjectID)
+	require.NotNil(t, err)
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestCheckAzureFunction_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckAzureFunction()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenAzureFunctionCheck(resourceData, nil, &CheckAzureFunctionTest, CheckAzureFunctionProjectID)
+
+	pipelinesCheckClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesCheckClient, Ctx: context.Background()}
+
+	expectedArgs := pipelineschecksextras.AddCheckConfigurationArgs{Configuration: &CheckAzureFunctionTest, Project: &CheckAzureFunctionProjectID}
+	pipelinesCheckClient.
+		EXPECT().
+		AddCheckConfiguration(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("AddCheckConfiguration() Failed")).
+		Times(1)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "AddCheckConfiguration() Failed")
+}
+
+// verifies that if an error is produced on a read, it is not swallowed
+func TestCheckAzureFunction_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckAzureFunction()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenAzureFunctionCheck(resourceData, nil, &CheckAzureFunctionTest, CheckAzureFunctionProjectID)
+
+	pipelinesCheckClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesCheckClient, Ctx: context.Background()}
+
+	expectedArgs := pipelineschecks.GetCheckConfigurationArgs{
+		Id:      CheckAzureFunctionTest.Id,
+		Project: &CheckAzureFunctionProjectID,
+	}
+
+	pipelinesCheckClient.
+		EXPECT().
+		GetCheckConfiguration(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("GetCheckConfiguration() Failed")).
+		Times(1)
+
+	err := r.Read(resourceData, clients)
+	require.Contains(t, err.Error(), "GetCheckConfiguration() Failed")
+}
+
+// verifies that if an error is produced on an update, it is not swallowed
+func TestCheckAzureFunction_Update_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckAzureFunction()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenAzureFunctionCheck(resourceData, nil, &CheckAzureFunctionTest, CheckAzureFunctionProjectID)
+
+	pipelinesCheckClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesCheckClient, Ctx: context.Background()}
+
+	expectedArgs := pipelineschecksextras.UpdateCheckConfigurationArgs{
+		Project:       &CheckAzureFunctionProjectID,
+		Configuration: &CheckAzureFunctionTest,
+		Id:            &CheckAzureFunctionID,
+	}
+
+	pipelinesCheckClient.
+		EXPECT().
+		UpdateCheckConfiguration(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("UpdateCheckConfiguration() Failed")).
+		Times(1)
+
+	err := r.Update(resourceData, clients)
+	require.Contains(t, err.Error(), "UpdateCheckConfiguration() Failed")
+}
+
+// verifies that if an error is produced on a delete, it is not swallowed
+func TestCheckAzureFunction_Delete_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckAzureFunction()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenAzureFunctionCheck(resourceData, nil, &CheckAzureFunctionTest, CheckAzureFunctionProjectID)
+
+	pipelinesCheckClient := azdosdkmocks.NewPipelinesChecksClientV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClient: pipelinesCheckClient, Ctx: context.Background()}
+
+	expectedArgs := pipelineschecks.DeleteCheckConfigurationArgs{
+		Id:      CheckAzureFunctionTest.Id,
+		Project: &CheckAzureFunctionProjectID,
+	}
+
+	pipelinesCheckClient.
+		EXPECT().
+		DeleteCheckConfiguration(clients.Ctx, expectedArgs).
+		Return(errors.New("DeleteCheckConfiguration() Failed")).
+		Times(1)
+
+	err := r.Delete(resourceData, clients)
+	require.Contains(t, err.Error(), "DeleteCheckConfiguration() Failed")
+}
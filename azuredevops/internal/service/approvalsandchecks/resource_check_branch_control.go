@@ -5,7 +5,8 @@ import (
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/microsoft/azure-devops-go-api/azuredevops/pipelineschecks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
 )
 
 var evaluateBranchProtectionDefVersion = "0.0.1"
@@ -40,7 +41,7 @@ func ResourceCheckBranchControl() *schema.Resource {
 	return r
 }
 
-func flattenBranchControlCheck(d *schema.ResourceData, branchControlCheck *pipelineschecks.CheckConfiguration, projectID string) error {
+func flattenBranchControlCheck(d *schema.ResourceData, clients *client.AggregatedClient, branchControlCheck *pipelineschecksextras.CheckConfiguration, projectID string) error {
 	err := doBaseFlattening(d, branchControlCheck, projectID, evaluateBranchProtectionDefId, evaluateBranchProtectionDefVersion)
 	if err != nil {
 		return err
@@ -83,7 +84,7 @@ func flattenBranchControlCheck(d *schema.ResourceData, branchControlCheck *pipel
 	return nil
 }
 
-func expandBranchControlCheck(d *schema.ResourceData) (*pipelineschecks.CheckConfiguration, string, error) {
+func expandBranchControlCheck(d *schema.ResourceData, clients *client.AggregatedClient) (*pipelineschecksextras.CheckConfiguration, string, error) {
 	inputs := map[string]interface{}{
 		"allowedBranches":          d.Get("allowed_branches").(string),
 		"ensureProtectionOfBranch": strconv.FormatBool(d.Get("verify_branch_protection").(bool)),
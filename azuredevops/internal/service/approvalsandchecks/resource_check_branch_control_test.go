@@ -16,6 +16,8 @@ import (
 	"github.com/microsoft/azure-devops-go-api/azuredevops/pipelineschecks"
 	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
+	mock_pipelineschecksextras "github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras/mocks"
 	"github.com/stretchr/testify/require"
 )
 
@@ -42,19 +44,21 @@ var branchControlCheckSettings = map[string]interface{}{
 	"inputs":        branchControlInputs,
 }
 
-var branchControlCheckTest = pipelineschecks.CheckConfiguration{
-	Id:       &branchControlCheckID,
-	Type:     &taskCheckType,
-	Settings: branchControlCheckSettings,
-	Resource: &endpointResource,
+var branchControlCheckTest = pipelineschecksextras.CheckConfiguration{
+	CheckConfiguration: pipelineschecks.CheckConfiguration{
+		Id:       &branchControlCheckID,
+		Type:     &taskCheckType,
+		Settings: branchControlCheckSettings,
+		Resource: &endpointResource,
+	},
 }
 
 // verifies that the flatten/expand round trip yields the same branch control
 func TestCheckBranchControl_ExpandFlatten_Roundtrip(t *testing.T) {
 	resourceData := schema.TestResourceDataRaw(t, ResourceCheckBranchControl().Schema, nil)
-	flattenBranchControlCheck(resourceData, &branchControlCheckTest, branchControlCheckProjectID)
+	flattenBranchControlCheck(resourceData, nil, &branchControlCheckTest, branchControlCheckProjectID)
 
-	branchControlCheckAfterRoundTrip, projectID, err := expandBranchControlCheck(resourceData)
+	branchControlCheckAfterRoundTrip, projectID, err := expandBranchControlCheck(resourceData, nil)
 
 	require.Equal(t, branchControlCheckTest, *branchControlCheckAfterRoundTrip)
 	require.Equal(t, branchControlCheckProjectID, projectID)
@@ -68,12 +72,12 @@ func TestCheckBranchControl_Create_DoesNotSwallowError(t *testing.T) {
 
 	r := ResourceCheckBranchControl()
 	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
-	flattenBranchControlCheck(resourceData, &branchControlCheckTest, branchControlCheckProjectID)
+	flattenBranchControlCheck(resourceData, nil, &branchControlCheckTest, branchControlCheckProjectID)
 
-	pipelinesChecksClient := azdosdkmocks.NewPipelinesChecksClientV5(ctrl)
-	clients := &client.AggregatedClient{V5PipelinesChecksClient: pipelinesChecksClient, Ctx: context.Background()}
+	pipelinesChecksClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesChecksClient, Ctx: context.Background()}
 
-	expectedArgs := pipelineschecks.AddCheckConfigurationArgs{Configuration: &branchControlCheckTest, Project: &branchControlCheckProjectID}
+	expectedArgs := pipelineschecksextras.AddCheckConfigurationArgs{Configuration: &branchControlCheckTest, Project: &branchControlCheckProjectID}
 	pipelinesChecksClient.
 		EXPECT().
 		AddCheckConfiguration(clients.Ctx, expectedArgs).
@@ -91,9 +95,9 @@ func TestCheckBranchControl_Read_DoesNotSwallowError(t *testing.T) {
 
 	r := ResourceCheckBranchControl()
 	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
-	flattenBranchControlCheck(resourceData, &branchControlCheckTest, branchControlCheckProjectID)
+	flattenBranchControlCheck(resourceData, nil, &branchControlCheckTest, branchControlCheckProjectID)
 
-	pipelinesChecksClient := azdosdkmocks.NewPipelinesChecksClientExtrasV5(ctrl)
+	pipelinesChecksClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
 	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesChecksClient, Ctx: context.Background()}
 
 	expectedArgs := pipelineschecks.GetCheckConfigurationArgs{
@@ -118,7 +122,7 @@ func TestCheckBranchControl_Delete_DoesNotSwallowError(t *testing.T) {
 
 	r := ResourceCheckBranchControl()
 	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
-	flattenBranchControlCheck(resourceData, &branchControlCheckTest, branchControlCheckProjectID)
+	flattenBranchControlCheck(resourceData, nil, &branchControlCheckTest, branchControlCheckProjectID)
 
 	pipelinesChecksClient := azdosdkmocks.NewPipelinesChecksClientV5(ctrl)
 	clients := &client.AggregatedClient{V5PipelinesChecksClient: pipelinesChecksClient, Ctx: context.Background()}
@@ -145,12 +149,12 @@ func TestCheckBranchControl_Update_DoesNotSwallowError(t *testing.T) {
 
 	r := ResourceCheckBranchControl()
 	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
-	flattenBranchControlCheck(resourceData, &branchControlCheckTest, branchControlCheckProjectID)
+	flattenBranchControlCheck(resourceData, nil, &branchControlCheckTest, branchControlCheckProjectID)
 
-	pipelinesChecksClient := azdosdkmocks.NewPipelinesChecksClientV5(ctrl)
-	clients := &client.AggregatedClient{V5PipelinesChecksClient: pipelinesChecksClient, Ctx: context.Background()}
+	pipelinesChecksClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesChecksClient, Ctx: context.Background()}
 
-	expectedArgs := pipelineschecks.UpdateCheckConfigurationArgs{
+	expectedArgs := pipelineschecksextras.UpdateCheckConfigurationArgs{
 		Project:       &branchControlCheckProjectID,
 		Configuration: &branchControlCheckTest,
 		Id:            &branchControlCheckID,
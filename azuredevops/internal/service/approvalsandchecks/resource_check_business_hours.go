@@ -7,7 +7,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"github.com/microsoft/azure-devops-go-api/azuredevops/pipelineschecks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
 )
 
 var evaulateBusinessHoursDefVersion = "0.0.1"
@@ -90,7 +91,7 @@ func ResourceCheckBusinessHours() *schema.Resource {
 	return r
 }
 
-func flattenBusinessHours(d *schema.ResourceData, businessHoursCheck *pipelineschecks.CheckConfiguration, projectID string) error {
+func flattenBusinessHours(d *schema.ResourceData, clients *client.AggregatedClient, businessHoursCheck *pipelineschecksextras.CheckConfiguration, projectID string) error {
 	err := doBaseFlattening(d, businessHoursCheck, projectID, evaulateBusinessHoursDefId, evaluateBranchProtectionDefVersion)
 	if err != nil {
 		return err
@@ -131,7 +132,7 @@ func flattenBusinessHours(d *schema.ResourceData, businessHoursCheck *pipelinesc
 	return nil
 }
 
-func expandBusinessHours(d *schema.ResourceData) (*pipelineschecks.CheckConfiguration, string, error) {
+func expandBusinessHours(d *schema.ResourceData, clients *client.AggregatedClient) (*pipelineschecksextras.CheckConfiguration, string, error) {
 	var days []string
 	for _, day := range daysOfBusinessWeek {
 		if d.Get(day.TfName).(bool) {
@@ -15,6 +15,8 @@ import (
 	"github.com/microsoft/azure-devops-go-api/azuredevops/pipelineschecks"
 	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
+	mock_pipelineschecksextras "github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras/mocks"
 	"github.com/stretchr/testify/require"
 )
 
@@ -35,19 +37,21 @@ var CheckBusinessHoursSettings = map[string]interface{}{
 	"inputs":        CheckBusinessHoursInputs,
 }
 
-var CheckBusinessHoursTest = pipelineschecks.CheckConfiguration{
-	Id:       &CheckBusinessHoursID,
-	Type:     &taskCheckType,
-	Settings: CheckBusinessHoursSettings,
-	Resource: &endpointResource,
+var CheckBusinessHoursTest = pipelineschecksextras.CheckConfiguration{
+	CheckConfiguration: pipelineschecks.CheckConfiguration{
+		Id:       &CheckBusinessHoursID,
+		Type:     &taskCheckType,
+		Settings: CheckBusinessHoursSettings,
+		Resource: &endpointResource,
+	},
 }
 
 // verifies that the flatten/expand round trip yields the same business hours check
 func TestCheckBusinessHours_ExpandFlatten_Roundtrip(t *testing.T) {
 	resourceData := schema.TestResourceDataRaw(t, ResourceCheckBusinessHours().Schema, nil)
-	flattenBusinessHours(resourceData, &CheckBusinessHoursTest, CheckBusinessHoursProjectID)
+	flattenBusinessHours(resourceData, nil, &CheckBusinessHoursTest, CheckBusinessHoursProjectID)
 
-	CheckBusinessHoursAfterRoundTrip, projectID, err := expandBusinessHours(resourceData)
+	CheckBusinessHoursAfterRoundTrip, projectID, err := expandBusinessHours(resourceData, nil)
 
 	require.Equal(t, CheckBusinessHoursTest, *CheckBusinessHoursAfterRoundTrip)
 	require.Equal(t, CheckBusinessHoursProjectID, projectID)
@@ -61,12 +65,12 @@ func TestCheckBusinessHours_Create_DoesNotSwallowError(t *testing.T) {
 
 	r := ResourceCheckBusinessHours()
 	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
-	flattenBusinessHours(resourceData, &CheckBusinessHoursTest, CheckBusinessHoursProjectID)
+	flattenBusinessHours(resourceData, nil, &CheckBusinessHoursTest, CheckBusinessHoursProjectID)
 
-	pipelinesCheckClient := azdosdkmocks.NewPipelinesChecksClientV5(ctrl)
-	clients := &client.AggregatedClient{V5PipelinesChecksClient: pipelinesCheckClient, Ctx: context.Background()}
+	pipelinesCheckClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesCheckClient, Ctx: context.Background()}
 
-	expectedArgs := pipelineschecks.AddCheckConfigurationArgs{Configuration: &CheckBusinessHoursTest, Project: &CheckBusinessHoursProjectID}
+	expectedArgs := pipelineschecksextras.AddCheckConfigurationArgs{Configuration: &CheckBusinessHoursTest, Project: &CheckBusinessHoursProjectID}
 	pipelinesCheckClient.
 		EXPECT().
 		AddCheckConfiguration(clients.Ctx, expectedArgs).
@@ -84,9 +88,9 @@ func TestCheckBusinessHours_Read_DoesNotSwallowError(t *testing.T) {
 
 	r := ResourceCheckBusinessHours()
 	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
-	flattenBusinessHours(resourceData, &CheckBusinessHoursTest, CheckBusinessHoursProjectID)
+	flattenBusinessHours(resourceData, nil, &CheckBusinessHoursTest, CheckBusinessHoursProjectID)
 
-	pipelinesCheckClient := azdosdkmocks.NewPipelinesChecksClientExtrasV5(ctrl)
+	pipelinesCheckClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
 	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesCheckClient, Ctx: context.Background()}
 
 	expectedArgs := pipelineschecks.GetCheckConfigurationArgs{
@@ -111,7 +115,7 @@ func TestCheckBusinessHours_Delete_DoesNotSwallowError(t *testing.T) {
 
 	r := ResourceCheckBusinessHours()
 	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
-	flattenBusinessHours(resourceData, &CheckBusinessHoursTest, CheckBusinessHoursProjectID)
+	flattenBusinessHours(resourceData, nil, &CheckBusinessHoursTest, CheckBusinessHoursProjectID)
 
 	pipelinesCheckClient := azdosdkmocks.NewPipelinesChecksClientV5(ctrl)
 	clients := &client.AggregatedClient{V5PipelinesChecksClient: pipelinesCheckClient, Ctx: context.Background()}
@@ -138,12 +142,12 @@ func TestCheckBusinessHours_Update_DoesNotSwallowError(t *testing.T) {
 
 	r := ResourceCheckBusinessHours()
 	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
-	flattenBusinessHours(resourceData, &CheckBusinessHoursTest, CheckBusinessHoursProjectID)
+	flattenBusinessHours(resourceData, nil, &CheckBusinessHoursTest, CheckBusinessHoursProjectID)
 
-	pipelinesCheckClient := azdosdkmocks.NewPipelinesChecksClientV5(ctrl)
-	clients := &client.AggregatedClient{V5PipelinesChecksClient: pipelinesCheckClient, Ctx: context.Background()}
+	pipelinesCheckClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesCheckClient, Ctx: context.Background()}
 
-	expectedArgs := pipelineschecks.UpdateCheckConfigurationArgs{
+	expectedArgs := pipelineschecksextras.UpdateCheckConfigurationArgs{
 		Project:       &CheckBusinessHoursProjectID,
 		Configuration: &CheckBusinessHoursTest,
 		Id:            &CheckBusinessHoursID,
@@ -0,0 +1,55 @@
+package approvalsandchecks
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/pipelineschecks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
+)
+
+var exclusiveLockCheckType = pipelineschecks.CheckType{
+	Id:   converter.UUID("2ef31ad6-baa0-403a-8b45-2cbc9b4e5563"),
+	Name: converter.String("ExclusiveLock"),
+}
+
+// ResourceCheckExclusiveLock schema and implementation for exclusive lock check resources
+func ResourceCheckExclusiveLock() *schema.Resource {
+	r := genBaseCheckResource(flattenExclusiveLockCheck, expandExclusiveLockCheck)
+
+	// Exclusive lock checks do not have a display name, the web UI always shows "Exclusive Lock"
+	delete(r.Schema, "display_name")
+
+	r.Schema["timeout"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      43200,
+		ValidateFunc: validation.IntBetween(1, 43200),
+	}
+
+	return r
+}
+
+func flattenExclusiveLockCheck(d *schema.ResourceData, clients *client.AggregatedClient, exclusiveLockCheck *pipelineschecksextras.CheckConfiguration, projectID string) error {
+	err := doCheckFlattening(d, exclusiveLockCheck, projectID)
+	if err != nil {
+		return err
+	}
+
+	if exclusiveLockCheck.Type == nil || exclusiveLockCheck.Type.Id == nil || *exclusiveLockCheck.Type.Id != *exclusiveLockCheckType.Id {
+		return fmt.Errorf("Check is not an exclusive lock check")
+	}
+
+	if exclusiveLockCheck.Timeout != nil {
+		d.Set("timeout", *exclusiveLockCheck.Timeout)
+	}
+
+	return nil
+}
+
+func expandExclusiveLockCheck(d *schema.ResourceData, clients *client.AggregatedClient) (*pipelineschecksextras.CheckConfiguration, string, error) {
+	return doCheckExpansion(d, &exclusiveLockCheckType, map[string]interface{}{}, converter.Int(d.Get("timeout").(int)))
+}
@@ -0,0 +1,134 @@
+//go:build (all || resource_check_exclusive_lock) && !exclude_approvalsandchecks
+// +build all resource_check_exclusive_lock
+// +build !exclude_approvalsandchecks
+
+package approvalsandchecks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/pipelineschecks"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
+	mock_pipelineschecksextras "github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+var exclusiveLockCheckID = 123456789
+var exclusiveLockCheckProjectID = uuid.New().String()
+
+var exclusiveLockCheckTimeout = 1440
+
+var exclusiveLockCheckTest = pipelineschecksextras.CheckConfiguration{
+	CheckConfiguration: pipelineschecks.CheckConfiguration{
+		Id:       &exclusiveLockCheckID,
+		Type:     &exclusiveLockCheckType,
+		Settings: map[string]interface{}{},
+		Resource: &endpointResource,
+	},
+	Timeout: &exclusiveLockCheckTimeout,
+}
+
+// verifies that the flatten/expand round trip yields the same exclusive lock check
+func TestCheckExclusiveLock_ExpandFlatten_Roundtrip(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceCheckExclusiveLock().Schema, nil)
+	err := flattenExclusiveLockCheck(resourceData, nil, &exclusiveLockCheckTest, exclusiveLockCheckProjectID)
+	require.Nil(t, err)
+	require.Equal(t, exclusiveLockCheckTimeout, resourceData.Get("timeout"))
+
+	exclusiveLockCheckAfterRoundTrip, projectID, err := expandExclusiveLockCheck(resourceData, nil)
+
+	require.Equal(t, exclusiveLockCheckTest, *exclusiveLockCheckAfterRoundTrip)
+	require.Equal(t, exclusiveLockCheckProjectID, projectID)
+	require.Nil(t, err)
+}
+
+// verifies that a check of another type is not flattened into an exclusive lock check
+func TestCheckExclusiveLock_Flatten_RejectsOtherCheckTypes(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceCheckExclusiveLock().Schema, nil)
+	err := flattenExclusiveLockCheck(resourceData, nil, &branchControlCheckTest, exclusiveLockCheckProjectID)
+	require.NotNil(t, err)
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestCheckExclusiveLock_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckExclusiveLock()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenExclusiveLockCheck(resourceData, nil, &exclusiveLockCheckTest, exclusiveLockCheckProjectID)
+
+	pipelinesChecksClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesChecksClient, Ctx: context.Background()}
+
+	expectedArgs := pipelineschecksextras.AddCheckConfigurationArgs{Configuration: &exclusiveLockCheckTest, Project: &exclusiveLockCheckProjectID}
+	pipelinesChecksClient.
+		EXPECT().
+		AddCheckConfiguration(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("AddCheckConfiguration() Failed")).
+		Times(1)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "AddCheckConfiguration() Failed")
+}
+
+// verifies that if an error is produced on a read, it is not swallowed
+func TestCheckExclusiveLock_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckExclusiveLock()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenExclusiveLockCheck(resourceData, nil, &exclusiveLockCheckTest, exclusiveLockCheckProjectID)
+
+	pipelinesChecksClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesChecksClient, Ctx: context.Background()}
+
+	expectedArgs := pipelineschecks.GetCheckConfigurationArgs{
+		Id:      &exclusiveLockCheckID,
+		Project: &exclusiveLockCheckProjectID,
+	}
+
+	pipelinesChecksClient.
+		EXPECT().
+		GetCheckConfiguration(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("GetCheckConfiguration() Failed")).
+		Times(1)
+
+	err := r.Read(resourceData, clients)
+	require.Contains(t, err.Error(), "GetCheckConfiguration() Failed")
+}
+
+// verifies that if an error is produced on a delete, it is not swallowed
+func TestCheckExclusiveLock_Delete_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckExclusiveLock()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenExclusiveLockCheck(resourceData, nil, &exclusiveLockCheckTest, exclusiveLockCheckProjectID)
+
+	pipelinesChecksClient := azdosdkmocks.NewPipelinesChecksClientV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClient: pipelinesChecksClient, Ctx: context.Background()}
+
+	expectedArgs := pipelineschecks.DeleteCheckConfigurationArgs{
+		Id:      &exclusiveLockCheckID,
+		Project: &exclusiveLockCheckProjectID,
+	}
+
+	pipelinesChecksClient.
+		EXPECT().
+		DeleteCheckConfiguration(clients.Ctx, expectedArgs).
+		Return(errors.New("DeleteCheckConfiguration() Failed")).
+		Times(1)
+
+	err := r.Delete(resourceData, clients)
+	require.Contains(t, err.Error(), "DeleteCheckConfiguration() Failed")
+}
@@ -0,0 +1,191 @@
+package approvalsandchecks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+var queryAzureMonitorAlertsDefVersion = "1.198.0"
+var queryAzureMonitorAlertsDefId = "b9f6b0ff-a4b8-4e8e-a7fd-1cbc4cd30bde"
+
+var queryAzureMonitorAlertsDef = map[string]interface{}{
+	"id":      queryAzureMonitorAlertsDefId,
+	"name":    "AzureMonitor",
+	"version": queryAzureMonitorAlertsDefVersion,
+}
+
+var azureMonitorAlertSeverities = []string{"Sev0", "Sev1", "Sev2", "Sev3", "Sev4"}
+var azureMonitorAlertStates = []string{"New", "Acknowledged", "Closed"}
+var azureMonitorConditions = []string{"Fired", "Resolved"}
+var azureMonitorTimeRanges = []string{"1h", "1d", "7d", "30d"}
+
+// ResourceCheckQueryAzureMonitorAlerts schema and implementation for query Azure Monitor alerts check resources
+func ResourceCheckQueryAzureMonitorAlerts() *schema.Resource {
+	r := genBaseCheckResource(flattenQueryAzureMonitorAlertsCheck, expandQueryAzureMonitorAlertsCheck)
+	addEvaluationSchema(r)
+
+	r.Schema["delay"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      0,
+		ValidateFunc: validation.IntAtLeast(0),
+	}
+	r.Schema["azurerm_service_connection_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.IsUUID,
+	}
+	r.Schema["subscription"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.IsUUID,
+	}
+	r.Schema["resource_group_name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.StringIsNotWhiteSpace,
+	}
+	r.Schema["alert_rules"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringIsNotWhiteSpace,
+		},
+	}
+	r.Schema["severities"] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringInSlice(azureMonitorAlertSeverities, false),
+		},
+		Set: schema.HashString,
+	}
+	r.Schema["time_range"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "1h",
+		ValidateFunc: validation.StringInSlice(azureMonitorTimeRanges, false),
+	}
+	r.Schema["alert_states"] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringInSlice(azureMonitorAlertStates, false),
+		},
+		Set: schema.HashString,
+	}
+	r.Schema["monitor_condition"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "Fired",
+		ValidateFunc: validation.StringInSlice(azureMonitorConditions, false),
+	}
+
+	return r
+}
+
+func flattenQueryAzureMonitorAlertsCheck(d *schema.ResourceData, clients *client.AggregatedClient, azureMonitorCheck *pipelineschecksextras.CheckConfiguration, projectID string) error {
+	err := doEvaluationFlattening(d, azureMonitorCheck, projectID, queryAzureMonitorAlertsDefId, queryAzureMonitorAlertsDefVersion)
+	if err != nil {
+		return err
+	}
+
+	if initialDelay, found := azureMonitorCheck.Settings.(map[string]interface{})["initialDelay"]; found {
+		value, err := settingToInt(initialDelay)
+		if err != nil {
+			return fmt.Errorf("initialDelay: %+v", err)
+		}
+		d.Set("delay", value)
+	} else {
+		d.Set("delay", 0)
+	}
+
+	if inputMap, found := azureMonitorCheck.Settings.(map[string]interface{})["inputs"]; found {
+		inputs := inputMap.(map[string]interface{})
+		if serviceConnectionID, found := inputs["connectedServiceNameARM"]; found {
+			d.Set("azurerm_service_connection_id", serviceConnectionID)
+		} else {
+			return fmt.Errorf("connectedServiceNameARM input not found")
+		}
+		d.Set("subscription", inputToString(inputs, "subscription"))
+		if resourceGroupName, found := inputs["ResourceGroupName"]; found {
+			d.Set("resource_group_name", resourceGroupName)
+		} else {
+			return fmt.Errorf("ResourceGroupName input not found")
+		}
+
+		if strings.EqualFold(inputToString(inputs, "filterType"), "alertrule") {
+			d.Set("alert_rules", splitInput(inputToString(inputs, "alertRule")))
+		} else {
+			d.Set("alert_rules", []string{})
+		}
+
+		d.Set("severities", splitInput(inputToString(inputs, "severity")))
+		d.Set("time_range", inputToString(inputs, "timeRange"))
+		d.Set("alert_states", splitInput(inputToString(inputs, "alertState")))
+		d.Set("monitor_condition", inputToString(inputs, "monitorCondition"))
+	} else {
+		return fmt.Errorf("inputs not found")
+	}
+
+	return nil
+}
+
+func expandQueryAzureMonitorAlertsCheck(d *schema.ResourceData, clients *client.AggregatedClient) (*pipelineschecksextras.CheckConfiguration, string, error) {
+	severities := tfhelper.ExpandStringSet(d.Get("severities").(*schema.Set))
+	if len(severities) == 0 {
+		severities = azureMonitorAlertSeverities
+	}
+	sort.Strings(severities)
+	alertStates := tfhelper.ExpandStringSet(d.Get("alert_states").(*schema.Set))
+	if len(alertStates) == 0 {
+		alertStates = []string{"Acknowledged", "New"}
+	}
+	sort.Strings(alertStates)
+
+	inputs := map[string]interface{}{
+		"connectedServiceNameARM": d.Get("azurerm_service_connection_id").(string),
+		"subscription":            d.Get("subscription").(string),
+		"ResourceGroupName":       d.Get("resource_group_name").(string),
+		"filterType":              "none",
+		"severity":                strings.Join(severities, ","),
+		"timeRange":               d.Get("time_range").(string),
+		"alertState":              strings.Join(alertStates, ","),
+		"monitorCondition":        d.Get("monitor_condition").(string),
+	}
+	if alertRules := tfhelper.ExpandStringList(d.Get("alert_rules").([]interface{})); len(alertRules) > 0 {
+		inputs["filterType"] = "alertrule"
+		inputs["alertRule"] = strings.Join(alertRules, ",")
+	}
+
+	check, projectID, err := doEvaluationExpansion(d, inputs, queryAzureMonitorAlertsDef)
+	if err != nil {
+		return nil, "", err
+	}
+	check.Settings.(map[string]interface{})["initialDelay"] = d.Get("delay").(int)
+
+	return check, projectID, nil
+}
+
+// splitInput splits a comma separated task input into its values
+func splitInput(input string) []string {
+	values := []string{}
+	for _, value := range strings.Split(input, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
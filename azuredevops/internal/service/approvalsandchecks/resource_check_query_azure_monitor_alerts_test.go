@@ -0,0 +1,182 @@
+//go:build (all || resource_check_query_azure_monitor_alerts) && !exclude_approvalsandchecks
+// +build all resource_check_query_azure_monitor_alerts
+// +build !exclude_approvalsandchecks
+
+package approvalsandchecks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/pipelineschecks"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
+	mock_pipelineschecksextras "github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+var CheckQueryAzureMonitorAlertsID = 123456789
+var CheckQueryAzureMonitorAlertsProjectID = uuid.New().String()
+var CheckQueryAzureMonitorAlertsTimeout = 60
+
+var CheckQueryAzureMonitorAlertsInputs = map[string]interface{}{
+	"connectedServiceNameARM": uuid.New().String(),
+	"subscription":            uuid.New().String(),
+	"ResourceGroupName":       "production-rg",
+	"filterType":              "alertrule",
+	"alertRule":               "cpu-alert,memory-alert",
+	"severity":                "Sev0,Sev1",
+	"timeRange":               "1d",
+	"alertState":              "Acknowledged,New",
+	"monitorCondition":        "Fired",
+}
+
+var CheckQueryAzureMonitorAlertsSettings = map[string]interface{}{
+	"definitionRef": queryAzureMonitorAlertsDef,
+	"displayName":   "Test Azure Monitor Alerts",
+	"inputs":        CheckQueryAzureMonitorAlertsInputs,
+	"retryInterval": 10,
+	"initialDelay":  5,
+}
+
+var CheckQueryAzureMonitorAlertsTest = pipelineschecksextras.CheckConfiguration{
+	CheckConfiguration: pipelineschecks.CheckConfiguration{
+		Id:       &CheckQueryAzureMonitorAlertsID,
+		Type:     &taskCheckType,
+		Settings: CheckQueryAzureMonitorAlertsSettings,
+		Resource: &endpointResource,
+	},
+	Timeout: &CheckQueryAzureMonitorAlertsTimeout,
+}
+
+// verifies that the flatten/expand round trip yields the same query Azure Monitor alerts check
+func TestCheckQueryAzureMonitorAlerts_ExpandFlatten_Roundtrip(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceCheckQueryAzureMonitorAlerts().Schema, nil)
+	err := flattenQueryAzureMonitorAlertsCheck(resourceData, nil, &CheckQueryAzureMonitorAlertsTest, CheckQueryAzureMonitorAlertsProjectID)
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{"cpu-alert", "memory-alert"}, resourceData.Get("alert_rules"))
+	require.Equal(t, 5, resourceData.Get("delay"))
+
+	CheckQueryAzureMonitorAlertsAfterRoundTrip, projectID, err := expandQueryAzureMonitorAlertsCheck(resourceData, nil)
+
+	require.Equal(t, CheckQueryAzureMonitorAlertsTest, *CheckQueryAzureMonitorAlertsAfterRoundTrip)
+	require.Equal(t, CheckQueryAzureMonitorAlertsProjectID, projectID)
+	require.Nil(t, err)
+}
+
+// verifies that a check using another task is not flattened into a query Azure Monitor alerts check
+func TestCheckQueryAzureMonitorAlerts_Flatten_RejectsOtherDefinitions(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceCheckQueryAzureMonitorAlerts().Schema, nil)
+	err := flattenQueryAzureMonitorAlertsCheck(resourceData, nil, &branchControlCheckTest, CheckQueryAzureMonitorAlertsProjectID)
+	require.NotNil(t, err)
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestCheckQueryAzureMonitorAlerts_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckQueryAzureMonitorAlerts()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenQueryAzureMonitorAlertsCheck(resourceData, nil, &CheckQueryAzureMonitorAlertsTest, CheckQueryAzureMonitorAlertsProjectID)
+
+	pipelinesCheckClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesCheckClient, Ctx: context.Background()}
+
+	expectedArgs := pipelineschecksextras.AddCheckConfigurationArgs{Configuration: &CheckQueryAzureMonitorAlertsTest, Project: &CheckQueryAzureMonitorAlertsProjectID}
+	pipelinesCheckClient.
+		EXPECT().
+		AddCheckConfiguration(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("AddCheckConfiguration() Failed")).
+		Times(1)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "AddCheckConfiguration() Failed")
+}
+
+// verifies that if an error is produced on a read, it is not swallowed
+func TestCheckQueryAzureMonitorAlerts_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckQueryAzureMonitorAlerts()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenQueryAzureMonitorAlertsCheck(resourceData, nil, &CheckQueryAzureMonitorAlertsTest, CheckQueryAzureMonitorAlertsProjectID)
+
+	pipelinesCheckClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesCheckClient, Ctx: context.Background()}
+
+	expectedArgs := pipelineschecks.GetCheckConfigurationArgs{
+		Id:      CheckQueryAzureMonitorAlertsTest.Id,
+		Project: &CheckQueryAzureMonitorAlertsProjectID,
+	}
+
+	pipelinesCheckClient.
+		EXPECT().
+		GetCheckConfiguration(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("GetCheckConfiguration() Failed")).
+		Times(1)
+
+	err := r.Read(resourceData, clients)
+	require.Contains(t, err.Error(), "GetCheckConfiguration() Failed")
+}
+
+// verifies that if an error is produced on an update, it is not swallowed
+func TestCheckQueryAzureMonitorAlerts_Update_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckQueryAzureMonitorAlerts()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenQueryAzureMonitorAlertsCheck(resourceData, nil, &CheckQueryAzureMonitorAlertsTest, CheckQueryAzureMonitorAlertsProjectID)
+
+	pipelinesCheckClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesCheckClient, Ctx: context.Background()}
+
+	expectedArgs := pipelineschecksextras.UpdateCheckConfigurationArgs{
+		Project:       &CheckQueryAzureMonitorAlertsProjectID,
+		Configuration: &CheckQueryAzureMonitorAlertsTest,
+		Id:            &CheckQueryAzureMonitorAlertsID,
+	}
+
+	pipelinesCheckClient.
+		EXPECT().
+		UpdateCheckConfiguration(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("UpdateCheckConfiguration() Failed")).
+		Times(1)
+
+	err := r.Update(resourceData, clients)
+	require.Contains(t, err.Error(), "UpdateCheckConfiguration() Failed")
+}
+
+// verifies that if an error is produced on a delete, it is not swallowed
+func TestCheckQueryAzureMonitorAlerts_Delete_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckQueryAzureMonitorAlerts()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenQueryAzureMonitorAlertsCheck(resourceData, nil, &CheckQueryAzureMonitorAlertsTest, CheckQueryAzureMonitorAlertsProjectID)
+
+	pipelinesCheckClient := azdosdkmocks.NewPipelinesChecksClientV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClient: pipelinesCheckClient, Ctx: context.Background()}
+
+	expectedArgs := pipelineschecks.DeleteCheckConfigurationArgs{
+		Id:      CheckQueryAzureMonitorAlertsTest.Id,
+		Project: &CheckQueryAzureMonitorAlertsProjectID,
+	}
+
+	pipelinesCheckClient.
+		EXPECT().
+		DeleteCheckConfiguration(clients.Ctx, expectedArgs).
+		Return(errors.New("DeleteCheckConfiguration() Failed")).
+		Times(1)
+
+	err := r.Delete(resourceData, clients)
+	require.Contains(t, err.Error(), "DeleteCheckConfiguration() Failed")
+}
@@ -0,0 +1,138 @@
+package approvalsandchecks
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/pipelineschecks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
+)
+
+var requiredTemplateCheckType = pipelineschecks.CheckType{
+	Id:   converter.UUID("4020e66e-b0f3-47e1-bc88-48f3cc59b5f3"),
+	Name: converter.String("ExtendsCheck"),
+}
+
+// The service identifies Azure Repos Git repositories as "git"
+var requiredTemplateRepositoryTypes = map[string]string{
+	"azuregit":  "git",
+	"github":    "github",
+	"bitbucket": "bitbucket",
+}
+
+type requiredTemplate struct {
+	RepositoryType string `json:"repositoryType"`
+	RepositoryName string `json:"repositoryName"`
+	RepositoryRef  string `json:"repositoryRef"`
+	TemplatePath   string `json:"templatePath"`
+}
+
+type requiredTemplateCheckSettings struct {
+	ExtendsChecks []requiredTemplate `json:"extendsChecks"`
+}
+
+// ResourceCheckRequiredTemplate schema and implementation for required template check resources
+func ResourceCheckRequiredTemplate() *schema.Resource {
+	r := genBaseCheckResource(flattenRequiredTemplateCheck, expandRequiredTemplateCheck)
+
+	// Required template checks do not have a display name, the web UI always shows "Required template"
+	delete(r.Schema, "display_name")
+
+	r.Schema["required_template"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		MinItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"repository_type": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "azuregit",
+					ValidateFunc: validation.StringInSlice([]string{"azuregit", "github", "bitbucket"}, false),
+				},
+				"repository_name": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				"repository_ref": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				"template_path": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+		},
+	}
+
+	return r
+}
+
+func flattenRequiredTemplateCheck(d *schema.ResourceData, clients *client.AggregatedClient, requiredTemplateCheck *pipelineschecksextras.CheckConfiguration, projectID string) error {
+	err := doCheckFlattening(d, requiredTemplateCheck, projectID)
+	if err != nil {
+		return err
+	}
+
+	if requiredTemplateCheck.Type == nil || requiredTemplateCheck.Type.Id == nil || *requiredTemplateCheck.Type.Id != *requiredTemplateCheckType.Id {
+		return fmt.Errorf("Check is not a required template check")
+	}
+
+	settingsJSON, err := json.Marshal(requiredTemplateCheck.Settings)
+	if err != nil {
+		return fmt.Errorf("Unable to marshal required template check settings into JSON: %+v", err)
+	}
+
+	var settings requiredTemplateCheckSettings
+	err = json.Unmarshal(settingsJSON, &settings)
+	if err != nil {
+		return fmt.Errorf("Unable to unmarshal required template check settings: %+v", err)
+	}
+
+	templates := make([]interface{}, len(settings.ExtendsChecks))
+	for i, template := range settings.ExtendsChecks {
+		repositoryType := template.RepositoryType
+		for tfType, apiType := range requiredTemplateRepositoryTypes {
+			if apiType == template.RepositoryType {
+				repositoryType = tfType
+			}
+		}
+		templates[i] = map[string]interface{}{
+			"repository_type": repositoryType,
+			"repository_name": template.RepositoryName,
+			"repository_ref":  template.RepositoryRef,
+			"template_path":   template.TemplatePath,
+		}
+	}
+	d.Set("required_template", templates)
+
+	return nil
+}
+
+func expandRequiredTemplateCheck(d *schema.ResourceData, clients *client.AggregatedClient) (*pipelineschecksextras.CheckConfiguration, string, error) {
+	templateList := d.Get("required_template").([]interface{})
+	templates := make([]interface{}, len(templateList))
+	for i, item := range templateList {
+		template := item.(map[string]interface{})
+		templates[i] = map[string]interface{}{
+			"repositoryType": requiredTemplateRepositoryTypes[template["repository_type"].(string)],
+			"repositoryName": template["repository_name"].(string),
+			"repositoryRef":  template["repository_ref"].(string),
+			"templatePath":   template["template_path"].(string),
+		}
+	}
+
+	settings := map[string]interface{}{
+		"extendsChecks": templates,
+	}
+
+	return doCheckExpansion(d, &requiredTemplateCheckType, settings, nil)
+}
@@ -0,0 +1,149 @@
+//go:build (all || resource_check_required_template) && !exclude_approvalsandchecks
+// +build all resource_check_required_template
+// +build !exclude_approvalsandchecks
+
+package approvalsandchecks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/pipelineschecks"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
+	mock_pipelineschecksextras "github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+var requiredTemplateCheckID = 123456789
+var requiredTemplateCheckProjectID = uuid.New().String()
+
+var requiredTemplateCheckTestSettings = map[string]interface{}{
+	"extendsChecks": []interface{}{
+		map[string]interface{}{
+			"repositoryType": "git",
+			"repositoryName": "project/templates",
+			"repositoryRef":  "refs/heads/main",
+			"templatePath":   "deploy.yml",
+		},
+		map[string]interface{}{
+			"repositoryType": "github",
+			"repositoryName": "org/templates",
+			"repositoryRef":  "refs/tags/v1",
+			"templatePath":   "templates/build.yml",
+		},
+	},
+}
+
+var requiredTemplateCheckTest = pipelineschecksextras.CheckConfiguration{
+	CheckConfiguration: pipelineschecks.CheckConfiguration{
+		Id:       &requiredTemplateCheckID,
+		Type:     &requiredTemplateCheckType,
+		Settings: requiredTemplateCheckTestSettings,
+		Resource: &endpointResource,
+	},
+}
+
+// verifies that the flatten/expand round trip yields the same required template check
+func TestCheckRequiredTemplate_ExpandFlatten_Roundtrip(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceCheckRequiredTemplate().Schema, nil)
+	err := flattenRequiredTemplateCheck(resourceData, nil, &requiredTemplateCheckTest, requiredTemplateCheckProjectID)
+	require.Nil(t, err)
+	require.Equal(t, "azuregit", resourceData.Get("required_template.0.repository_type"))
+	require.Equal(t, "github", resourceData.Get("required_template.1.repository_type"))
+
+	requiredTemplateCheckAfterRoundTrip, projectID, err := expandRequiredTemplateCheck(resourceData, nil)
+
+	require.Equal(t, requiredTemplateCheckTest, *requiredTemplateCheckAfterRoundTrip)
+	require.Equal(t, requiredTemplateCheckProjectID, projectID)
+	require.Nil(t, err)
+}
+
+// verifies that a check of another type is not flattened into a required template check
+func TestCheckRequiredTemplate_Flatten_RejectsOtherCheckTypes(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceCheckRequiredTemplate().Schema, nil)
+	err := flattenRequiredTemplateCheck(resourceData, nil, &branchControlCheckTest, requiredTemplateCheckProjectID)
+	require.NotNil(t, err)
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestCheckRequiredTemplate_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckRequiredTemplate()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenRequiredTemplateCheck(resourceData, nil, &requiredTemplateCheckTest, requiredTemplateCheckProjectID)
+
+	pipelinesChecksClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesChecksClient, Ctx: context.Background()}
+
+	expectedArgs := pipelineschecksextras.AddCheckConfigurationArgs{Configuration: &requiredTemplateCheckTest, Project: &requiredTemplateCheckProjectID}
+	pipelinesChecksClient.
+		EXPECT().
+		AddCheckConfiguration(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("AddCheckConfiguration() Failed")).
+		Times(1)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "AddCheckConfiguration() Failed")
+}
+
+// verifies that if an error is produced on a read, it is not swallowed
+func TestCheckRequiredTemplate_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckRequiredTemplate()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenRequiredTemplateCheck(resourceData, nil, &requiredTemplateCheckTest, requiredTemplateCheckProjectID)
+
+	pipelinesChecksClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesChecksClient, Ctx: context.Background()}
+
+	expectedArgs := pipelineschecks.GetCheckConfigurationArgs{
+		Id:      &requiredTemplateCheckID,
+		Project: &requiredTemplateCheckProjectID,
+	}
+
+	pipelinesChecksClient.
+		EXPECT().
+		GetCheckConfiguration(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("GetCheckConfiguration() Failed")).
+		Times(1)
+
+	err := r.Read(resourceData, clients)
+	require.Contains(t, err.Error(), "GetCheckConfiguration() Failed")
+}
+
+// verifies that if an error is produced on a delete, it is not swallowed
+func TestCheckRequiredTemplate_Delete_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckRequiredTemplate()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenRequiredTemplateCheck(resourceData, nil, &requiredTemplateCheckTest, requiredTemplateCheckProjectID)
+
+	pipelinesChecksClient := azdosdkmocks.NewPipelinesChecksClientV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClient: pipelinesChecksClient, Ctx: context.Background()}
+
+	expectedArgs := pipelineschecks.DeleteCheckConfigurationArgs{
+		Id:      &requiredTemplateCheckID,
+		Project: &requiredTemplateCheckProjectID,
+	}
+
+	pipelinesChecksClient.
+		EXPECT().
+		DeleteCheckConfiguration(clients.Ctx, expectedArgs).
+		Return(errors.New("DeleteCheckConfiguration() Failed")).
+		Times(1)
+
+	err := r.Delete(resourceData, clients)
+	require.Contains(t, err.Error(), "DeleteCheckConfiguration() Failed")
+}
@@ -0,0 +1,110 @@
+package approvalsandchecks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
+)
+
+var invokeRestAPIDefVersion = "1.220.0"
+var invokeRestAPIDefId = "9c3e8943-130d-4c78-ac63-8af81df62dfb"
+
+var invokeRestAPIDef = map[string]interface{}{
+	"id":      invokeRestAPIDefId,
+	"name":    "InvokeRESTAPI",
+	"version": invokeRestAPIDefVersion,
+}
+
+// The InvokeRESTAPI task selects the input holding the service connection based on its type
+var restAPIServiceConnectionInputs = map[string]string{
+	"generic": "connectedServiceName",
+	"azurerm": "connectedServiceNameARM",
+}
+
+// ResourceCheckRestAPI schema and implementation for invoke REST API check resources
+func ResourceCheckRestAPI() *schema.Resource {
+	r := genBaseCheckResource(flattenRestAPICheck, expandRestAPICheck)
+	addEvaluationSchema(r)
+
+	r.Schema["service_connection_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.IsUUID,
+	}
+	r.Schema["service_connection_type"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "generic",
+		ValidateFunc: validation.StringInSlice([]string{"generic", "azurerm"}, false),
+	}
+	r.Schema["url_suffix"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  "",
+	}
+	addHTTPRequestSchema(r)
+
+	return r
+}
+
+func flattenRestAPICheck(d *schema.ResourceData, clients *client.AggregatedClient, restAPICheck *pipelineschecksextras.CheckConfiguration, projectID string) error {
+	err := doEvaluationFlattening(d, restAPICheck, projectID, invokeRestAPIDefId, invokeRestAPIDefVersion)
+	if err != nil {
+		return err
+	}
+
+	flattenLinkedVariableGroup(d, restAPICheck)
+
+	if inputMap, found := restAPICheck.Settings.(map[string]interface{})["inputs"]; found {
+		inputs := inputMap.(map[string]interface{})
+
+		serviceConnectionType := "generic"
+		if selector, found := inputs["connectedServiceNameSelector"]; found {
+			for connectionType, input := range restAPIServiceConnectionInputs {
+				if strings.EqualFold(selector.(string), input) {
+					serviceConnectionType = connectionType
+				}
+			}
+		}
+		d.Set("service_connection_type", serviceConnectionType)
+
+		if serviceConnectionID, found := inputs[restAPIServiceConnectionInputs[serviceConnectionType]]; found {
+			d.Set("service_connection_id", serviceConnectionID)
+		} else {
+			return fmt.Errorf("%s input not found", restAPIServiceConnectionInputs[serviceConnectionType])
+		}
+
+		d.Set("url_suffix", inputToString(inputs, "urlSuffix"))
+
+		err = flattenHTTPRequestInputs(d, inputs)
+		if err != nil {
+			return err
+		}
+	} else {
+		return fmt.Errorf("inputs not found")
+	}
+
+	return nil
+}
+
+func expandRestAPICheck(d *schema.ResourceData, clients *client.AggregatedClient) (*pipelineschecksextras.CheckConfiguration, string, error) {
+	serviceConnectionInput := restAPIServiceConnectionInputs[d.Get("service_connection_type").(string)]
+	inputs := map[string]interface{}{
+		"connectedServiceNameSelector": serviceConnectionInput,
+		serviceConnectionInput:         d.Get("service_connection_id").(string),
+		"urlSuffix":                    d.Get("url_suffix").(string),
+	}
+	expandHTTPRequestInputs(d, inputs)
+
+	check, projectID, err := doEvaluationExpansion(d, inputs, invokeRestAPIDef)
+	if err != nil {
+		return nil, "", err
+	}
+	expandLinkedVariableGroup(d, check)
+
+	return check, projectID, nil
+}
@@ -0,0 +1,181 @@
+//go:build (all || resource_check_rest_api) && !exclude_approvalsandchecks
+// +build all resource_check_rest_api
+// +build !exclude_approvalsandchecks
+
+package approvalsandchecks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/pipelineschecks"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
+	mock_pipelineschecksextras "github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+var CheckRestAPIID = 123456789
+var CheckRestAPIProjectID = uuid.New().String()
+var CheckRestAPITimeout = 60
+
+var CheckRestAPIInputs = map[string]interface{}{
+	"connectedServiceNameSelector": "connectedServiceNameARM",
+	"connectedServiceNameARM":      uuid.New().String(),
+	"urlSuffix":                    "/api/health",
+	"method":                       "GET",
+	"headers":                      "{\"Content-Type\":\"application/json\"}",
+	"body":                         "",
+	"waitForCompletion":            "false",
+	"successCriteria":              "eq(root['status'], 'healthy')",
+}
+
+var CheckRestAPISettings = map[string]interface{}{
+	"definitionRef":       invokeRestAPIDef,
+	"displayName":         "Test REST API",
+	"inputs":              CheckRestAPIInputs,
+	"retryInterval":       10,
+	"linkedVariableGroup": "Test Variable Group",
+}
+
+var CheckRestAPITest = pipelineschecksextras.CheckConfiguration{
+	CheckConfiguration: pipelineschecks.CheckConfiguration{
+		Id:       &CheckRestAPIID,
+		Type:     &taskCheckType,
+		Settings: CheckRestAPISettings,
+		Resource: &endpointResource,
+	},
+	Timeout: &CheckRestAPITimeout,
+}
+
+// verifies that the flatten/expand round trip yields the same REST API check
+func TestCheckRestAPI_ExpandFlatten_Roundtrip(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceCheckRestAPI().Schema, nil)
+	err := flattenRestAPICheck(resourceData, nil, &CheckRestAPITest, CheckRestAPIProjectID)
+	require.Nil(t, err)
+	require.Equal(t, "azurerm", resourceData.Get("service_connection_type"))
+	require.Equal(t, "ApiResponse", resourceData.Get("completion_event"))
+
+	CheckRestAPIAfterRoundTrip, projectID, err := expandRestAPICheck(resourceData, nil)
+
+	require.Equal(t, CheckRestAPITest, *CheckRestAPIAfterRoundTrip)
+	require.Equal(t, CheckRestAPIProjectID, projectID)
+	require.Nil(t, err)
+}
+
+// verifies that a check using another task is not flattened into a REST API check
+func TestCheckRestAPI_Flatten_RejectsOtherDefinitions(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceCheckRestAPI().Schema, nil)
+	err := flattenRestAPICheck(resourceData, nil, &branchControlCheckTest, CheckRestAPIProjectID)
+	require.NotNil(t, err)
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestCheckRestAPI_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckRestAPI()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenRestAPICheck(resourceData, nil, &CheckRestAPITest, CheckRestAPIProjectID)
+
+	pipelinesCheckClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesCheckClient, Ctx: context.Background()}
+
+	expectedArgs := pipelineschecksextras.AddCheckConfigurationArgs{Configuration: &CheckRestAPITest, Project: &CheckRestAPIProjectID}
+	pipelinesCheckClient.
+		EXPECT().
+		AddCheckConfiguration(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("AddCheckConfiguration() Failed")).
+		Times(1)
+
+	err := r.Create(resourceData, clients)
+	require.Contains(t, err.Error(), "AddCheckConfiguration() Failed")
+}
+
+// verifies that if an error is produced on a read, it is not swallowed
+func TestCheckRestAPI_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckRestAPI()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenRestAPICheck(resourceData, nil, &CheckRestAPITest, CheckRestAPIProjectID)
+
+	pipelinesCheckClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesCheckClient, Ctx: context.Background()}
+
+	expectedArgs := pipelineschecks.GetCheckConfigurationArgs{
+		Id:      CheckRestAPITest.Id,
+		Project: &CheckRestAPIProjectID,
+	}
+
+	pipelinesCheckClient.
+		EXPECT().
+		GetCheckConfiguration(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("GetCheckConfiguration() Failed")).
+		Times(1)
+
+	err := r.Read(resourceData, clients)
+	require.Contains(t, err.Error(), "GetCheckConfiguration() Failed")
+}
+
+// verifies that if an error is produced on an update, it is not swallowed
+func TestCheckRestAPI_Update_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckRestAPI()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenRestAPICheck(resourceData, nil, &CheckRestAPITest, CheckRestAPIProjectID)
+
+	pipelinesCheckClient := mock_pipelineschecksextras.NewPipelinesChecksClientExtrasV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClientExtras: pipelinesCheckClient, Ctx: context.Background()}
+
+	expectedArgs := pipelineschecksextras.UpdateCheckConfigurationArgs{
+		Project:       &CheckRestAPIProjectID,
+		Configuration: &CheckRestAPITest,
+		Id:            &CheckRestAPIID,
+	}
+
+	pipelinesCheckClient.
+		EXPECT().
+		UpdateCheckConfiguration(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("UpdateCheckConfiguration() Failed")).
+		Times(1)
+
+	err := r.Update(resourceData, clients)
+	require.Contains(t, err.Error(), "UpdateCheckConfiguration() Failed")
+}
+
+// verifies that if an error is produced on a delete, it is not swallowed
+func TestCheckRestAPI_Delete_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceCheckRestAPI()
+	resourceData := schema.TestResourceDataRaw(t, r.Schema, nil)
+	flattenRestAPICheck(resourceData, nil, &CheckRestAPITest, CheckRestAPIProjectID)
+
+	pipelinesCheckClient := azdosdkmocks.NewPipelinesChecksClientV5(ctrl)
+	clients := &client.AggregatedClient{V5PipelinesChecksClient: pipelinesCheckClient, Ctx: context.Background()}
+
+	expectedArgs := pipelineschecks.DeleteCheckConfigurationArgs{
+		Id:      CheckRestAPITest.Id,
+		Project: &CheckRestAPIProjectID,
+	}
+
+	pipelinesCheckClient.
+		EXPECT().
+		DeleteCheckConfiguration(clients.Ctx, expectedArgs).
+		Return(errors.New("DeleteCheckConfiguration() Failed")).
+		Times(1)
+
+	err := r.Delete(resourceData, clients)
+	require.Contains(t, err.Error(), "DeleteCheckConfiguration() Failed")
+}
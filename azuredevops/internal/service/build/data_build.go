@@ -0,0 +1,170 @@
+package build
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+// DataBuild schema and implementation for the build data source, which returns the latest build matching the filters
+func DataBuild() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceBuildRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"definition_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"branch": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(build.BuildStatusValues.NotStarted),
+					string(build.BuildStatusValues.InProgress),
+					string(build.BuildStatusValues.Cancelling),
+					string(build.BuildStatusValues.Postponed),
+					string(build.BuildStatusValues.Completed),
+				}, false),
+			},
+			"result": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(build.BuildResultValues.Succeeded),
+					string(build.BuildResultValues.PartiallySucceeded),
+					string(build.BuildResultValues.Failed),
+					string(build.BuildResultValues.Canceled),
+				}, false),
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"build_number": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"source_branch": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"source_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"finish_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"artifact_names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBuildRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+
+	builds, err := clients.BuildClient.GetBuilds(clients.Ctx, expandBuildFilter(d))
+	if err != nil {
+		return fmt.Errorf(" finding builds in project %s: %+v", projectID, err)
+	}
+	if builds == nil || len(builds.Value) == 0 {
+		return fmt.Errorf(" no build matching the filters found in project %s", projectID)
+	}
+	latestBuild := builds.Value[0]
+
+	artifacts, err := clients.BuildClient.GetArtifacts(clients.Ctx, build.GetArtifactsArgs{
+		Project: &projectID,
+		BuildId: latestBuild.Id,
+	})
+	if err != nil {
+		return fmt.Errorf(" reading artifacts of build %d: %+v", *latestBuild.Id, err)
+	}
+
+	flattenBuild(d, &latestBuild, artifacts)
+	return nil
+}
+
+func expandBuildFilter(d *schema.ResourceData) build.GetBuildsArgs {
+	args := build.GetBuildsArgs{
+		Project:    converter.String(d.Get("project_id").(string)),
+		QueryOrder: &build.BuildQueryOrderValues.QueueTimeDescending,
+		Top:        converter.Int(1),
+	}
+	if definitionID, ok := d.GetOk("definition_id"); ok {
+		args.Definitions = &[]int{definitionID.(int)}
+	}
+	if branch, ok := d.GetOk("branch"); ok {
+		args.BranchName = converter.String(expandBranchRefName(branch.(string)))
+	}
+	if status, ok := d.GetOk("status"); ok {
+		buildStatus := build.BuildStatus(status.(string))
+		args.StatusFilter = &buildStatus
+	}
+	if result, ok := d.GetOk("result"); ok {
+		buildResult := build.BuildResult(result.(string))
+		args.ResultFilter = &buildResult
+	}
+	if tags := tfhelper.ExpandStringSet(d.Get("tags").(*schema.Set)); len(tags) > 0 {
+		args.TagFilters = &tags
+	}
+	return args
+}
+
+func flattenBuild(d *schema.ResourceData, latestBuild *build.Build, artifacts *[]build.BuildArtifact) {
+	d.SetId(strconv.Itoa(*latestBuild.Id))
+	if latestBuild.Definition != nil && latestBuild.Definition.Id != nil {
+		d.Set("definition_id", *latestBuild.Definition.Id)
+	}
+	d.Set("status", converter.ToString((*string)(latestBuild.Status), ""))
+	d.Set("result", converter.ToString((*string)(latestBuild.Result), ""))
+	d.Set("build_number", converter.ToString(latestBuild.BuildNumber, ""))
+	d.Set("source_branch", converter.ToString(latestBuild.SourceBranch, ""))
+	d.Set("source_version", converter.ToString(latestBuild.SourceVersion, ""))
+
+	finishTime := ""
+	if latestBuild.FinishTime != nil {
+		finishTime = latestBuild.FinishTime.Time.Format(time.RFC3339)
+	}
+	d.Set("finish_time", finishTime)
+
+	artifactNames := []string{}
+	if artifacts != nil {
+		for _, artifact := range *artifacts {
+			artifactNames = append(artifactNames, converter.ToString(artifact.Name, ""))
+		}
+	}
+	d.Set("artifact_names", artifactNames)
+}
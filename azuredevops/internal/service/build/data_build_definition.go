@@ -144,6 +144,26 @@ func DataBuildDefinition() *schema.Resource {
 							Type:     schema.TypeBool,
 							Computed: true,
 						},
+						"clean": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"fetch_depth": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"checkout_submodules": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"checkout_nested_submodules": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"git_lfs": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
 					},
 				},
 			},
@@ -0,0 +1,87 @@
+package build
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/validate"
+)
+
+// DataBuildFolders schema and implementation for the build folders data source, which lists the folders below a path
+func DataBuildFolders() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceBuildFoldersRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      `\`,
+				ValidateFunc: validate.Path,
+			},
+			"folders": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBuildFoldersRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	path := d.Get("path").(string)
+
+	buildFolders, err := clients.BuildClient.GetFolders(clients.Ctx, build.GetFoldersArgs{
+		Project:    &projectID,
+		Path:       &path,
+		QueryOrder: &build.FolderQueryOrderValues.FolderAscending,
+	})
+	if err != nil {
+		return fmt.Errorf(" finding build folders below %s in project %s: %+v", path, projectID, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", projectID, path))
+	return d.Set("folders", flattenBuildFolders(buildFolders, path))
+}
+
+// flattenBuildFolders returns all folders below the path, the folder with the path itself is not included
+func flattenBuildFolders(buildFolders *[]build.Folder, path string) []interface{} {
+	results := []interface{}{}
+	if buildFolders == nil {
+		return results
+	}
+
+	for _, buildFolder := range *buildFolders {
+		folderPath := converter.ToString(buildFolder.Path, "")
+		if strings.EqualFold(folderPath, path) || folderPath == `\` {
+			continue
+		}
+		results = append(results, map[string]interface{}{
+			"path":        folderPath,
+			"description": converter.ToString(buildFolder.Description, ""),
+		})
+	}
+	return results
+}
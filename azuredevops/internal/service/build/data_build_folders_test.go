@@ -0,0 +1,77 @@
+//go:build (all || data_sources || data_build_folders) && (!exclude_data_sources || !exclude_data_build_folders)
+// +build all data_sources data_build_folders
+// +build !exclude_data_sources !exclude_data_build_folders
+
+package build
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var dataBuildFoldersTestProjectID = uuid.New().String()
+
+// verifies that the folders below the path are listed without the folder of the path
+func TestDataBuildFolders_Read_ListsFoldersBelowPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	buildClient.
+		EXPECT().
+		GetFolders(clients.Ctx, build.GetFoldersArgs{
+			Project:    &dataBuildFoldersTestProjectID,
+			Path:       converter.String(`\Teams`),
+			QueryOrder: &build.FolderQueryOrderValues.FolderAscending,
+		}).
+		Return(&[]build.Folder{
+			{Path: converter.String(`\Teams`)},
+			{Path: converter.String(`\Teams\App`), Description: converter.String("App team")},
+			{Path: converter.String(`\Teams\App\Release`)},
+		}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, DataBuildFolders().Schema, map[string]interface{}{
+		"project_id": dataBuildFoldersTestProjectID,
+		"path":       `\Teams`,
+	})
+	err := dataSourceBuildFoldersRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"path": `\Teams\App`, "description": "App team"},
+		map[string]interface{}{"path": `\Teams\App\Release`, "description": ""},
+	}, resourceData.Get("folders"))
+}
+
+// verifies that if an error is produced on read, the error is not swallowed
+func TestDataBuildFolders_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	buildClient.
+		EXPECT().
+		GetFolders(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetFolders() Failed")).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, DataBuildFolders().Schema, map[string]interface{}{
+		"project_id": dataBuildFoldersTestProjectID,
+	})
+	err := dataSourceBuildFoldersRead(resourceData, clients)
+	require.Contains(t, err.Error(), "GetFolders() Failed")
+}
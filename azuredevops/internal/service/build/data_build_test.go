@@ -0,0 +1,132 @@
+//go:build (all || data_sources || data_build) && (!exclude_data_sources || !exclude_data_build)
+// +build all data_sources data_build
+// +build !exclude_data_sources !exclude_data_build
+
+package build
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var dataBuildTestProjectID = uuid.New().String()
+
+// verifies that the filters are sent and the latest build and its artifacts are returned
+func TestDataBuild_Read_ReturnsLatestBuild(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	finishTime := time.Date(2023, 10, 17, 8, 30, 0, 0, time.UTC)
+	buildClient.
+		EXPECT().
+		GetBuilds(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, args build.GetBuildsArgs) (*build.GetBuildsResponseValue, error) {
+			require.Equal(t, dataBuildTestProjectID, *args.Project)
+			require.Equal(t, []int{12}, *args.Definitions)
+			require.Equal(t, "refs/heads/main", *args.BranchName)
+			require.Equal(t, build.BuildStatusValues.Completed, *args.StatusFilter)
+			require.Equal(t, build.BuildResultValues.Succeeded, *args.ResultFilter)
+			require.Equal(t, []string{"release"}, *args.TagFilters)
+			require.Equal(t, 1, *args.Top)
+			return &build.GetBuildsResponseValue{
+				Value: []build.Build{
+					{
+						Id:            converter.Int(42),
+						BuildNumber:   converter.String("20231017.3"),
+						Definition:    &build.DefinitionReference{Id: converter.Int(12)},
+						SourceBranch:  converter.String("refs/heads/main"),
+						SourceVersion: converter.String("6a1f0c2"),
+						Status:        &build.BuildStatusValues.Completed,
+						Result:        &build.BuildResultValues.Succeeded,
+						FinishTime:    &azuredevops.Time{Time: finishTime},
+					},
+				},
+			}, nil
+		}).
+		Times(1)
+	buildClient.
+		EXPECT().
+		GetArtifacts(clients.Ctx, build.GetArtifactsArgs{
+			Project: &dataBuildTestProjectID,
+			BuildId: converter.Int(42),
+		}).
+		Return(&[]build.BuildArtifact{
+			{Name: converter.String("drop")},
+			{Name: converter.String("terraform")},
+		}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, DataBuild().Schema, map[string]interface{}{
+		"project_id":    dataBuildTestProjectID,
+		"definition_id": 12,
+		"branch":        "main",
+		"status":        "completed",
+		"result":        "succeeded",
+		"tags":          []interface{}{"release"},
+	})
+	err := dataSourceBuildRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "42", resourceData.Id())
+	require.Equal(t, "20231017.3", resourceData.Get("build_number"))
+	require.Equal(t, "6a1f0c2", resourceData.Get("source_version"))
+	require.Equal(t, "2023-10-17T08:30:00Z", resourceData.Get("finish_time"))
+	require.Equal(t, []interface{}{"drop", "terraform"}, resourceData.Get("artifact_names"))
+}
+
+// verifies that the data source fails if no build matches the filters
+func TestDataBuild_Read_NoMatchingBuild(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	buildClient.
+		EXPECT().
+		GetBuilds(clients.Ctx, gomock.Any()).
+		Return(&build.GetBuildsResponseValue{Value: []build.Build{}}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, DataBuild().Schema, map[string]interface{}{
+		"project_id": dataBuildTestProjectID,
+	})
+	err := dataSourceBuildRead(resourceData, clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "no build matching the filters found")
+}
+
+// verifies that if an error is produced on read, the error is not swallowed
+func TestDataBuild_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	buildClient.
+		EXPECT().
+		GetBuilds(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetBuilds() Failed")).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, DataBuild().Schema, map[string]interface{}{
+		"project_id": dataBuildTestProjectID,
+	})
+	err := dataSourceBuildRead(resourceData, clients)
+	require.Contains(t, err.Error(), "GetBuilds() Failed")
+}
@@ -1,12 +1,15 @@
 package build
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/build"
@@ -14,10 +17,17 @@ import (
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/model"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/taskagentextras"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/validate"
 )
 
+// Process type of designer (non-YAML) build definitions
+const designerProcessType = 1
+
+// Target type of phases which run on an agent
+const agentPhaseTargetType = 1
+
 const (
 	bdVariable              = "variable"
 	bdVariableName          = "name"
@@ -27,6 +37,19 @@ const (
 	bdVariableAllowOverride = "allow_override"
 )
 
+// The tag sources option of a repository is stored as the build results, as flags, for which the sources are tagged
+const (
+	tagSourcesNever     = "Never"
+	tagSourcesOnSuccess = "OnSuccess"
+	tagSourcesAlways    = "Always"
+)
+
+var tagSourcesLabelSources = map[string]string{
+	tagSourcesNever:     "0",
+	tagSourcesOnSuccess: "6",
+	tagSourcesAlways:    "46",
+}
+
 // ResourceBuildDefinition schema and implementation for build definition resource
 func ResourceBuildDefinition() *schema.Resource {
 	filterSchema := map[string]*schema.Schema{
@@ -66,11 +89,12 @@ func ResourceBuildDefinition() *schema.Resource {
 	}
 
 	return &schema.Resource{
-		Create:   resourceBuildDefinitionCreate,
-		Read:     resourceBuildDefinitionRead,
-		Update:   resourceBuildDefinitionUpdate,
-		Delete:   resourceBuildDefinitionDelete,
-		Importer: tfhelper.ImportProjectQualifiedResource(),
+		Create:        resourceBuildDefinitionCreate,
+		Read:          resourceBuildDefinitionRead,
+		Update:        resourceBuildDefinitionUpdate,
+		Delete:        resourceBuildDefinitionDelete,
+		Importer:      tfhelper.ImportProjectQualifiedResource(),
+		CustomizeDiff: customizeBuildDefinitionDiff,
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Type:     schema.TypeString,
@@ -101,6 +125,15 @@ func ResourceBuildDefinition() *schema.Resource {
 					ValidateFunc: validation.IntAtLeast(1),
 				},
 			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
 			bdVariable: {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -149,7 +182,7 @@ func ResourceBuildDefinition() *schema.Resource {
 					Schema: map[string]*schema.Schema{
 						"yml_path": {
 							Type:     schema.TypeString,
-							Required: true,
+							Optional: true,
 						},
 						"repo_id": {
 							Type:     schema.TypeString,
@@ -163,6 +196,8 @@ func ResourceBuildDefinition() *schema.Resource {
 								string(model.RepoTypeValues.TfsGit),
 								string(model.RepoTypeValues.Bitbucket),
 								string(model.RepoTypeValues.GitHubEnterprise),
+								string(model.RepoTypeValues.Git),
+								string(model.RepoTypeValues.Svn),
 							}, false),
 						},
 						"branch_name": {
@@ -185,6 +220,244 @@ func ResourceBuildDefinition() *schema.Resource {
 							Optional: true,
 							Default:  true,
 						},
+						"tag_sources": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      tagSourcesNever,
+							ValidateFunc: validation.StringInSlice([]string{tagSourcesNever, tagSourcesOnSuccess, tagSourcesAlways}, false),
+						},
+						"tag_format": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "$(build.buildNumber)",
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"clean": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"fetch_depth": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"checkout_submodules": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"checkout_nested_submodules": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"git_lfs": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+			"build_number_format": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"badge_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"queue_status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  string(build.DefinitionQueueStatusValues.Enabled),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(build.DefinitionQueueStatusValues.Enabled),
+					string(build.DefinitionQueueStatusValues.Paused),
+					string(build.DefinitionQueueStatusValues.Disabled),
+				}, false),
+			},
+			"job_authorization_scope": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  string(build.BuildAuthorizationScopeValues.ProjectCollection),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(build.BuildAuthorizationScopeValues.ProjectCollection),
+					string(build.BuildAuthorizationScopeValues.Project),
+				}, false),
+			},
+			"job_timeout_in_minutes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"job_cancel_timeout_in_minutes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntBetween(1, 60),
+			},
+			"retention_rule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"branches": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringIsNotWhiteSpace,
+							},
+						},
+						"days_to_keep": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"minimum_to_keep": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"delete_build_record": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"delete_test_results": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"artifacts": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringIsNotWhiteSpace,
+							},
+						},
+						"artifact_types_to_delete": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringIsNotWhiteSpace,
+							},
+						},
+					},
+				},
+			},
+			"agent_specification": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"repository.0.yml_path"},
+				ValidateFunc:  validation.StringIsNotWhiteSpace,
+			},
+			"phase": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MinItems:      1,
+				ConflictsWith: []string{"repository.0.yml_path"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"ref_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"condition": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "succeeded()",
+						},
+						"job_timeout_in_minutes": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      60,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"job_cancel_timeout_in_minutes": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      5,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"allow_scripts_auth_access": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"step": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"task_id": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.IsUUID,
+									},
+									"version": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotWhiteSpace,
+									},
+									"display_name": {
+										Type:     schema.TypeString,
+										Optional: true,
+										Default:  "",
+									},
+									"enabled": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  true,
+									},
+									"continue_on_error": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
+									"always_run": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
+									"condition": {
+										Type:     schema.TypeString,
+										Optional: true,
+										Default:  "succeeded()",
+									},
+									"timeout_in_minutes": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      0,
+										ValidateFunc: validation.IntAtLeast(0),
+									},
+									"inputs": {
+										Type:     schema.TypeMap,
+										Optional: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -362,6 +635,11 @@ func resourceBuildDefinitionCreate(d *schema.ResourceData, m interface{}) error
 	}
 
 	flattenBuildDefinition(d, createdBuildDefinition, projectID)
+
+	err = updateDefinitionTags(clients, projectID, *createdBuildDefinition.Id, &schema.Set{F: schema.HashString}, d.Get("tags").(*schema.Set))
+	if err != nil {
+		return fmt.Errorf("error adding tags to Build Definition: %+v", err)
+	}
 	return resourceBuildDefinitionRead(d, m)
 }
 
@@ -386,7 +664,16 @@ func resourceBuildDefinitionRead(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
+	if err := removeDefaultTaskInputs(clients, d, buildDefinition); err != nil {
+		return fmt.Errorf("error reading task inputs of Build Definition: %+v", err)
+	}
 	flattenBuildDefinition(d, buildDefinition, projectID)
+
+	tags, err := getDefinitionTags(clients, projectID, buildDefinitionID)
+	if err != nil {
+		return fmt.Errorf("error reading tags of Build Definition: %+v", err)
+	}
+	d.Set("tags", tags)
 	return nil
 }
 
@@ -411,6 +698,14 @@ func resourceBuildDefinitionUpdate(d *schema.ResourceData, m interface{}) error
 		return err
 	}
 
+	if d.HasChange("tags") {
+		oldTags, newTags := d.GetChange("tags")
+		err = updateDefinitionTags(clients, projectID, *buildDefinition.Id, oldTags.(*schema.Set), newTags.(*schema.Set))
+		if err != nil {
+			return fmt.Errorf("error updating tags of Build Definition: %+v", err)
+		}
+	}
+
 	flattenBuildDefinition(d, updatedBuildDefinition, projectID)
 	return resourceBuildDefinitionRead(d, m)
 }
@@ -441,11 +736,24 @@ func flattenBuildDefinition(d *schema.ResourceData, buildDefinition *build.Build
 	d.Set("name", *buildDefinition.Name)
 	d.Set("path", *buildDefinition.Path)
 	d.Set("repository", flattenRepository(buildDefinition))
+	flattenDesignerProcess(d, buildDefinition)
 
 	if buildDefinition.Queue != nil && buildDefinition.Queue.Pool != nil {
 		d.Set("agent_pool_name", *buildDefinition.Queue.Pool.Name)
 	}
 
+	d.Set("build_number_format", converter.ToString(buildDefinition.BuildNumberFormat, ""))
+	d.Set("badge_enabled", converter.ToBool(buildDefinition.BadgeEnabled, false))
+	if buildDefinition.QueueStatus != nil {
+		d.Set("queue_status", string(*buildDefinition.QueueStatus))
+	}
+	if buildDefinition.JobAuthorizationScope != nil {
+		d.Set("job_authorization_scope", string(*buildDefinition.JobAuthorizationScope))
+	}
+	d.Set("job_timeout_in_minutes", converter.ToInt(buildDefinition.JobTimeoutInMinutes, 0))
+	d.Set("job_cancel_timeout_in_minutes", converter.ToInt(buildDefinition.JobCancelTimeoutInMinutes, 0))
+	d.Set("retention_rule", flattenRetentionRules(buildDefinition.RetentionRules))
+
 	d.Set("variable_groups", flattenVariableGroups(buildDefinition))
 	d.Set(bdVariable, flattenBuildVariables(d, buildDefinition))
 
@@ -530,6 +838,33 @@ func flattenVariableGroups(buildDefinition *build.BuildDefinition) []int {
 	return variableGroups
 }
 
+func flattenRetentionRules(retentionRules *[]build.RetentionPolicy) []interface{} {
+	if retentionRules == nil {
+		return nil
+	}
+
+	rules := []interface{}{}
+	for _, rule := range *retentionRules {
+		rules = append(rules, map[string]interface{}{
+			"branches":                 flattenStringList(rule.Branches),
+			"days_to_keep":             converter.ToInt(rule.DaysToKeep, 0),
+			"minimum_to_keep":          converter.ToInt(rule.MinimumToKeep, 0),
+			"delete_build_record":      converter.ToBool(rule.DeleteBuildRecord, false),
+			"delete_test_results":      converter.ToBool(rule.DeleteTestResults, false),
+			"artifacts":                flattenStringList(rule.Artifacts),
+			"artifact_types_to_delete": flattenStringList(rule.ArtifactTypesToDelete),
+		})
+	}
+	return rules
+}
+
+func flattenStringList(values *[]string) []string {
+	if values == nil {
+		return nil
+	}
+	return *values
+}
+
 func flattenRepository(buildDefinition *build.BuildDefinition) interface{} {
 	yamlFilePath := ""
 	githubEnterpriseUrl := ""
@@ -538,7 +873,9 @@ func flattenRepository(buildDefinition *build.BuildDefinition) interface{} {
 	// available from the compiler is `interface{}` so we can probe for known
 	// implementations
 	if processMap, ok := buildDefinition.Process.(map[string]interface{}); ok {
-		yamlFilePath = processMap["yamlFilename"].(string)
+		if yamlFilename, ok := processMap["yamlFilename"].(string); ok {
+			yamlFilePath = yamlFilename
+		}
 	}
 	if yamlProcess, ok := buildDefinition.Process.(*build.YamlProcess); ok {
 		yamlFilePath = *yamlProcess.YamlFilename
@@ -559,6 +896,8 @@ func flattenRepository(buildDefinition *build.BuildDefinition) interface{} {
 		"repo_type":             *buildDefinition.Repository.Type,
 		"branch_name":           *buildDefinition.Repository.DefaultBranch,
 		"github_enterprise_url": githubEnterpriseUrl,
+		"tag_sources":           tagSourcesNever,
+		"tag_format":            "$(build.buildNumber)",
 	}}
 
 	if buildDefinition.Repository != nil && buildDefinition.Repository.Properties != nil {
@@ -570,10 +909,254 @@ func flattenRepository(buildDefinition *build.BuildDefinition) interface{} {
 			reportBuildStatus, _ := strconv.ParseBool(buildStatus)
 			repo[0]["report_build_status"] = reportBuildStatus
 		}
+
+		if labelSources, ok := (*buildDefinition.Repository.Properties)["labelSources"]; ok {
+			for tagSources, value := range tagSourcesLabelSources {
+				if value == labelSources {
+					repo[0]["tag_sources"] = tagSources
+				}
+			}
+		}
+
+		if labelSourcesFormat, ok := (*buildDefinition.Repository.Properties)["labelSourcesFormat"]; ok && labelSourcesFormat != "" {
+			repo[0]["tag_format"] = labelSourcesFormat
+		}
+
+		if fetchDepth, ok := (*buildDefinition.Repository.Properties)["fetchDepth"]; ok {
+			repo[0]["fetch_depth"], _ = strconv.Atoi(fetchDepth)
+		}
+
+		if nestedSubmodules, ok := (*buildDefinition.Repository.Properties)["checkoutNestedSubmodules"]; ok {
+			repo[0]["checkout_nested_submodules"], _ = strconv.ParseBool(nestedSubmodules)
+		}
+
+		if gitLfs, ok := (*buildDefinition.Repository.Properties)["gitLfsSupport"]; ok {
+			repo[0]["git_lfs"], _ = strconv.ParseBool(gitLfs)
+		}
+	}
+
+	if buildDefinition.Repository != nil {
+		clean, _ := strconv.ParseBool(converter.ToString(buildDefinition.Repository.Clean, "false"))
+		repo[0]["clean"] = clean
+		repo[0]["checkout_submodules"] = converter.ToBool(buildDefinition.Repository.CheckoutSubmodules, false)
 	}
 	return repo
 }
 
+// designerPhase mirrors build.Phase. The SDK models the target of a phase with the base type which
+// drops the agent execution options.
+type designerPhase struct {
+	build.Phase
+	Target *build.AgentPoolQueueTarget `json:"target,omitempty"`
+}
+
+type designerProcess struct {
+	Type   *int                         `json:"type,omitempty"`
+	Phases *[]designerPhase             `json:"phases,omitempty"`
+	Target *build.DesignerProcessTarget `json:"target,omitempty"`
+}
+
+func flattenDesignerProcess(d *schema.ResourceData, buildDefinition *build.BuildDefinition) {
+	process, err := toDesignerProcess(buildDefinition.Process)
+	if err != nil || process == nil {
+		d.Set("phase", nil)
+		d.Set("agent_specification", "")
+		return
+	}
+
+	agentSpecification := ""
+	if process.Target != nil && process.Target.AgentSpecification != nil {
+		agentSpecification = converter.ToString(process.Target.AgentSpecification.Identifier, "")
+	}
+	d.Set("agent_specification", agentSpecification)
+
+	phases := []interface{}{}
+	if process.Phases != nil {
+		for i, phase := range *process.Phases {
+			allowScriptsAuthAccess := false
+			if phase.Target != nil {
+				allowScriptsAuthAccess = converter.ToBool(phase.Target.AllowScriptsAuthAccessOption, false)
+			}
+			phases = append(phases, map[string]interface{}{
+				"name":                          converter.ToString(phase.Name, ""),
+				"ref_name":                      converter.ToString(phase.RefName, ""),
+				"condition":                     converter.ToString(phase.Condition, ""),
+				"job_timeout_in_minutes":        converter.ToInt(phase.JobTimeoutInMinutes, 0),
+				"job_cancel_timeout_in_minutes": converter.ToInt(phase.JobCancelTimeoutInMinutes, 0),
+				"allow_scripts_auth_access":     allowScriptsAuthAccess,
+				"step":                          flattenDesignerSteps(d, fmt.Sprintf("phase.%d.step", i), phase.Steps),
+			})
+		}
+	}
+	d.Set("phase", phases)
+}
+
+// toDesignerProcess returns the designer process of a build definition or nil if the definition
+// uses another process type
+func toDesignerProcess(process interface{}) (*designerProcess, error) {
+	if process == nil {
+		return nil, nil
+	}
+	if typedProcess, ok := process.(*designerProcess); ok {
+		return typedProcess, nil
+	}
+
+	processAsJSON, err := json.Marshal(process)
+	if err != nil {
+		return nil, err
+	}
+	var result designerProcess
+	if err := json.Unmarshal(processAsJSON, &result); err != nil {
+		return nil, err
+	}
+	if converter.ToInt(result.Type, 0) != designerProcessType {
+		return nil, nil
+	}
+	return &result, nil
+}
+
+// flattenDesignerSteps flattens the steps of a phase. The service stores the default value of every input
+// of a task, including the ones which were not sent, so only the inputs of a step which are already in the
+// state are kept. The default inputs of a step which is not in the state yet are removed by removeDefaultTaskInputs.
+func flattenDesignerSteps(d *schema.ResourceData, stepsKey string, steps *[]build.BuildDefinitionStep) []interface{} {
+	if steps == nil {
+		return nil
+	}
+
+	result := []interface{}{}
+	for i, step := range *steps {
+		taskID := ""
+		version := ""
+		if step.Task != nil {
+			if step.Task.Id != nil {
+				taskID = step.Task.Id.String()
+			}
+			version = converter.ToString(step.Task.VersionSpec, "")
+		}
+		inputs := map[string]string{}
+		if step.Inputs != nil {
+			inputs = *step.Inputs
+		}
+		if currentStep := getDesignerStepInState(d, stepsKey, i, taskID); currentStep != nil {
+			inputs = filterConfiguredTaskInputs(inputs, currentStep["inputs"].(map[string]interface{}))
+		}
+
+		result = append(result, map[string]interface{}{
+			"task_id":            taskID,
+			"version":            version,
+			"display_name":       converter.ToString(step.DisplayName, ""),
+			"enabled":            converter.ToBool(step.Enabled, true),
+			"continue_on_error":  converter.ToBool(step.ContinueOnError, false),
+			"always_run":         converter.ToBool(step.AlwaysRun, false),
+			"condition":          converter.ToString(step.Condition, ""),
+			"timeout_in_minutes": converter.ToInt(step.TimeoutInMinutes, 0),
+			"inputs":             inputs,
+		})
+	}
+	return result
+}
+
+// getDesignerStepInState returns the step at the index if it is in the state and runs the same task, nil otherwise
+func getDesignerStepInState(d *schema.ResourceData, stepsKey string, index int, taskID string) map[string]interface{} {
+	currentSteps, _ := d.Get(stepsKey).([]interface{})
+	if index >= len(currentSteps) || currentSteps[index] == nil {
+		return nil
+	}
+	currentStep := currentSteps[index].(map[string]interface{})
+	if !strings.EqualFold(currentStep["task_id"].(string), taskID) {
+		return nil
+	}
+	return currentStep
+}
+
+func filterConfiguredTaskInputs(inputs map[string]string, configuredInputs map[string]interface{}) map[string]string {
+	result := map[string]string{}
+	for name, value := range inputs {
+		if _, ok := configuredInputs[name]; ok {
+			result[name] = value
+		}
+	}
+	return result
+}
+
+// removeDefaultTaskInputs removes the inputs of the designer steps which are not in the state yet, e.g. after an
+// import, if they are empty or have the default value of the task.
+func removeDefaultTaskInputs(clients *client.AggregatedClient, d *schema.ResourceData, buildDefinition *build.BuildDefinition) error {
+	process, err := toDesignerProcess(buildDefinition.Process)
+	if err != nil || process == nil || process.Phases == nil {
+		return err
+	}
+
+	taskInputDefaults := map[string]map[string]string{}
+	for i, phase := range *process.Phases {
+		if phase.Steps == nil {
+			continue
+		}
+		for j, step := range *phase.Steps {
+			if step.Task == nil || step.Task.Id == nil || step.Inputs == nil {
+				continue
+			}
+			if getDesignerStepInState(d, fmt.Sprintf("phase.%d.step", i), j, step.Task.Id.String()) != nil {
+				continue
+			}
+
+			versionSpec := converter.ToString(step.Task.VersionSpec, "")
+			key := step.Task.Id.String() + "@" + versionSpec
+			defaults, ok := taskInputDefaults[key]
+			if !ok {
+				defaults, err = getTaskInputDefaults(clients, step.Task.Id, versionSpec)
+				if err != nil {
+					return err
+				}
+				taskInputDefaults[key] = defaults
+			}
+
+			inputs := map[string]string{}
+			for name, value := range *step.Inputs {
+				if value != defaults[name] {
+					inputs[name] = value
+				}
+			}
+			(*phase.Steps)[j].Inputs = &inputs
+		}
+	}
+	buildDefinition.Process = process
+	return nil
+}
+
+// getTaskInputDefaults returns the default values of the inputs of the task definition with the major version of
+// the version spec. No defaults are returned if the task or the version does not exist (anymore).
+func getTaskInputDefaults(clients *client.AggregatedClient, taskID *uuid.UUID, versionSpec string) (map[string]string, error) {
+	defaults := map[string]string{}
+	major, err := strconv.Atoi(strings.SplitN(versionSpec, ".", 2)[0])
+	if err != nil {
+		return defaults, nil
+	}
+
+	definitions, err := clients.TaskAgentClientExtras.GetTaskDefinitions(clients.Ctx, taskagentextras.GetTaskDefinitionsArgs{
+		TaskId: taskID,
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			return defaults, nil
+		}
+		return nil, fmt.Errorf("error reading task %s: %+v", taskID, err)
+	}
+
+	for _, definition := range *definitions {
+		if definition.Version == nil || converter.ToInt(definition.Version.Major, -1) != major || definition.Inputs == nil {
+			continue
+		}
+		for _, input := range *definition.Inputs {
+			if input.Name != nil {
+				defaults[*input.Name] = converter.ToString(input.DefaultValue, "")
+			}
+		}
+		break
+	}
+	return defaults, nil
+}
+
 func flattenBuildDefinitionBranchOrPathFilter(m []interface{}) []interface{} {
 	var include []string
 	var exclude []string
@@ -960,6 +1543,11 @@ func expandBuildDefinition(d *schema.ResourceData) (*build.BuildDefinition, stri
 		repoURL = fmt.Sprintf("%s/%s.git", githubEnterpriseURL, repoID)
 		repoAPIURL = fmt.Sprintf("%s/api/v3/repos/%s", githubEnterpriseURL, repoID)
 	}
+	// Generic Git and Subversion repositories are identified by their URL
+	if strings.EqualFold(string(repoType), string(model.RepoTypeValues.Git)) ||
+		strings.EqualFold(string(repoType), string(model.RepoTypeValues.Svn)) {
+		repoURL = repoID
+	}
 
 	ciTriggers := expandBuildDefinitionTriggerList(
 		d.Get("ci_trigger").([]interface{}),
@@ -999,32 +1587,54 @@ func expandBuildDefinition(d *schema.ResourceData) (*build.BuildDefinition, stri
 		return nil, "", fmt.Errorf("Error expanding varibles: %+v", err)
 	}
 
+	process, err := expandBuildDefinitionProcess(d, repository)
+	if err != nil {
+		return nil, "", err
+	}
+
+	queueStatus := build.DefinitionQueueStatus(d.Get("queue_status").(string))
+	jobAuthorizationScope := build.BuildAuthorizationScope(d.Get("job_authorization_scope").(string))
+
 	buildDefinition := build.BuildDefinition{
 		Id:       buildDefinitionReference,
 		Name:     converter.String(d.Get("name").(string)),
 		Path:     converter.String(d.Get("path").(string)),
 		Revision: converter.Int(d.Get("revision").(int)),
 		Repository: &build.BuildRepository{
-			Url:           &repoURL,
-			Id:            &repoID,
-			Name:          &repoID,
-			DefaultBranch: converter.String(repository["branch_name"].(string)),
-			Type:          converter.String(string(repoType)),
+			Url:                &repoURL,
+			Id:                 &repoID,
+			Name:               &repoID,
+			DefaultBranch:      converter.String(repository["branch_name"].(string)),
+			Type:               converter.String(string(repoType)),
+			Clean:              converter.String(strconv.FormatBool(repository["clean"].(bool))),
+			CheckoutSubmodules: converter.Bool(repository["checkout_submodules"].(bool)),
 			Properties: &map[string]string{
-				"connectedServiceId": repository["service_connection_id"].(string),
-				"apiUrl":             repoAPIURL,
-				"reportBuildStatus":  strconv.FormatBool(repository["report_build_status"].(bool)),
+				"connectedServiceId":       repository["service_connection_id"].(string),
+				"apiUrl":                   repoAPIURL,
+				"reportBuildStatus":        strconv.FormatBool(repository["report_build_status"].(bool)),
+				"labelSources":             tagSourcesLabelSources[repository["tag_sources"].(string)],
+				"labelSourcesFormat":       repository["tag_format"].(string),
+				"fetchDepth":               strconv.Itoa(repository["fetch_depth"].(int)),
+				"checkoutNestedSubmodules": strconv.FormatBool(repository["checkout_nested_submodules"].(bool)),
+				"gitLfsSupport":            strconv.FormatBool(repository["git_lfs"].(bool)),
 			},
 		},
-		Process: &build.YamlProcess{
-			YamlFilename: converter.String(repository["yml_path"].(string)),
-		},
-		QueueStatus:    &build.DefinitionQueueStatusValues.Enabled,
-		Type:           &build.DefinitionTypeValues.Build,
-		Quality:        &build.DefinitionQualityValues.Definition,
-		VariableGroups: expandVariableGroups(d),
-		Variables:      variables,
-		Triggers:       &buildTriggers,
+		Process:                   process,
+		QueueStatus:               &queueStatus,
+		Type:                      &build.DefinitionTypeValues.Build,
+		Quality:                   &build.DefinitionQualityValues.Definition,
+		VariableGroups:            expandVariableGroups(d),
+		Variables:                 variables,
+		Triggers:                  &buildTriggers,
+		BadgeEnabled:              converter.Bool(d.Get("badge_enabled").(bool)),
+		JobAuthorizationScope:     &jobAuthorizationScope,
+		JobTimeoutInMinutes:       converter.Int(d.Get("job_timeout_in_minutes").(int)),
+		JobCancelTimeoutInMinutes: converter.Int(d.Get("job_cancel_timeout_in_minutes").(int)),
+		RetentionRules:            expandRetentionRules(d.Get("retention_rule").([]interface{})),
+	}
+
+	if buildNumberFormat, ok := d.GetOk("build_number_format"); ok {
+		buildDefinition.BuildNumberFormat = converter.String(buildNumberFormat.(string))
 	}
 
 	if agentPoolName, ok := d.GetOk("agent_pool_name"); ok {
@@ -1039,6 +1649,128 @@ func expandBuildDefinition(d *schema.ResourceData) (*build.BuildDefinition, stri
 	return &buildDefinition, projectID, nil
 }
 
+// expandRetentionRules returns nil if no rules are configured, the service applies its default rule
+func expandRetentionRules(rules []interface{}) *[]build.RetentionPolicy {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	result := []build.RetentionPolicy{}
+	for _, rule := range rules {
+		ruleMap := rule.(map[string]interface{})
+		branches := tfhelper.ExpandStringList(ruleMap["branches"].([]interface{}))
+		if len(branches) == 0 {
+			branches = []string{"+refs/heads/*"}
+		}
+		result = append(result, build.RetentionPolicy{
+			Branches:              &branches,
+			DaysToKeep:            converter.Int(ruleMap["days_to_keep"].(int)),
+			MinimumToKeep:         converter.Int(ruleMap["minimum_to_keep"].(int)),
+			DeleteBuildRecord:     converter.Bool(ruleMap["delete_build_record"].(bool)),
+			DeleteTestResults:     converter.Bool(ruleMap["delete_test_results"].(bool)),
+			Artifacts:             converter.ToPtr(tfhelper.ExpandStringList(ruleMap["artifacts"].([]interface{}))),
+			ArtifactTypesToDelete: converter.ToPtr(tfhelper.ExpandStringList(ruleMap["artifact_types_to_delete"].([]interface{}))),
+		})
+	}
+	return &result
+}
+
+func expandBuildDefinitionProcess(d *schema.ResourceData, repository map[string]interface{}) (interface{}, error) {
+	yamlPath := repository["yml_path"].(string)
+	phases := d.Get("phase").([]interface{})
+	if yamlPath == "" && len(phases) == 0 {
+		return nil, fmt.Errorf("either repository.yml_path or phase must be specified")
+	}
+	if yamlPath != "" {
+		return &build.YamlProcess{
+			YamlFilename: converter.String(yamlPath),
+		}, nil
+	}
+
+	process := designerProcess{
+		Type:   converter.Int(designerProcessType),
+		Phases: expandDesignerPhases(phases),
+	}
+	if agentSpecification := d.Get("agent_specification").(string); agentSpecification != "" {
+		process.Target = &build.DesignerProcessTarget{
+			AgentSpecification: &build.AgentSpecification{
+				Identifier: converter.String(agentSpecification),
+			},
+		}
+	}
+	return &process, nil
+}
+
+func expandDesignerPhases(phases []interface{}) *[]designerPhase {
+	result := []designerPhase{}
+	for _, phase := range phases {
+		phaseMap := phase.(map[string]interface{})
+		expandedPhase := designerPhase{
+			Phase: build.Phase{
+				Name:                      converter.String(phaseMap["name"].(string)),
+				Condition:                 converter.String(phaseMap["condition"].(string)),
+				JobTimeoutInMinutes:       converter.Int(phaseMap["job_timeout_in_minutes"].(int)),
+				JobCancelTimeoutInMinutes: converter.Int(phaseMap["job_cancel_timeout_in_minutes"].(int)),
+				Steps:                     expandDesignerSteps(phaseMap["step"].([]interface{})),
+			},
+			Target: &build.AgentPoolQueueTarget{
+				Type:                         converter.Int(agentPhaseTargetType),
+				AllowScriptsAuthAccessOption: converter.Bool(phaseMap["allow_scripts_auth_access"].(bool)),
+				ExecutionOptions: &build.AgentTargetExecutionOptions{
+					Type: converter.Int(0),
+				},
+			},
+		}
+		if refName := phaseMap["ref_name"].(string); refName != "" {
+			expandedPhase.RefName = converter.String(refName)
+		}
+		result = append(result, expandedPhase)
+	}
+	return &result
+}
+
+func expandDesignerSteps(steps []interface{}) *[]build.BuildDefinitionStep {
+	result := []build.BuildDefinitionStep{}
+	for _, step := range steps {
+		stepMap := step.(map[string]interface{})
+
+		inputs := map[string]string{}
+		for name, value := range stepMap["inputs"].(map[string]interface{}) {
+			inputs[name] = value.(string)
+		}
+
+		result = append(result, build.BuildDefinitionStep{
+			DisplayName:      converter.String(stepMap["display_name"].(string)),
+			Enabled:          converter.Bool(stepMap["enabled"].(bool)),
+			ContinueOnError:  converter.Bool(stepMap["continue_on_error"].(bool)),
+			AlwaysRun:        converter.Bool(stepMap["always_run"].(bool)),
+			Condition:        converter.String(stepMap["condition"].(string)),
+			TimeoutInMinutes: converter.Int(stepMap["timeout_in_minutes"].(int)),
+			Inputs:           &inputs,
+			Task: &build.TaskDefinitionReference{
+				Id:             converter.UUID(stepMap["task_id"].(string)),
+				VersionSpec:    converter.String(stepMap["version"].(string)),
+				DefinitionType: converter.String("task"),
+			},
+		})
+	}
+	return &result
+}
+
+// customizeBuildDefinitionDiff rejects a YAML file for Subversion repositories, which are only supported
+// by designer pipelines
+func customizeBuildDefinitionDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	repositories, _ := d.Get("repository").([]interface{})
+	if len(repositories) == 0 || repositories[0] == nil {
+		return nil
+	}
+	repository := repositories[0].(map[string]interface{})
+	if strings.EqualFold(repository["repo_type"].(string), string(model.RepoTypeValues.Svn)) && repository["yml_path"].(string) != "" {
+		return errors.New("repository.0.yml_path can not be used with Subversion repositories, which are only supported by designer pipelines. Use phase instead.")
+	}
+	return nil
+}
+
 /**
  * certain types of build definitions require a service connection to run. This function
  * returns an error if a service connection was needed but not provided
@@ -1056,6 +1788,12 @@ func validateServiceConnectionIDExistsIfNeeded(d *schema.ResourceData) error {
 	if strings.EqualFold(repoType, string(model.RepoTypeValues.GitHubEnterprise)) && serviceConnectionID == "" {
 		return errors.New("GitHub Enterprise repositories need a referenced service connection ID")
 	}
+	if strings.EqualFold(repoType, string(model.RepoTypeValues.Git)) && serviceConnectionID == "" {
+		return errors.New("Git repositories need a referenced service connection ID")
+	}
+	if strings.EqualFold(repoType, string(model.RepoTypeValues.Svn)) && serviceConnectionID == "" {
+		return errors.New("Subversion repositories need a referenced service connection ID")
+	}
 	return nil
 }
 
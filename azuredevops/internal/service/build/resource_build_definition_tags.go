@@ -0,0 +1,182 @@
+package build
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+// ResourceBuildDefinitionTags schema and implementation for tags of a build definition which is not managed by Terraform.
+// Only the configured tags are managed, other tags of the definition are kept.
+func ResourceBuildDefinitionTags() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBuildDefinitionTagsCreate,
+		Read:   resourceBuildDefinitionTagsRead,
+		Update: resourceBuildDefinitionTagsUpdate,
+		Delete: resourceBuildDefinitionTagsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceBuildDefinitionTagsImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"definition_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"tags": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+		},
+	}
+}
+
+func resourceBuildDefinitionTagsCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	definitionID := d.Get("definition_id").(int)
+
+	err := updateDefinitionTags(clients, projectID, definitionID, &schema.Set{F: schema.HashString}, d.Get("tags").(*schema.Set))
+	if err != nil {
+		return fmt.Errorf(" adding tags to build definition %d: %+v", definitionID, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%d", projectID, definitionID))
+	return resourceBuildDefinitionTagsRead(d, m)
+}
+
+func resourceBuildDefinitionTagsRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	definitionID := d.Get("definition_id").(int)
+
+	definitionTags, err := getDefinitionTags(clients, projectID, definitionID)
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf(" reading tags of build definition %d: %+v", definitionID, err)
+	}
+
+	// only the tags managed by this resource are tracked
+	managedTags := d.Get("tags").(*schema.Set)
+	tags := []string{}
+	for _, tag := range definitionTags {
+		if managedTags.Contains(tag) {
+			tags = append(tags, tag)
+		}
+	}
+	d.Set("tags", tags)
+	return nil
+}
+
+func resourceBuildDefinitionTagsUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	definitionID := d.Get("definition_id").(int)
+
+	oldTags, newTags := d.GetChange("tags")
+	err := updateDefinitionTags(clients, projectID, definitionID, oldTags.(*schema.Set), newTags.(*schema.Set))
+	if err != nil {
+		return fmt.Errorf(" updating tags of build definition %d: %+v", definitionID, err)
+	}
+	return resourceBuildDefinitionTagsRead(d, m)
+}
+
+func resourceBuildDefinitionTagsDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	definitionID := d.Get("definition_id").(int)
+
+	err := updateDefinitionTags(clients, projectID, definitionID, d.Get("tags").(*schema.Set), &schema.Set{F: schema.HashString})
+	if err != nil {
+		return fmt.Errorf(" removing tags from build definition %d: %+v", definitionID, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceBuildDefinitionTagsImport imports all tags of the build definition
+func resourceBuildDefinitionTagsImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	clients := m.(*client.AggregatedClient)
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf(" Unexpected format of ID (%s), expected projectID/definitionID", d.Id())
+	}
+	definitionID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf(" Build definition ID (%s) must be an integer", parts[1])
+	}
+
+	tags, err := getDefinitionTags(clients, parts[0], definitionID)
+	if err != nil {
+		return nil, fmt.Errorf(" reading tags of build definition %d: %+v", definitionID, err)
+	}
+
+	d.Set("project_id", parts[0])
+	d.Set("definition_id", definitionID)
+	d.Set("tags", tags)
+	return []*schema.ResourceData{d}, nil
+}
+
+func getDefinitionTags(clients *client.AggregatedClient, projectID string, definitionID int) ([]string, error) {
+	tags, err := clients.BuildClient.GetDefinitionTags(clients.Ctx, build.GetDefinitionTagsArgs{
+		Project:      &projectID,
+		DefinitionId: &definitionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if tags == nil {
+		return []string{}, nil
+	}
+	return *tags, nil
+}
+
+// updateDefinitionTags adds the tags missing in oldTags and removes the tags missing in newTags
+func updateDefinitionTags(clients *client.AggregatedClient, projectID string, definitionID int, oldTags *schema.Set, newTags *schema.Set) error {
+	if addedTags := tfhelper.ExpandStringSet(newTags.Difference(oldTags)); len(addedTags) > 0 {
+		_, err := clients.BuildClient.AddDefinitionTags(clients.Ctx, build.AddDefinitionTagsArgs{
+			Tags:         &addedTags,
+			Project:      &projectID,
+			DefinitionId: &definitionID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, removedTag := range tfhelper.ExpandStringSet(oldTags.Difference(newTags)) {
+		_, err := clients.BuildClient.DeleteDefinitionTag(clients.Ctx, build.DeleteDefinitionTagArgs{
+			Project:      &projectID,
+			DefinitionId: &definitionID,
+			Tag:          converter.String(removedTag),
+		})
+		if err != nil && !utils.ResponseWasNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+//go:build (all || resource_build_definition_tags) && !exclude_resource_build_definition_tags
+// +build all resource_build_definition_tags
+// +build !exclude_resource_build_definition_tags
+
+package build
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/build"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var definitionTagsTestProjectID = uuid.New().String()
+
+func newBuildDefinitionTagsResourceData(t *testing.T, tags ...interface{}) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, ResourceBuildDefinitionTags().Schema, map[string]interface{}{
+		"project_id":    definitionTagsTestProjectID,
+		"definition_id": 12,
+		"tags":          tags,
+	})
+}
+
+// verifies that the tags are added and only the managed tags are read back
+func TestBuildDefinitionTags_Create_AddsTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	buildClient.
+		EXPECT().
+		AddDefinitionTags(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, args build.AddDefinitionTagsArgs) (*[]string, error) {
+			require.Equal(t, definitionTagsTestProjectID, *args.Project)
+			require.Equal(t, 12, *args.DefinitionId)
+			require.ElementsMatch(t, []string{"compliance", "reporting"}, *args.Tags)
+			return args.Tags, nil
+		}).
+		Times(1)
+	buildClient.
+		EXPECT().
+		GetDefinitionTags(clients.Ctx, build.GetDefinitionTagsArgs{
+			Project:      &definitionTagsTestProjectID,
+			DefinitionId: converter.Int(12),
+		}).
+		Return(&[]string{"compliance", "external", "reporting"}, nil).
+		Times(1)
+
+	resourceData := newBuildDefinitionTagsResourceData(t, "compliance", "reporting")
+	err := resourceBuildDefinitionTagsCreate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, definitionTagsTestProjectID+"/12", resourceData.Id())
+	require.ElementsMatch(t, []interface{}{"compliance", "reporting"}, resourceData.Get("tags").(*schema.Set).List())
+}
+
+// verifies that added tags are added and removed tags are deleted
+func TestBuildDefinitionTags_UpdateDefinitionTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	buildClient.
+		EXPECT().
+		AddDefinitionTags(clients.Ctx, build.AddDefinitionTagsArgs{
+			Tags:         &[]string{"new"},
+			Project:      &definitionTagsTestProjectID,
+			DefinitionId: converter.Int(12),
+		}).
+		Return(&[]string{"kept", "new"}, nil).
+		Times(1)
+	buildClient.
+		EXPECT().
+		DeleteDefinitionTag(clients.Ctx, build.DeleteDefinitionTagArgs{
+			Project:      &definitionTagsTestProjectID,
+			DefinitionId: converter.Int(12),
+			Tag:          converter.String("removed"),
+		}).
+		Return(&[]string{"kept", "new"}, nil).
+		Times(1)
+
+	oldTags := schema.NewSet(schema.HashString, []interface{}{"kept", "removed"})
+	newTags := schema.NewSet(schema.HashString, []interface{}{"kept", "new"})
+	err := updateDefinitionTags(clients, definitionTagsTestProjectID, 12, oldTags, newTags)
+	require.Nil(t, err)
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestBuildDefinitionTags_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	buildClient.
+		EXPECT().
+		AddDefinitionTags(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("AddDefinitionTags() Failed")).
+		Times(1)
+
+	err := resourceBuildDefinitionTagsCreate(newBuildDefinitionTagsResourceData(t, "compliance"), clients)
+	require.Contains(t, err.Error(), "AddDefinitionTags() Failed")
+}
+
+// verifies that the resource is removed from the state if the build definition no longer exists
+func TestBuildDefinitionTags_Read_RemovesDeletedDefinition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	buildClient.
+		EXPECT().
+		GetDefinitionTags(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(404)}).
+		Times(1)
+
+	resourceData := newBuildDefinitionTagsResourceData(t, "compliance")
+	resourceData.SetId(definitionTagsTestProjectID + "/12")
+	err := resourceBuildDefinitionTagsRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
@@ -13,10 +13,14 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/build"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/taskagent"
 	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/taskagentextras"
+	mock_taskagentextras "github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/taskagentextras/mocks"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
 	"github.com/stretchr/testify/require"
 )
@@ -97,15 +101,22 @@ var testBuildDefinition = build.BuildDefinition{
 	Name:     converter.String("Name"),
 	Path:     converter.String("\\"),
 	Repository: &build.BuildRepository{
-		Url:           converter.String("https://github.com/RepoId.git"),
-		Id:            converter.String("RepoId"),
-		Name:          converter.String("RepoId"),
-		DefaultBranch: converter.String("RepoBranchName"),
-		Type:          converter.String("GitHub"),
+		Url:                converter.String("https://github.com/RepoId.git"),
+		Id:                 converter.String("RepoId"),
+		Name:               converter.String("RepoId"),
+		DefaultBranch:      converter.String("RepoBranchName"),
+		Type:               converter.String("GitHub"),
+		Clean:              converter.String("true"),
+		CheckoutSubmodules: converter.Bool(true),
 		Properties: &map[string]string{
-			"connectedServiceId": "ServiceConnectionID",
-			"apiUrl":             "https://api.github.com/repos/RepoId",
-			"reportBuildStatus":  "true",
+			"connectedServiceId":       "ServiceConnectionID",
+			"apiUrl":                   "https://api.github.com/repos/RepoId",
+			"reportBuildStatus":        "true",
+			"fetchDepth":               "1",
+			"checkoutNestedSubmodules": "true",
+			"gitLfsSupport":            "true",
+			"labelSources":             "6",
+			"labelSourcesFormat":       "v$(build.buildNumber)",
 		},
 	},
 	Process: &build.YamlProcess{
@@ -117,11 +128,27 @@ var testBuildDefinition = build.BuildDefinition{
 			Name: converter.String("BuildPoolName"),
 		},
 	},
-	QueueStatus:    &build.DefinitionQueueStatusValues.Enabled,
-	Type:           &build.DefinitionTypeValues.Build,
-	Quality:        &build.DefinitionQualityValues.Definition,
-	Triggers:       &[]interface{}{},
-	VariableGroups: &[]build.VariableGroup{},
+	QueueStatus:               &build.DefinitionQueueStatusValues.Paused,
+	Type:                      &build.DefinitionTypeValues.Build,
+	Quality:                   &build.DefinitionQualityValues.Definition,
+	Triggers:                  &[]interface{}{},
+	VariableGroups:            &[]build.VariableGroup{},
+	BuildNumberFormat:         converter.String("$(date:yyyyMMdd)$(rev:.r)"),
+	BadgeEnabled:              converter.Bool(true),
+	JobAuthorizationScope:     &build.BuildAuthorizationScopeValues.Project,
+	JobTimeoutInMinutes:       converter.Int(30),
+	JobCancelTimeoutInMinutes: converter.Int(2),
+	RetentionRules: &[]build.RetentionPolicy{
+		{
+			Branches:              &[]string{"+refs/heads/main"},
+			DaysToKeep:            converter.Int(30),
+			MinimumToKeep:         converter.Int(5),
+			DeleteBuildRecord:     converter.Bool(true),
+			DeleteTestResults:     converter.Bool(false),
+			Artifacts:             &[]string{"build.SourceLabel"},
+			ArtifactTypesToDelete: &[]string{"FilePath", "SymbolStore"},
+		},
+	},
 }
 
 // This definition matches the overall structure of what a configured Bitbucket git repository would
@@ -132,15 +159,22 @@ var testBuildDefinitionBitbucketWithCITrigger = build.BuildDefinition{
 	Name:     converter.String("Name"),
 	Path:     converter.String("\\"),
 	Repository: &build.BuildRepository{
-		Url:           converter.String("https://bitbucket.org/RepoId.git"),
-		Id:            converter.String("RepoId"),
-		Name:          converter.String("RepoId"),
-		DefaultBranch: converter.String("RepoBranchName"),
-		Type:          converter.String("Bitbucket"),
+		Url:                converter.String("https://bitbucket.org/RepoId.git"),
+		Id:                 converter.String("RepoId"),
+		Name:               converter.String("RepoId"),
+		DefaultBranch:      converter.String("RepoBranchName"),
+		Type:               converter.String("Bitbucket"),
+		Clean:              converter.String("false"),
+		CheckoutSubmodules: converter.Bool(false),
 		Properties: &map[string]string{
-			"connectedServiceId": "ServiceConnectionID",
-			"apiUrl":             "https://api.bitbucket.org/2.0/repositories/RepoId",
-			"reportBuildStatus":  "true",
+			"connectedServiceId":       "ServiceConnectionID",
+			"apiUrl":                   "https://api.bitbucket.org/2.0/repositories/RepoId",
+			"reportBuildStatus":        "true",
+			"fetchDepth":               "0",
+			"checkoutNestedSubmodules": "false",
+			"gitLfsSupport":            "false",
+			"labelSources":             "0",
+			"labelSourcesFormat":       "$(build.buildNumber)",
 		},
 	},
 	Triggers: &[]interface{}{
@@ -155,10 +189,14 @@ var testBuildDefinitionBitbucketWithCITrigger = build.BuildDefinition{
 			Name: converter.String("BuildPoolName"),
 		},
 	},
-	QueueStatus:    &build.DefinitionQueueStatusValues.Enabled,
-	Type:           &build.DefinitionTypeValues.Build,
-	Quality:        &build.DefinitionQualityValues.Definition,
-	VariableGroups: &[]build.VariableGroup{},
+	QueueStatus:               &build.DefinitionQueueStatusValues.Enabled,
+	Type:                      &build.DefinitionTypeValues.Build,
+	Quality:                   &build.DefinitionQualityValues.Definition,
+	VariableGroups:            &[]build.VariableGroup{},
+	BadgeEnabled:              converter.Bool(false),
+	JobAuthorizationScope:     &build.BuildAuthorizationScopeValues.ProjectCollection,
+	JobTimeoutInMinutes:       converter.Int(60),
+	JobCancelTimeoutInMinutes: converter.Int(5),
 }
 
 // This definition matches the overall structure of what a configured GitHub Enterprise git repository would
@@ -170,15 +208,22 @@ var testBuildDefinitionGitHubEnterpriseWithCITrigger = build.BuildDefinition{
 	Name:     converter.String("Name"),
 	Path:     converter.String("\\"),
 	Repository: &build.BuildRepository{
-		Url:           converter.String("https://github.company.com/RepoId.git"),
-		Id:            converter.String("RepoId"),
-		Name:          converter.String("RepoId"),
-		DefaultBranch: converter.String("RepoBranchName"),
-		Type:          converter.String("GitHubEnterprise"),
+		Url:                converter.String("https://github.company.com/RepoId.git"),
+		Id:                 converter.String("RepoId"),
+		Name:               converter.String("RepoId"),
+		DefaultBranch:      converter.String("RepoBranchName"),
+		Type:               converter.String("GitHubEnterprise"),
+		Clean:              converter.String("false"),
+		CheckoutSubmodules: converter.Bool(false),
 		Properties: &map[string]string{
-			"connectedServiceId": "ServiceConnectionID",
-			"apiUrl":             "https://github.company.com/api/v3/repos/RepoId",
-			"reportBuildStatus":  "true",
+			"connectedServiceId":       "ServiceConnectionID",
+			"apiUrl":                   "https://github.company.com/api/v3/repos/RepoId",
+			"reportBuildStatus":        "true",
+			"fetchDepth":               "0",
+			"checkoutNestedSubmodules": "false",
+			"gitLfsSupport":            "false",
+			"labelSources":             "0",
+			"labelSourcesFormat":       "$(build.buildNumber)",
 		},
 	},
 	Triggers: &[]interface{}{
@@ -193,10 +238,14 @@ var testBuildDefinitionGitHubEnterpriseWithCITrigger = build.BuildDefinition{
 			Name: converter.String("BuildPoolName"),
 		},
 	},
-	QueueStatus:    &build.DefinitionQueueStatusValues.Enabled,
-	Type:           &build.DefinitionTypeValues.Build,
-	Quality:        &build.DefinitionQualityValues.Definition,
-	VariableGroups: &[]build.VariableGroup{},
+	QueueStatus:               &build.DefinitionQueueStatusValues.Enabled,
+	Type:                      &build.DefinitionTypeValues.Build,
+	Quality:                   &build.DefinitionQualityValues.Definition,
+	VariableGroups:            &[]build.VariableGroup{},
+	BadgeEnabled:              converter.Bool(false),
+	JobAuthorizationScope:     &build.BuildAuthorizationScopeValues.ProjectCollection,
+	JobTimeoutInMinutes:       converter.Int(60),
+	JobCancelTimeoutInMinutes: converter.Int(5),
 }
 
 // This definition matches the overall structure of what a configured Bitbucket git repository would
@@ -272,7 +321,7 @@ func testBuildDefinitionGitHubEnterprise() build.BuildDefinition {
 
 // validates that all supported repo types are allowed by the schema
 func TestBuildDefinition_RepoTypeListIsCorrect(t *testing.T) {
-	expectedRepoTypes := []string{"GitHub", "TfsGit", "Bitbucket", "GitHubEnterprise"}
+	expectedRepoTypes := []string{"GitHub", "TfsGit", "Bitbucket", "GitHubEnterprise", "Git", "Svn"}
 	repoSchema := ResourceBuildDefinition().Schema["repository"]
 	repoTypeSchema := repoSchema.Elem.(*schema.Resource).Schema["repo_type"]
 
@@ -336,6 +385,74 @@ func TestBuildDefinition_Expand_RepoUrl_GithubEnterprise(t *testing.T) {
 	require.Equal(t, testProjectID, projectID)
 }
 
+// verifies that generic Git and Subversion repositories are identified by their URL
+func TestBuildDefinition_Expand_RepoUrl_GitAndSvn(t *testing.T) {
+	for _, repoType := range []string{"Git", "Svn"} {
+		resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, map[string]interface{}{
+			"project_id": testProjectID,
+			"name":       "Name",
+			"repository": []interface{}{
+				map[string]interface{}{
+					"repo_type":             repoType,
+					"repo_id":               "https://scm.company.com/repo",
+					"service_connection_id": "ServiceConnectionID",
+					"yml_path":              "azure-pipelines.yml",
+					"fetch_depth":           1,
+				},
+			},
+		})
+		buildDefinition, _, err := expandBuildDefinition(resourceData)
+
+		require.Nil(t, err)
+		require.Equal(t, repoType, *buildDefinition.Repository.Type)
+		require.Equal(t, "https://scm.company.com/repo", *buildDefinition.Repository.Url)
+		require.Equal(t, "1", (*buildDefinition.Repository.Properties)["fetchDepth"])
+	}
+}
+
+// verifies that Subversion repositories require a service connection
+func TestBuildDefinition_ValidatesServiceConnection_Svn(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, map[string]interface{}{
+		"project_id": testProjectID,
+		"name":       "Name",
+		"repository": []interface{}{
+			map[string]interface{}{
+				"repo_type": "Svn",
+				"repo_id":   "https://svn.company.com/repo",
+			},
+		},
+	})
+
+	err := validateServiceConnectionIDExistsIfNeeded(resourceData)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "Subversion repositories need a referenced service connection ID")
+}
+
+// verifies that a YAML file is rejected at plan time for Subversion repositories, which only support designer pipelines
+func TestBuildDefinition_Diff_RejectsYamlPathForSvn(t *testing.T) {
+	config := func(repoType string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"project_id": testProjectID,
+			"name":       "Name",
+			"repository": []interface{}{
+				map[string]interface{}{
+					"repo_type":             repoType,
+					"repo_id":               "https://scm.company.com/repo",
+					"service_connection_id": "ServiceConnectionID",
+					"yml_path":              "azure-pipelines.yml",
+				},
+			},
+		})
+	}
+
+	_, err := ResourceBuildDefinition().Diff(context.Background(), nil, config("Svn"), nil)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "repository.0.yml_path can not be used with Subversion repositories")
+
+	_, err = ResourceBuildDefinition().Diff(context.Background(), nil, config("Git"), nil)
+	require.Nil(t, err)
+}
+
 // verifies that a service connection is required for bitbucket repos
 func TestBuildDefinition_ValidatesServiceConnection_Bitbucket(t *testing.T) {
 	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, nil)
@@ -549,6 +666,206 @@ func TestExpandVariables_CatchesDuplicateVariables(t *testing.T) {
 	require.Contains(t, err.Error(), "Unexpectedly found duplicate variable with name")
 }
 
+// verifies that a designer process, as returned by the service, survives the flatten/expand round trip
+func TestBuildDefinition_ExpandFlatten_DesignerProcess(t *testing.T) {
+	taskID := uuid.New()
+	designerBuildDefinition := testBuildDefinition
+	designerBuildDefinition.Process = map[string]interface{}{
+		"type": float64(1),
+		"target": map[string]interface{}{
+			"agentSpecification": map[string]interface{}{"identifier": "ubuntu-latest"},
+		},
+		"phases": []interface{}{
+			map[string]interface{}{
+				"name":                      "Agent job 1",
+				"refName":                   "Job_1",
+				"condition":                 "succeeded()",
+				"jobTimeoutInMinutes":       float64(30),
+				"jobCancelTimeoutInMinutes": float64(5),
+				"target": map[string]interface{}{
+					"type":                         float64(1),
+					"allowScriptsAuthAccessOption": true,
+					"executionOptions":             map[string]interface{}{"type": float64(0)},
+				},
+				"steps": []interface{}{
+					map[string]interface{}{
+						"displayName":      "Build",
+						"enabled":          true,
+						"continueOnError":  false,
+						"alwaysRun":        false,
+						"condition":        "succeeded()",
+						"timeoutInMinutes": float64(0),
+						"inputs":           map[string]interface{}{"script": "make"},
+						"task": map[string]interface{}{
+							"id":             taskID.String(),
+							"versionSpec":    "2.*",
+							"definitionType": "task",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, nil)
+	flattenBuildDefinition(resourceData, &designerBuildDefinition, testProjectID)
+	require.Equal(t, "", resourceData.Get("repository.0.yml_path"))
+	require.Equal(t, "ubuntu-latest", resourceData.Get("agent_specification"))
+	require.Equal(t, "Job_1", resourceData.Get("phase.0.ref_name"))
+	require.Equal(t, "make", resourceData.Get("phase.0.step.0.inputs.script"))
+
+	expandedBuildDefinition, _, err := expandBuildDefinition(resourceData)
+	require.Nil(t, err)
+
+	process := expandedBuildDefinition.Process.(*designerProcess)
+	require.Equal(t, 1, *process.Type)
+	require.Equal(t, "ubuntu-latest", *process.Target.AgentSpecification.Identifier)
+	require.Len(t, *process.Phases, 1)
+	phase := (*process.Phases)[0]
+	require.Equal(t, "Job_1", *phase.RefName)
+	require.Equal(t, 30, *phase.JobTimeoutInMinutes)
+	require.True(t, *phase.Target.AllowScriptsAuthAccessOption)
+	require.Len(t, *phase.Steps, 1)
+	step := (*phase.Steps)[0]
+	require.Equal(t, taskID, *step.Task.Id)
+	require.Equal(t, "2.*", *step.Task.VersionSpec)
+	require.Equal(t, map[string]string{"script": "make"}, *step.Inputs)
+}
+
+// verifies that only the configured task inputs are read, so that removing an input from the configuration is planned
+func TestBuildDefinition_Flatten_DesignerStepKeepsConfiguredInputs(t *testing.T) {
+	taskID := uuid.New()
+	step := build.BuildDefinitionStep{
+		Task:   &build.TaskDefinitionReference{Id: &taskID, VersionSpec: converter.String("2.*")},
+		Inputs: &map[string]string{"script": "make", "workingDirectory": "", "failOnStderr": "false"},
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, map[string]interface{}{
+		"phase": []interface{}{map[string]interface{}{
+			"step": []interface{}{map[string]interface{}{
+				"task_id": taskID.String(),
+				"version": "2.*",
+				"inputs":  map[string]interface{}{"script": "make", "workingDirectory": "src"},
+			}},
+		}},
+	})
+	steps := flattenDesignerSteps(resourceData, "phase.0.step", &[]build.BuildDefinitionStep{step})
+	require.Equal(t, map[string]string{"script": "make", "workingDirectory": ""}, steps[0].(map[string]interface{})["inputs"])
+}
+
+// verifies that the inputs with the default value of the task are removed from a step which is not in the state, e.g. after an import
+func TestBuildDefinition_Read_ImportedDesignerStepRemovesDefaultInputs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskAgentClientExtras := mock_taskagentextras.NewTaskAgentClientExtras(ctrl)
+	clients := &client.AggregatedClient{TaskAgentClientExtras: taskAgentClientExtras, Ctx: context.Background()}
+
+	taskID := uuid.New()
+	designerBuildDefinition := testBuildDefinition
+	designerBuildDefinition.Process = map[string]interface{}{
+		"type": float64(1),
+		"phases": []interface{}{
+			map[string]interface{}{
+				"name": "Agent job 1",
+				"steps": []interface{}{
+					map[string]interface{}{
+						"inputs": map[string]interface{}{"script": "make", "workingDirectory": "", "failOnStderr": "false"},
+						"task":   map[string]interface{}{"id": taskID.String(), "versionSpec": "2.*"},
+					},
+				},
+			},
+		},
+	}
+
+	taskAgentClientExtras.
+		EXPECT().
+		GetTaskDefinitions(clients.Ctx, taskagentextras.GetTaskDefinitionsArgs{TaskId: &taskID}).
+		Return(&[]taskagent.TaskDefinition{
+			{
+				Version: &taskagent.TaskVersion{Major: converter.Int(1)},
+				Inputs:  &[]taskagent.TaskInputDefinition{{Name: converter.String("failOnStderr"), DefaultValue: converter.String("true")}},
+			},
+			{
+				Version: &taskagent.TaskVersion{Major: converter.Int(2)},
+				Inputs: &[]taskagent.TaskInputDefinition{
+					{Name: converter.String("script"), DefaultValue: converter.String("echo")},
+					{Name: converter.String("failOnStderr"), DefaultValue: converter.String("false")},
+				},
+			},
+		}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, nil)
+	err := removeDefaultTaskInputs(clients, resourceData, &designerBuildDefinition)
+	require.Nil(t, err)
+	flattenBuildDefinition(resourceData, &designerBuildDefinition, testProjectID)
+	require.Equal(t, map[string]interface{}{"script": "make"}, resourceData.Get("phase.0.step.0.inputs"))
+}
+
+// verifies that either a YAML file or designer phases are required
+func TestBuildDefinition_Expand_RequiresYamlPathOrPhase(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildDefinition().Schema, nil)
+	resourceData.Set("repository", []interface{}{
+		map[string]interface{}{"repo_id": "RepoId", "repo_type": "TfsGit"},
+	})
+
+	_, _, err := expandBuildDefinition(resourceData)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "either repository.yml_path or phase must be specified")
+}
+
+// verifies that a task input removed from the configuration is reported as a diff
+func TestBuildDefinition_Diff_PlansRemovedTaskInputs(t *testing.T) {
+	taskID := uuid.New().String()
+	state := &terraform.InstanceState{
+		ID: "100",
+		Attributes: map[string]string{
+			"project_id":                             testProjectID,
+			"repository.#":                           "1",
+			"repository.0.repo_id":                   "RepoId",
+			"repository.0.repo_type":                 "TfsGit",
+			"phase.#":                                "1",
+			"phase.0.name":                           "Agent job 1",
+			"phase.0.step.#":                         "1",
+			"phase.0.step.0.task_id":                 taskID,
+			"phase.0.step.0.version":                 "2.*",
+			"phase.0.step.0.inputs.%":                "2",
+			"phase.0.step.0.inputs.script":           "make",
+			"phase.0.step.0.inputs.workingDirectory": "src",
+		},
+	}
+	config := func(script string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"project_id": testProjectID,
+			"repository": []interface{}{
+				map[string]interface{}{"repo_id": "RepoId", "repo_type": "TfsGit"},
+			},
+			"phase": []interface{}{
+				map[string]interface{}{
+					"name": "Agent job 1",
+					"step": []interface{}{
+						map[string]interface{}{
+							"task_id": taskID,
+							"version": "2.*",
+							"inputs":  map[string]interface{}{"script": script},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	diff, err := ResourceBuildDefinition().Diff(context.Background(), state, config("make"), nil)
+	require.Nil(t, err)
+	require.True(t, diff.Attributes["phase.0.step.0.inputs.workingDirectory"].NewRemoved)
+	require.Equal(t, "1", diff.Attributes["phase.0.step.0.inputs.%"].New)
+
+	diff, err = ResourceBuildDefinition().Diff(context.Background(), state, config("make all"), nil)
+	require.Nil(t, err)
+	require.Equal(t, "make all", diff.Attributes["phase.0.step.0.inputs.script"].New)
+}
+
 func sortBuildDefinition(b build.BuildDefinition) build.BuildDefinition {
 	if b.Triggers == nil {
 		return b
@@ -40,6 +40,11 @@ func ResourceBuildFolder() *schema.Resource {
 				Optional: true,
 				Default:  ``,
 			},
+			"create_parents": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
@@ -51,6 +56,12 @@ func resourceBuildFolderCreate(d *schema.ResourceData, m interface{}) error {
 	description := d.Get("description").(string)
 	path := d.Get("path").(string)
 
+	if d.Get("create_parents").(bool) {
+		if err := createParentBuildFolders(clients, path, projectID); err != nil {
+			return fmt.Errorf(" failed creating parent folders of Build Folder, %+v", err)
+		}
+	}
+
 	createdBuildFolder, err := createBuildFolder(clients, path, projectID, description)
 	if err != nil {
 		return fmt.Errorf(" failed creating resource Build Folder, %+v", err)
@@ -66,11 +77,7 @@ func resourceBuildFolderRead(d *schema.ResourceData, m interface{}) error {
 	projectID := d.Get("project_id").(string)
 	path := d.Id()
 
-	buildFolders, err := clients.BuildClient.GetFolders(clients.Ctx, build.GetFoldersArgs{
-		Project: &projectID,
-		Path:    &path,
-	})
-
+	buildFolder, err := getBuildFolder(clients, path, projectID)
 	if err != nil {
 		if utils.ResponseWasNotFound(err) {
 			d.SetId("")
@@ -79,15 +86,13 @@ func resourceBuildFolderRead(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
-	if len(*buildFolders) == 0 {
+	if buildFolder == nil {
 		d.SetId("")
 		log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Folder [%s] not found. Removing from state.", path)
 		return nil
 	}
 
-	buildFolder := (*buildFolders)[0]
-
-	flattenBuildFolder(d, &buildFolder, projectID)
+	flattenBuildFolder(d, buildFolder, projectID)
 	return nil
 }
 
@@ -100,6 +105,13 @@ func resourceBuildFolderUpdate(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf(" failed to expand build folder configurations. Project ID: %s , Error: %+v", projectID, err)
 	}
 
+	// Changing the path moves the folder including its definitions
+	if d.HasChange("path") && d.Get("create_parents").(bool) {
+		if err := createParentBuildFolders(clients, *buildFolder.Path, projectID); err != nil {
+			return fmt.Errorf(" failed creating parent folders of Build Folder, %+v", err)
+		}
+	}
+
 	updatedBuildFolder, err := clients.BuildClient.UpdateFolder(m.(*client.AggregatedClient).Ctx, build.UpdateFolderArgs{
 		Project: &projectID,
 		Path:    converter.String(oldPath.(string)),
@@ -161,6 +173,48 @@ func createBuildFolder(clients *client.AggregatedClient, path string, project st
 	return createdBuild, err
 }
 
+// getBuildFolder returns the folder with the given path or nil if it does not exist.
+// The service returns the folder together with all folders below it.
+func getBuildFolder(clients *client.AggregatedClient, path string, projectID string) (*build.Folder, error) {
+	buildFolders, err := clients.BuildClient.GetFolders(clients.Ctx, build.GetFoldersArgs{
+		Project: &projectID,
+		Path:    &path,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if buildFolders == nil {
+		return nil, nil
+	}
+
+	for _, buildFolder := range *buildFolders {
+		if buildFolder.Path != nil && strings.EqualFold(*buildFolder.Path, path) {
+			return &buildFolder, nil
+		}
+	}
+	return nil, nil
+}
+
+// createParentBuildFolders creates the missing folders above the given path, e.g. \Team and \Team\App for \Team\App\Release
+func createParentBuildFolders(clients *client.AggregatedClient, path string, projectID string) error {
+	parts := strings.Split(strings.Trim(path, `\`), `\`)
+	parentPath := ""
+	for _, part := range parts[:len(parts)-1] {
+		parentPath = parentPath + `\` + part
+		parentFolder, err := getBuildFolder(clients, parentPath, projectID)
+		if err != nil {
+			return err
+		}
+		if parentFolder != nil {
+			continue
+		}
+		if _, err := createBuildFolder(clients, parentPath, projectID, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // create a Folder object from the tf Resource Data
 func expandBuildFolder(d *schema.ResourceData) (*build.Folder, string, error) {
 	projectID := d.Get("project_id").(string)
@@ -142,3 +142,74 @@ func TestBuildFolder_Update_DoesNotSwallowError(t *testing.T) {
 	err := resourceBuildFolderUpdate(resourceData, clients)
 	require.Contains(t, err.Error(), "UpdateFolder() Failed")
 }
+
+// verifies that the missing parent folders are created before the folder
+func TestBuildFolder_Create_CreatesMissingParents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildFolder().Schema, map[string]interface{}{
+		"project_id":     testProjectUUID.String(),
+		"path":           `\Teams\App\Release`,
+		"create_parents": true,
+	})
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	projectID := testProjectUUID.String()
+	gomock.InOrder(
+		buildClient.
+			EXPECT().
+			GetFolders(clients.Ctx, build.GetFoldersArgs{Project: &projectID, Path: converter.String(`\Teams`)}).
+			Return(&[]build.Folder{{Path: converter.String(`\Teams`)}, {Path: converter.String(`\Teams\Other`)}}, nil),
+		buildClient.
+			EXPECT().
+			GetFolders(clients.Ctx, build.GetFoldersArgs{Project: &projectID, Path: converter.String(`\Teams\App`)}).
+			Return(&[]build.Folder{}, nil),
+		buildClient.
+			EXPECT().
+			CreateFolder(clients.Ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, args build.CreateFolderArgs) (*build.Folder, error) {
+				require.Equal(t, `\Teams\App`, *args.Path)
+				return args.Folder, nil
+			}),
+		buildClient.
+			EXPECT().
+			CreateFolder(clients.Ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, args build.CreateFolderArgs) (*build.Folder, error) {
+				require.Equal(t, `\Teams\App\Release`, *args.Path)
+				return nil, errors.New("CreateFolder() Failed")
+			}),
+	)
+
+	err := resourceBuildFolderCreate(resourceData, clients)
+	require.Contains(t, err.Error(), "CreateFolder() Failed")
+}
+
+// verifies that the folder with the exact path is read and not one of its sub folders
+func TestBuildFolder_Read_SelectsFolderWithPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceBuildFolder().Schema, nil)
+	resourceData.Set("project_id", testProjectID)
+	resourceData.SetId(`\Teams`)
+
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{BuildClient: buildClient, Ctx: context.Background()}
+
+	buildClient.
+		EXPECT().
+		GetFolders(clients.Ctx, gomock.Any()).
+		Return(&[]build.Folder{
+			{Path: converter.String(`\Teams\App`), Description: converter.String("App")},
+			{Path: converter.String(`\Teams`), Description: converter.String("Teams")},
+		}, nil).
+		Times(1)
+
+	err := resourceBuildFolderRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, `\Teams`, resourceData.Id())
+	require.Equal(t, "Teams", resourceData.Get("description"))
+}
@@ -0,0 +1,192 @@
+package build
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/pipelinepermissions"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+)
+
+// Resource types supported by the pipeline permissions API
+var pipelineAuthorizationResourceTypes = []string{"endpoint", "queue", "variablegroup", "environment", "securefile", "repository"}
+
+// ResourcePipelineAuthorization schema and implementation for pipeline authorization resource
+func ResourcePipelineAuthorization() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePipelineAuthorizationCreate,
+		Read:   resourcePipelineAuthorizationRead,
+		Delete: resourcePipelineAuthorizationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourcePipelineAuthorizationImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"resource_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "id of the resource",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "type of the resource",
+				ValidateFunc: validation.StringInSlice(pipelineAuthorizationResourceTypes, false),
+			},
+			"pipeline_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "id of the pipeline to authorize. All pipelines of the project are authorized if not set",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+		},
+	}
+}
+
+func resourcePipelineAuthorizationCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	err := updatePipelineAuthorization(d, clients, true)
+	if err != nil {
+		return fmt.Errorf(" creating pipeline authorization: %+v", err)
+	}
+
+	d.SetId(pipelineAuthorizationID(d))
+	return resourcePipelineAuthorizationRead(d, m)
+}
+
+func resourcePipelineAuthorizationRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	projectID := d.Get("project_id").(string)
+	resourceType := d.Get("type").(string)
+	permissions, err := clients.PipelinePermissionsClient.GetPipelinePermissionsForResource(clients.Ctx, pipelinepermissions.GetPipelinePermissionsForResourceArgs{
+		Project:      &projectID,
+		ResourceType: &resourceType,
+		ResourceId:   converter.String(pipelineAuthorizationResourceID(d)),
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf(" reading pipeline authorization: %+v", err)
+	}
+
+	if !isPipelineAuthorized(permissions, d.Get("pipeline_id").(int)) {
+		log.Printf("[WARN] The pipeline authorization with ID '%s' no longer exists. Setting Id to empty \n", d.Id())
+		d.SetId("")
+		return nil
+	}
+	return nil
+}
+
+func resourcePipelineAuthorizationDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	err := updatePipelineAuthorization(d, clients, false)
+	if err != nil {
+		return fmt.Errorf(" deleting pipeline authorization: %+v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourcePipelineAuthorizationImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) < 3 || len(parts) > 4 {
+		return nil, fmt.Errorf(" Unexpected format of ID (%s), expected projectID/type/resourceID or projectID/type/resourceID/pipelineID", d.Id())
+	}
+
+	d.Set("project_id", parts[0])
+	d.Set("type", parts[1])
+	d.Set("resource_id", parts[2])
+	if len(parts) == 4 {
+		pipelineID, err := strconv.Atoi(parts[3])
+		if err != nil {
+			return nil, fmt.Errorf(" Pipeline ID (%s) must be an integer", parts[3])
+		}
+		d.Set("pipeline_id", pipelineID)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func updatePipelineAuthorization(d *schema.ResourceData, clients *client.AggregatedClient, authorized bool) error {
+	projectID := d.Get("project_id").(string)
+	resourceType := d.Get("type").(string)
+
+	permissions := pipelinepermissions.ResourcePipelinePermissions{}
+	if pipelineID, ok := d.GetOk("pipeline_id"); ok {
+		permissions.Pipelines = &[]pipelinepermissions.PipelinePermission{
+			{
+				Id:         converter.Int(pipelineID.(int)),
+				Authorized: converter.Bool(authorized),
+			},
+		}
+	} else {
+		permissions.AllPipelines = &pipelinepermissions.Permission{
+			Authorized: converter.Bool(authorized),
+		}
+	}
+
+	_, err := clients.PipelinePermissionsClient.UpdatePipelinePermisionsForResource(clients.Ctx, pipelinepermissions.UpdatePipelinePermisionsForResourceArgs{
+		ResourceAuthorization: &permissions,
+		Project:               &projectID,
+		ResourceType:          &resourceType,
+		ResourceId:            converter.String(pipelineAuthorizationResourceID(d)),
+	})
+	return err
+}
+
+func isPipelineAuthorized(permissions *pipelinepermissions.ResourcePipelinePermissions, pipelineID int) bool {
+	if permissions == nil {
+		return false
+	}
+	if pipelineID == 0 {
+		return permissions.AllPipelines != nil && permissions.AllPipelines.Authorized != nil && *permissions.AllPipelines.Authorized
+	}
+	if permissions.Pipelines == nil {
+		return false
+	}
+	for _, pipeline := range *permissions.Pipelines {
+		if pipeline.Id != nil && *pipeline.Id == pipelineID {
+			return pipeline.Authorized == nil || *pipeline.Authorized
+		}
+	}
+	return false
+}
+
+// pipelineAuthorizationResourceID returns the id of the resource as expected by the pipeline permissions API.
+// Repositories are identified by the project and repository id.
+func pipelineAuthorizationResourceID(d *schema.ResourceData) string {
+	resourceID := d.Get("resource_id").(string)
+	if d.Get("type").(string) == "repository" {
+		return d.Get("project_id").(string) + "." + resourceID
+	}
+	return resourceID
+}
+
+func pipelineAuthorizationID(d *schema.ResourceData) string {
+	id := fmt.Sprintf("%s/%s/%s", d.Get("project_id").(string), d.Get("type").(string), d.Get("resource_id").(string))
+	if pipelineID, ok := d.GetOk("pipeline_id"); ok {
+		id = fmt.Sprintf("%s/%d", id, pipelineID.(int))
+	}
+	return id
+}
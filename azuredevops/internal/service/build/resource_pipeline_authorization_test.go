@@ -0,0 +1,198 @@
+//go:build (all || resource_pipeline_authorization) && !exclude_resource_pipeline_authorization
+// +build all resource_pipeline_authorization
+// +build !exclude_resource_pipeline_authorization
+
+package build
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/pipelinepermissions"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var pipelineAuthorizationTestProjectID = uuid.New().String()
+var pipelineAuthorizationTestResourceID = uuid.New().String()
+
+func newPipelineAuthorizationResourceData(t *testing.T, resourceType string, pipelineID int) *schema.ResourceData {
+	resourceData := schema.TestResourceDataRaw(t, ResourcePipelineAuthorization().Schema, nil)
+	resourceData.Set("project_id", pipelineAuthorizationTestProjectID)
+	resourceData.Set("resource_id", pipelineAuthorizationTestResourceID)
+	resourceData.Set("type", resourceType)
+	if pipelineID != 0 {
+		resourceData.Set("pipeline_id", pipelineID)
+	}
+	return resourceData
+}
+
+// verifies that all pipelines are authorized if no pipeline is specified
+func TestPipelineAuthorization_Create_AuthorizesAllPipelines(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	permissionsClient := azdosdkmocks.NewMockPipelinepermissionsClient(ctrl)
+	clients := &client.AggregatedClient{PipelinePermissionsClient: permissionsClient, Ctx: context.Background()}
+
+	allPipelines := pipelinepermissions.ResourcePipelinePermissions{
+		AllPipelines: &pipelinepermissions.Permission{Authorized: converter.Bool(true)},
+	}
+	permissionsClient.
+		EXPECT().
+		UpdatePipelinePermisionsForResource(clients.Ctx, pipelinepermissions.UpdatePipelinePermisionsForResourceArgs{
+			ResourceAuthorization: &allPipelines,
+			Project:               &pipelineAuthorizationTestProjectID,
+			ResourceType:          converter.String("environment"),
+			ResourceId:            &pipelineAuthorizationTestResourceID,
+		}).
+		Return(&allPipelines, nil).
+		Times(1)
+	permissionsClient.
+		EXPECT().
+		GetPipelinePermissionsForResource(clients.Ctx, gomock.Any()).
+		Return(&allPipelines, nil).
+		Times(1)
+
+	resourceData := newPipelineAuthorizationResourceData(t, "environment", 0)
+	err := resourcePipelineAuthorizationCreate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, pipelineAuthorizationTestProjectID+"/environment/"+pipelineAuthorizationTestResourceID, resourceData.Id())
+}
+
+// verifies that a single pipeline is authorized and that repositories are qualified by the project
+func TestPipelineAuthorization_Create_AuthorizesPipelineForRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	permissionsClient := azdosdkmocks.NewMockPipelinepermissionsClient(ctrl)
+	clients := &client.AggregatedClient{PipelinePermissionsClient: permissionsClient, Ctx: context.Background()}
+
+	pipeline := pipelinepermissions.ResourcePipelinePermissions{
+		Pipelines: &[]pipelinepermissions.PipelinePermission{
+			{Id: converter.Int(42), Authorized: converter.Bool(true)},
+		},
+	}
+	expectedResourceID := pipelineAuthorizationTestProjectID + "." + pipelineAuthorizationTestResourceID
+	permissionsClient.
+		EXPECT().
+		UpdatePipelinePermisionsForResource(clients.Ctx, pipelinepermissions.UpdatePipelinePermisionsForResourceArgs{
+			ResourceAuthorization: &pipeline,
+			Project:               &pipelineAuthorizationTestProjectID,
+			ResourceType:          converter.String("repository"),
+			ResourceId:            &expectedResourceID,
+		}).
+		Return(&pipeline, nil).
+		Times(1)
+	permissionsClient.
+		EXPECT().
+		GetPipelinePermissionsForResource(clients.Ctx, pipelinepermissions.GetPipelinePermissionsForResourceArgs{
+			Project:      &pipelineAuthorizationTestProjectID,
+			ResourceType: converter.String("repository"),
+			ResourceId:   &expectedResourceID,
+		}).
+		Return(&pipeline, nil).
+		Times(1)
+
+	resourceData := newPipelineAuthorizationResourceData(t, "repository", 42)
+	err := resourcePipelineAuthorizationCreate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, pipelineAuthorizationTestProjectID+"/repository/"+pipelineAuthorizationTestResourceID+"/42", resourceData.Id())
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestPipelineAuthorization_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	permissionsClient := azdosdkmocks.NewMockPipelinepermissionsClient(ctrl)
+	clients := &client.AggregatedClient{PipelinePermissionsClient: permissionsClient, Ctx: context.Background()}
+
+	permissionsClient.
+		EXPECT().
+		UpdatePipelinePermisionsForResource(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("UpdatePipelinePermisionsForResource() Failed")).
+		Times(1)
+
+	resourceData := newPipelineAuthorizationResourceData(t, "securefile", 0)
+	err := resourcePipelineAuthorizationCreate(resourceData, clients)
+	require.Contains(t, err.Error(), "UpdatePipelinePermisionsForResource() Failed")
+}
+
+// verifies that the resource is removed from the state if the pipeline is no longer authorized
+func TestPipelineAuthorization_Read_RemovesRevokedAuthorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	permissionsClient := azdosdkmocks.NewMockPipelinepermissionsClient(ctrl)
+	clients := &client.AggregatedClient{PipelinePermissionsClient: permissionsClient, Ctx: context.Background()}
+
+	permissionsClient.
+		EXPECT().
+		GetPipelinePermissionsForResource(clients.Ctx, gomock.Any()).
+		Return(&pipelinepermissions.ResourcePipelinePermissions{
+			AllPipelines: &pipelinepermissions.Permission{Authorized: converter.Bool(true)},
+			Pipelines: &[]pipelinepermissions.PipelinePermission{
+				{Id: converter.Int(7), Authorized: converter.Bool(true)},
+			},
+		}, nil).
+		Times(1)
+
+	resourceData := newPipelineAuthorizationResourceData(t, "queue", 42)
+	resourceData.SetId("someid")
+	err := resourcePipelineAuthorizationRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that deleting revokes the authorization of all pipelines
+func TestPipelineAuthorization_Delete_RevokesAuthorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	permissionsClient := azdosdkmocks.NewMockPipelinepermissionsClient(ctrl)
+	clients := &client.AggregatedClient{PipelinePermissionsClient: permissionsClient, Ctx: context.Background()}
+
+	permissionsClient.
+		EXPECT().
+		UpdatePipelinePermisionsForResource(clients.Ctx, pipelinepermissions.UpdatePipelinePermisionsForResourceArgs{
+			ResourceAuthorization: &pipelinepermissions.ResourcePipelinePermissions{
+				AllPipelines: &pipelinepermissions.Permission{Authorized: converter.Bool(false)},
+			},
+			Project:      &pipelineAuthorizationTestProjectID,
+			ResourceType: converter.String("variablegroup"),
+			ResourceId:   &pipelineAuthorizationTestResourceID,
+		}).
+		Return(nil, nil).
+		Times(1)
+
+	resourceData := newPipelineAuthorizationResourceData(t, "variablegroup", 0)
+	resourceData.SetId("someid")
+	err := resourcePipelineAuthorizationDelete(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that the import ID is parsed into the resource arguments
+func TestPipelineAuthorization_Import(t *testing.T) {
+	resourceData := schema.TestResourceDataRaw(t, ResourcePipelineAuthorization().Schema, nil)
+	resourceData.SetId(pipelineAuthorizationTestProjectID + "/environment/12/42")
+
+	result, err := resourcePipelineAuthorizationImport(resourceData, nil)
+	require.Nil(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, pipelineAuthorizationTestProjectID, resourceData.Get("project_id"))
+	require.Equal(t, "environment", resourceData.Get("type"))
+	require.Equal(t, "12", resourceData.Get("resource_id"))
+	require.Equal(t, 42, resourceData.Get("pipeline_id"))
+
+	resourceData.SetId("invalid")
+	_, err = resourcePipelineAuthorizationImport(resourceData, nil)
+	require.NotNil(t, err)
+}
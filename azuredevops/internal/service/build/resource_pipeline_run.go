@@ -0,0 +1,262 @@
+package build
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/pipelines"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+)
+
+// Name of the repository resource which contains the pipeline definition
+const selfRepositoryResource = "self"
+
+// ResourcePipelineRun schema and implementation for pipeline run resource
+func ResourcePipelineRun() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePipelineRunCreate,
+		Read:   resourcePipelineRunRead,
+		Update: resourcePipelineRunUpdate,
+		Delete: resourcePipelineRunDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"definition_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"branch": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"template_parameters": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"variables": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"secret_variables": {
+				Type:      schema.TypeMap,
+				Optional:  true,
+				ForceNew:  true,
+				Sensitive: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"wait_for_completion": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"result": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourcePipelineRunCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	definitionID := d.Get("definition_id").(int)
+
+	run, err := clients.PipelinesClient.RunPipeline(clients.Ctx, pipelines.RunPipelineArgs{
+		Project:       &projectID,
+		PipelineId:    &definitionID,
+		RunParameters: expandPipelineRunParameters(d),
+	})
+	if err != nil {
+		return fmt.Errorf(" running pipeline %d: %+v", definitionID, err)
+	}
+
+	d.SetId(strconv.Itoa(*run.Id))
+
+	if d.Get("wait_for_completion").(bool) {
+		run, err = waitForPipelineRun(clients, projectID, definitionID, *run.Id, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return err
+		}
+		flattenPipelineRun(d, run)
+		return checkPipelineRunSucceeded(run, definitionID)
+	}
+
+	return resourcePipelineRunRead(d, m)
+}
+
+func resourcePipelineRunRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	definitionID := d.Get("definition_id").(int)
+	runID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf(" parsing pipeline run ID: %+v", err)
+	}
+
+	run, err := clients.PipelinesClient.GetRun(clients.Ctx, pipelines.GetRunArgs{
+		Project:    &projectID,
+		PipelineId: &definitionID,
+		RunId:      &runID,
+	})
+	if err != nil {
+		// A run deleted by the retention policies must not be triggered again
+		if utils.ResponseWasNotFound(err) {
+			log.Printf("[WARN] The pipeline run with ID '%d' no longer exists. Keeping the last known state.", runID)
+			return nil
+		}
+		return fmt.Errorf(" reading pipeline run %d: %+v", runID, err)
+	}
+
+	flattenPipelineRun(d, run)
+	return nil
+}
+
+// resourcePipelineRunUpdate only applies wait_for_completion, all other changes trigger a new run
+func resourcePipelineRunUpdate(d *schema.ResourceData, m interface{}) error {
+	return resourcePipelineRunRead(d, m)
+}
+
+// resourcePipelineRunDelete removes the run from the state. Runs are kept according to the retention policies.
+func resourcePipelineRunDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+func expandPipelineRunParameters(d *schema.ResourceData) *pipelines.RunPipelineParameters {
+	templateParameters := map[string]string{}
+	for name, value := range d.Get("template_parameters").(map[string]interface{}) {
+		templateParameters[name] = value.(string)
+	}
+
+	variables := map[string]pipelines.Variable{}
+	for name, value := range d.Get("variables").(map[string]interface{}) {
+		variables[name] = pipelines.Variable{
+			Value:    converter.String(value.(string)),
+			IsSecret: converter.Bool(false),
+		}
+	}
+	for name, value := range d.Get("secret_variables").(map[string]interface{}) {
+		variables[name] = pipelines.Variable{
+			Value:    converter.String(value.(string)),
+			IsSecret: converter.Bool(true),
+		}
+	}
+
+	parameters := &pipelines.RunPipelineParameters{
+		TemplateParameters: &templateParameters,
+		Variables:          &variables,
+	}
+
+	if branch, ok := d.GetOk("branch"); ok {
+		parameters.Resources = &pipelines.RunResourcesParameters{
+			Repositories: &map[string]pipelines.RepositoryResourceParameters{
+				selfRepositoryResource: {
+					RefName: converter.String(expandBranchRefName(branch.(string))),
+				},
+			},
+		}
+	}
+	return parameters
+}
+
+// expandBranchRefName qualifies a short branch name, e.g. main becomes refs/heads/main
+func expandBranchRefName(branch string) string {
+	if strings.HasPrefix(branch, "refs/") {
+		return branch
+	}
+	return "refs/heads/" + branch
+}
+
+// checkPipelineRunSucceeded returns an error if a completed run did not succeed, which fails the apply
+func checkPipelineRunSucceeded(run *pipelines.Run, definitionID int) error {
+	if run.Result == nil || *run.Result != pipelines.RunResultValues.Succeeded {
+		result := converter.ToString((*string)(run.Result), string(pipelines.RunResultValues.Unknown))
+		return fmt.Errorf(" pipeline run %d of pipeline %d finished with result %s", *run.Id, definitionID, result)
+	}
+	return nil
+}
+
+func flattenPipelineRun(d *schema.ResourceData, run *pipelines.Run) {
+	d.Set("name", converter.ToString(run.Name, ""))
+	d.Set("state", converter.ToString((*string)(run.State), ""))
+	d.Set("result", converter.ToString((*string)(run.Result), ""))
+}
+
+func waitForPipelineRun(clients *client.AggregatedClient, projectID string, definitionID int, runID int, timeout time.Duration) (*pipelines.Run, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			string(pipelines.RunStateValues.Unknown),
+			string(pipelines.RunStateValues.InProgress),
+			string(pipelines.RunStateValues.Canceling),
+		},
+		Target: []string{
+			string(pipelines.RunStateValues.Completed),
+		},
+		Refresh: func() (interface{}, string, error) {
+			run, err := clients.PipelinesClient.GetRun(clients.Ctx, pipelines.GetRunArgs{
+				Project:    &projectID,
+				PipelineId: &definitionID,
+				RunId:      &runID,
+			})
+			if err != nil {
+				return nil, "", fmt.Errorf(" reading pipeline run %d: %+v", runID, err)
+			}
+			return run, converter.ToString((*string)(run.State), string(pipelines.RunStateValues.Unknown)), nil
+		},
+		Timeout:    timeout,
+		MinTimeout: 10 * time.Second,
+		Delay:      5 * time.Second,
+	}
+
+	run, err := stateConf.WaitForState() //nolint:staticcheck
+	if err != nil {
+		return nil, fmt.Errorf(" waiting for pipeline run %d to complete: %+v", runID, err)
+	}
+	return run.(*pipelines.Run), nil
+}
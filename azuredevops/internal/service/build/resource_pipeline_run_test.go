@@ -0,0 +1,130 @@
+//go:build (all || resource_pipeline_run) && !exclude_resource_pipeline_run
+// +build all resource_pipeline_run
+// +build !exclude_resource_pipeline_run
+
+package build
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/pipelines"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var pipelineRunTestProjectID = uuid.New().String()
+
+func newPipelineRunResourceData(t *testing.T) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, ResourcePipelineRun().Schema, map[string]interface{}{
+		"project_id":          pipelineRunTestProjectID,
+		"definition_id":       12,
+		"branch":              "main",
+		"template_parameters": map[string]interface{}{"environment": "dev"},
+		"variables":           map[string]interface{}{"verbose": "true"},
+		"secret_variables":    map[string]interface{}{"token": "secret"},
+	})
+}
+
+// verifies that the run parameters are sent and the run is read back
+func TestPipelineRun_Create_RunsPipeline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pipelinesClient := azdosdkmocks.NewMockPipelinesClient(ctrl)
+	clients := &client.AggregatedClient{PipelinesClient: pipelinesClient, Ctx: context.Background()}
+
+	pipelinesClient.
+		EXPECT().
+		RunPipeline(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, args pipelines.RunPipelineArgs) (*pipelines.Run, error) {
+			require.Equal(t, pipelineRunTestProjectID, *args.Project)
+			require.Equal(t, 12, *args.PipelineId)
+			require.Equal(t, map[string]string{"environment": "dev"}, *args.RunParameters.TemplateParameters)
+			require.Equal(t, "refs/heads/main", *(*args.RunParameters.Resources.Repositories)["self"].RefName)
+			variables := *args.RunParameters.Variables
+			require.False(t, *variables["verbose"].IsSecret)
+			require.True(t, *variables["token"].IsSecret)
+			require.Equal(t, "secret", *variables["token"].Value)
+			return &pipelines.Run{Id: converter.Int(7)}, nil
+		}).
+		Times(1)
+	pipelinesClient.
+		EXPECT().
+		GetRun(clients.Ctx, pipelines.GetRunArgs{
+			Project:    &pipelineRunTestProjectID,
+			PipelineId: converter.Int(12),
+			RunId:      converter.Int(7),
+		}).
+		Return(&pipelines.Run{
+			Id:    converter.Int(7),
+			Name:  converter.String("20231017.1"),
+			State: &pipelines.RunStateValues.InProgress,
+		}, nil).
+		Times(1)
+
+	resourceData := newPipelineRunResourceData(t)
+	err := resourcePipelineRunCreate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "7", resourceData.Id())
+	require.Equal(t, "20231017.1", resourceData.Get("name"))
+	require.Equal(t, "inProgress", resourceData.Get("state"))
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestPipelineRun_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pipelinesClient := azdosdkmocks.NewMockPipelinesClient(ctrl)
+	clients := &client.AggregatedClient{PipelinesClient: pipelinesClient, Ctx: context.Background()}
+
+	pipelinesClient.
+		EXPECT().
+		RunPipeline(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("RunPipeline() Failed")).
+		Times(1)
+
+	err := resourcePipelineRunCreate(newPipelineRunResourceData(t), clients)
+	require.Contains(t, err.Error(), "RunPipeline() Failed")
+}
+
+// verifies that only a succeeded run passes
+func TestPipelineRun_CheckPipelineRunSucceeded(t *testing.T) {
+	require.Nil(t, checkPipelineRunSucceeded(&pipelines.Run{Id: converter.Int(7), Result: &pipelines.RunResultValues.Succeeded}, 12))
+
+	err := checkPipelineRunSucceeded(&pipelines.Run{Id: converter.Int(7), Result: &pipelines.RunResultValues.Failed}, 12)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "finished with result failed")
+
+	err = checkPipelineRunSucceeded(&pipelines.Run{Id: converter.Int(7)}, 12)
+	require.Contains(t, err.Error(), "finished with result unknown")
+}
+
+// verifies that a run removed by the retention policies stays in the state so it is not triggered again
+func TestPipelineRun_Read_KeepsDeletedRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pipelinesClient := azdosdkmocks.NewMockPipelinesClient(ctrl)
+	clients := &client.AggregatedClient{PipelinesClient: pipelinesClient, Ctx: context.Background()}
+
+	pipelinesClient.
+		EXPECT().
+		GetRun(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(404)}).
+		Times(1)
+
+	resourceData := newPipelineRunResourceData(t)
+	resourceData.SetId("7")
+	err := resourcePipelineRunRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "7", resourceData.Id())
+}
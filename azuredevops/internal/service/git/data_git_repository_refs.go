@@ -0,0 +1,98 @@
+package git
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+)
+
+// DataGitRepositoryRefs schema and implementation for the git repository refs data source
+func DataGitRepositoryRefs() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGitRepositoryRefsRead,
+		Schema: map[string]*schema.Schema{
+			"repository_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"filter": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"filter_contains": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"refs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"object_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"commit_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGitRepositoryRefsRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	repoId := d.Get("repository_id").(string)
+	filter := strings.TrimPrefix(d.Get("filter").(string), "refs/")
+	filterContains := d.Get("filter_contains").(string)
+
+	args := git.GetRefsArgs{
+		RepositoryId: &repoId,
+		PeelTags:     converter.Bool(true),
+	}
+	if filter != "" {
+		args.Filter = &filter
+	}
+	if filterContains != "" {
+		args.FilterContains = &filterContains
+	}
+
+	refs, err := getAllRefs(clients, args)
+	if err != nil {
+		return fmt.Errorf("Error finding refs of repository %s. Error: %v", repoId, err)
+	}
+	log.Printf("[TRACE] plugin.terraform-provider-azuredevops: Read [%d] Git refs", len(refs))
+
+	d.SetId(fmt.Sprintf("%s:%s:%s", repoId, filter, filterContains))
+	return d.Set("refs", flattenGitRefs(refs))
+}
+
+// flattenGitRefs returns the refs, annotated tags are peeled to the tagged commit
+func flattenGitRefs(refs []git.GitRef) []interface{} {
+	results := make([]interface{}, 0, len(refs))
+	for _, ref := range refs {
+		objectId := converter.ToString(ref.ObjectId, "")
+		results = append(results, map[string]interface{}{
+			"name":      converter.ToString(ref.Name, ""),
+			"object_id": objectId,
+			"commit_id": converter.ToString(ref.PeeledObjectId, objectId),
+		})
+	}
+	return results
+}
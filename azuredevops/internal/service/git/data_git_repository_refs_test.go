@@ -0,0 +1,96 @@
+//go:build (all || git || data_sources || data_git_repository_refs) && (!exclude_data_sources || !exclude_git || !exclude_data_git_repository_refs)
+// +build all git data_sources data_git_repository_refs
+// +build !exclude_data_sources !exclude_git !exclude_data_git_repository_refs
+
+package git
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var refsTestRepositoryID = uuid.New().String()
+
+// verifies that all pages of refs are read and annotated tags are peeled to the tagged commit
+func TestDataSourceGitRepositoryRefs_Read_FollowsContinuationToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: gitClient, Ctx: context.Background()}
+
+	firstPage := gitClient.
+		EXPECT().
+		GetRefs(clients.Ctx, git.GetRefsArgs{
+			RepositoryId: &refsTestRepositoryID,
+			Filter:       converter.String("tags/v1"),
+			PeelTags:     converter.Bool(true),
+		}).
+		Return(&git.GetRefsResponseValue{
+			Value: []git.GitRef{{
+				Name:           converter.String("refs/tags/v1.0.0"),
+				ObjectId:       converter.String("a-tag-object"),
+				PeeledObjectId: converter.String("a-commit"),
+			}},
+			ContinuationToken: "next",
+		}, nil).
+		Times(1)
+	gitClient.
+		EXPECT().
+		GetRefs(clients.Ctx, git.GetRefsArgs{
+			RepositoryId:      &refsTestRepositoryID,
+			Filter:            converter.String("tags/v1"),
+			PeelTags:          converter.Bool(true),
+			ContinuationToken: converter.String("next"),
+		}).
+		Return(&git.GetRefsResponseValue{
+			Value: []git.GitRef{{
+				Name:     converter.String("refs/tags/v1.1.0"),
+				ObjectId: converter.String("another-commit"),
+			}},
+		}, nil).
+		After(firstPage).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, DataGitRepositoryRefs().Schema, map[string]interface{}{
+		"repository_id": refsTestRepositoryID,
+		"filter":        "refs/tags/v1",
+	})
+	err := dataSourceGitRepositoryRefsRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"name": "refs/tags/v1.0.0", "object_id": "a-tag-object", "commit_id": "a-commit"},
+		map[string]interface{}{"name": "refs/tags/v1.1.0", "object_id": "another-commit", "commit_id": "another-commit"},
+	}, resourceData.Get("refs"))
+}
+
+// verifies that an error is not swallowed
+func TestDataSourceGitRepositoryRefs_Read_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: gitClient, Ctx: context.Background()}
+
+	gitClient.
+		EXPECT().
+		GetRefs(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("GetRefs() Failed")).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, DataGitRepositoryRefs().Schema, map[string]interface{}{
+		"repository_id": refsTestRepositoryID,
+	})
+	err := dataSourceGitRepositoryRefsRead(resourceData, clients)
+	require.Contains(t, err.Error(), "GetRefs() Failed")
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/core"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/serviceendpoint"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
@@ -45,6 +46,9 @@ func ResourceGitRepository() *schema.Resource {
 		Update:   resourceGitRepositoryUpdate,
 		Delete:   resourceGitRepositoryDelete,
 		Importer: tfhelper.ImportProjectQualifiedResource(),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Type:             schema.TypeString,
@@ -136,7 +140,26 @@ func ResourceGitRepository() *schema.Resource {
 								"initialization.0.source_url",
 								"initialization.0.source_type",
 							},
-							Default: "",
+							ConflictsWith: []string{"initialization.0.username"},
+							Default:       "",
+						},
+						"username": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							RequiredWith: []string{
+								"initialization.0.source_url",
+								"initialization.0.password",
+							},
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"password": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							Sensitive:    true,
+							RequiredWith: []string{"initialization.0.username"},
+							ValidateFunc: validation.StringIsNotWhiteSpace,
 						},
 					},
 				},
@@ -151,6 +174,8 @@ type repoInitializationMeta struct {
 	sourceType          string
 	sourceURL           string
 	serviceConnectionID string
+	username            string
+	password            string
 }
 
 func resourceGitRepositoryCreate(d *schema.ResourceData, m interface{}) error {
@@ -199,10 +224,26 @@ func resourceGitRepositoryCreate(d *schema.ResourceData, m interface{}) error {
 			importRequest.Parameters.DeleteServiceEndpointAfterImportIsDone = converter.Bool(false)
 		}
 
-		_, importErr := createImportRequest(clients, importRequest, projectID.String(), *createdRepo.Name)
+		// a temporary service connection holding the credentials is created for the import and deleted afterwards
+		if initialization.username != "" {
+			serviceEndpoint, err := createImportServiceEndpoint(clients, projectID, createdRepo, initialization)
+			if err != nil {
+				return fmt.Errorf("Error creating service connection to import repository in Azure DevOps: %+v ", err)
+			}
+			defer deleteImportServiceEndpoint(clients, projectID, serviceEndpoint.Id)
+
+			importRequest.Parameters.ServiceEndpointId = serviceEndpoint.Id
+			importRequest.Parameters.DeleteServiceEndpointAfterImportIsDone = converter.Bool(false)
+		}
+
+		createdImportRequest, importErr := createImportRequest(clients, importRequest, projectID.String(), *createdRepo.Name)
 		if importErr != nil {
 			return fmt.Errorf("Error import repository in Azure DevOps: %+v ", importErr)
 		}
+
+		if err := waitForImportRequest(clients, projectID.String(), createdRepo.Id.String(), createdImportRequest.ImportRequestId, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
 	}
 
 	if initialization != nil && strings.EqualFold(initialization.initType, string(RepoInitTypeValues.Clean)) {
@@ -260,6 +301,97 @@ func createImportRequest(clients *client.AggregatedClient, gitImportRequest git.
 	return clients.GitReposClient.CreateImportRequest(clients.Ctx, args)
 }
 
+// waitForImportRequest polls the import request until the import is completed, a failed or abandoned import is returned as error
+func waitForImportRequest(clients *client.AggregatedClient, projectID string, repositoryID string, importRequestID *int, timeout time.Duration) error {
+	if importRequestID == nil {
+		return fmt.Errorf("Error importing repository [%s]: the import request has no ID", repositoryID)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			string(git.GitAsyncOperationStatusValues.Queued),
+			string(git.GitAsyncOperationStatusValues.InProgress),
+		},
+		Target: []string{
+			string(git.GitAsyncOperationStatusValues.Completed),
+		},
+		Refresh: func() (interface{}, string, error) {
+			importRequest, err := clients.GitReposClient.GetImportRequest(clients.Ctx, git.GetImportRequestArgs{
+				Project:         &projectID,
+				RepositoryId:    &repositoryID,
+				ImportRequestId: importRequestID,
+			})
+			if err != nil {
+				return nil, "", fmt.Errorf("Error reading import request: %+v", err)
+			}
+			if importRequest.Status == nil {
+				return importRequest, string(git.GitAsyncOperationStatusValues.Queued), nil
+			}
+
+			status := *importRequest.Status
+			if status == git.GitAsyncOperationStatusValues.Failed || status == git.GitAsyncOperationStatusValues.Abandoned {
+				errorMessage := ""
+				if importRequest.DetailedStatus != nil {
+					errorMessage = converter.ToString(importRequest.DetailedStatus.ErrorMessage, "")
+				}
+				return nil, "", fmt.Errorf("Import request %d has status %s: %s", *importRequestID, status, errorMessage)
+			}
+			if status != git.GitAsyncOperationStatusValues.Completed {
+				log.Printf("[DEBUG] Waiting for import request %d of repository %s. Status: %s", *importRequestID, repositoryID, status)
+			}
+			return importRequest, string(status), nil
+		},
+		Timeout:                   timeout,
+		MinTimeout:                2 * time.Second,
+		Delay:                     1 * time.Second,
+		ContinuousTargetOccurence: 1,
+	}
+	if _, err := stateConf.WaitForState(); err != nil { //nolint:staticcheck
+		return fmt.Errorf("Error importing repository [%s]: %+v", repositoryID, err)
+	}
+	return nil
+}
+
+func createImportServiceEndpoint(clients *client.AggregatedClient, projectID *uuid.UUID, repo *git.GitRepository, initialization *repoInitializationMeta) (*serviceendpoint.ServiceEndpoint, error) {
+	name := fmt.Sprintf("Import %s %s", *repo.Name, repo.Id.String())
+	return clients.ServiceEndpointClient.CreateServiceEndpoint(clients.Ctx, serviceendpoint.CreateServiceEndpointArgs{
+		Endpoint: &serviceendpoint.ServiceEndpoint{
+			Name:  &name,
+			Owner: converter.String("library"),
+			Type:  converter.String("git"),
+			Url:   &initialization.sourceURL,
+			Authorization: &serviceendpoint.EndpointAuthorization{
+				Parameters: &map[string]string{
+					"username": initialization.username,
+					"password": initialization.password,
+				},
+				Scheme: converter.String("UsernamePassword"),
+			},
+			ServiceEndpointProjectReferences: &[]serviceendpoint.ServiceEndpointProjectReference{
+				{
+					ProjectReference: &serviceendpoint.ProjectReference{
+						Id: projectID,
+					},
+					Name: &name,
+				},
+			},
+		},
+	})
+}
+
+// deleteImportServiceEndpoint deletes the temporary service connection, a failed deletion does not fail the import
+func deleteImportServiceEndpoint(clients *client.AggregatedClient, projectID *uuid.UUID, serviceEndpointID *uuid.UUID) {
+	err := clients.ServiceEndpointClient.DeleteServiceEndpoint(clients.Ctx, serviceendpoint.DeleteServiceEndpointArgs{
+		ProjectIds: &[]string{
+			projectID.String(),
+		},
+		EndpointId: serviceEndpointID,
+	})
+	if err != nil {
+		log.Printf("[WARN] Failed to delete service connection %s used to import the repository: %+v", serviceEndpointID.String(), err)
+	}
+}
+
 func createGitRepository(clients *client.AggregatedClient, repoName *string, projectID *uuid.UUID, parentRepo *git.GitRepositoryRef) (*git.GitRepository, error) {
 	args := git.CreateRepositoryArgs{
 		GitRepositoryToCreate: &git.GitRepositoryCreateOptions{
@@ -459,12 +591,16 @@ func expandGitRepository(d *schema.ResourceData) (*git.GitRepository, *repoIniti
 			sourceType:          initValues["source_type"].(string),
 			sourceURL:           initValues["source_url"].(string),
 			serviceConnectionID: initValues["service_connection_id"].(string),
+			username:            initValues["username"].(string),
+			password:            initValues["password"].(string),
 		}
 
 		if strings.EqualFold(initialization.initType, "clean") {
 			initialization.sourceType = ""
 			initialization.sourceURL = ""
 			initialization.serviceConnectionID = ""
+			initialization.username = ""
+			initialization.password = ""
 		}
 
 		if _, ok := d.GetOk("default_branch"); ok {
@@ -91,38 +91,11 @@ func resourceGitRepositoryBranchCreate(ctx context.Context, d *schema.ResourceDa
 			rs = withPrefix(REF_TAG_PREFIX, v.(string))
 		}
 
-		filter := strings.TrimPrefix(rs, "refs/")
-		gotRefs, err := clients.GitReposClient.GetRefs(clients.Ctx, git.GetRefsArgs{
-			RepositoryId: converter.String(repoId),
-			Filter:       converter.String(filter),
-			Top:          converter.Int(1),
-			PeelTags:     converter.Bool(true),
-		})
+		objectId, err := getRefCommitId(clients, repoId, rs)
 		if err != nil {
-			return diag.FromErr(fmt.Errorf("Error getting refs matching %q: %w", filter, err))
-		}
-
-		if len(gotRefs.Value) == 0 {
-			return diag.FromErr(fmt.Errorf("No refs found that match ref %q.", rs))
-		}
-
-		gotRef := gotRefs.Value[0]
-		if gotRef.Name == nil {
-			return diag.FromErr(fmt.Errorf("Got unexpected GetRefs response, a ref without a name was returned."))
-		}
-
-		// Check for complete match. Sometimes refs exist that match prefix with Ref, but do not match completely.
-		if *gotRef.Name != rs {
-			return diag.FromErr(fmt.Errorf("Ref %q not found, closest match is %q.", filter, *gotRef.Name))
-		}
-
-		if gotRef.PeeledObjectId != nil {
-			newObjectId = *gotRef.PeeledObjectId
-		} else if gotRef.ObjectId != nil {
-			newObjectId = *gotRef.ObjectId
-		} else {
-			return diag.FromErr(fmt.Errorf("GetRefs response doesn't have a valid commit id."))
+			return diag.FromErr(err)
 		}
+		newObjectId = objectId
 	}
 
 	_, err := updateRefs(clients, git.UpdateRefsArgs{
@@ -212,6 +185,41 @@ func resourceGitRepositoryBranchDelete(ctx context.Context, d *schema.ResourceDa
 	return nil
 }
 
+// getRefCommitId returns the commit id a ref points to, annotated tags are peeled to the tagged commit.
+func getRefCommitId(clients *client.AggregatedClient, repoId string, rs string) (string, error) {
+	filter := strings.TrimPrefix(rs, "refs/")
+	gotRefs, err := clients.GitReposClient.GetRefs(clients.Ctx, git.GetRefsArgs{
+		RepositoryId: converter.String(repoId),
+		Filter:       converter.String(filter),
+		Top:          converter.Int(1),
+		PeelTags:     converter.Bool(true),
+	})
+	if err != nil {
+		return "", fmt.Errorf("Error getting refs matching %q: %w", filter, err)
+	}
+
+	if len(gotRefs.Value) == 0 {
+		return "", fmt.Errorf("No refs found that match ref %q.", rs)
+	}
+
+	gotRef := gotRefs.Value[0]
+	if gotRef.Name == nil {
+		return "", fmt.Errorf("Got unexpected GetRefs response, a ref without a name was returned.")
+	}
+
+	// Check for complete match. Sometimes refs exist that match prefix with Ref, but do not match completely.
+	if *gotRef.Name != rs {
+		return "", fmt.Errorf("Ref %q not found, closest match is %q.", filter, *gotRef.Name)
+	}
+
+	if gotRef.PeeledObjectId != nil {
+		return *gotRef.PeeledObjectId, nil
+	} else if gotRef.ObjectId != nil {
+		return *gotRef.ObjectId, nil
+	}
+	return "", fmt.Errorf("GetRefs response doesn't have a valid commit id.")
+}
+
 func updateRefs(clients *client.AggregatedClient, args git.UpdateRefsArgs) (*[]git.GitRefUpdateResult, error) {
 	updateRefResults, err := clients.GitReposClient.UpdateRefs(clients.Ctx, args)
 	if err != nil {
@@ -0,0 +1,322 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+)
+
+// ResourceGitRepositoryFiles schema and implementation for managing multiple files of a git repository with a single push
+func ResourceGitRepositoryFiles() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGitRepositoryFilesCreate,
+		Read:   resourceGitRepositoryFilesRead,
+		Update: resourceGitRepositoryFilesUpdate,
+		Delete: resourceGitRepositoryFilesDelete,
+		Schema: map[string]*schema.Schema{
+			"repository_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The repository ID",
+				ValidateFunc: validation.IsUUID,
+			},
+			"branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The branch name, defaults to \"refs/heads/master\"",
+				Default:     "refs/heads/master",
+			},
+			"files": {
+				Type:        schema.TypeMap,
+				Required:    true,
+				Description: "The files to manage, a map of file path to file content",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				ValidateDiagFunc: validation.MapKeyLenBetween(1, 4096),
+			},
+			"commit_message": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The commit message when creating, updating or deleting the files",
+			},
+			"overwrite_on_create": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Enable overwriting existing files, defaults to \"false\"",
+				Default:     false,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(1 * time.Minute),
+		},
+	}
+}
+
+func resourceGitRepositoryFilesCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	repoId := d.Get("repository_id").(string)
+	branch := d.Get("branch").(string)
+	files := d.Get("files").(map[string]interface{})
+
+	if err := checkRepositoryBranchExists(clients, repoId, branch); err != nil {
+		return err
+	}
+
+	changes := []interface{}{}
+	for _, file := range sortedFilePaths(files) {
+		exists, err := repositoryFileExists(clients, repoId, file, branch)
+		if err != nil {
+			return err
+		}
+
+		// Change type should be edit if overwrite is enabled when file exists
+		changeType := git.VersionControlChangeTypeValues.Add
+		if exists {
+			if !d.Get("overwrite_on_create").(bool) {
+				return fmt.Errorf("Refusing to overwrite existing file %s. Configure `overwrite_on_create` to `true` to override.", file)
+			}
+			changeType = git.VersionControlChangeTypeValues.Edit
+		}
+		changes = append(changes, newGitFileChange(file, files[file].(string), changeType))
+	}
+
+	message := commitMessageOrDefault(d, fmt.Sprintf("Add %d files", len(changes)))
+	if err := pushRepositoryFileChanges(clients, repoId, branch, message, changes, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("Create repository files failed, repositoryID: %s, branch: %s. Error:  %+v", repoId, branch, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", repoId, branch))
+	return resourceGitRepositoryFilesRead(d, m)
+}
+
+func resourceGitRepositoryFilesRead(d *schema.ResourceData, m interface{}) error {
+	ctx := context.Background()
+	clients := m.(*client.AggregatedClient)
+
+	repoId := d.Get("repository_id").(string)
+	branch := d.Get("branch").(string)
+
+	if err := checkRepositoryBranchExists(clients, repoId, branch); err != nil {
+		if utils.ResponseWasNotFound(err) {
+			log.Printf("[INFO] Branch %s of repository %s not found. Removing files from state", branch, repoId)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	// Files which no longer exist are removed from the state, so that they are added again
+	files := map[string]interface{}{}
+	for file := range d.Get("files").(map[string]interface{}) {
+		repoItem, err := clients.GitReposClient.GetItem(ctx, git.GetItemArgs{
+			RepositoryId:   &repoId,
+			Path:           converter.String(file),
+			IncludeContent: converter.Bool(true),
+			VersionDescriptor: &git.GitVersionDescriptor{
+				Version:     converter.String(shortBranchName(branch)),
+				VersionType: &git.GitVersionTypeValues.Branch,
+			},
+		})
+		if err != nil {
+			if utils.ResponseWasNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("Query repository item failed, repositoryID: %s, branch: %s, file: %s . Error:  %+v", repoId, branch, file, err)
+		}
+		files[file] = converter.ToString(repoItem.Content, "")
+	}
+
+	d.Set("files", files)
+	return nil
+}
+
+func resourceGitRepositoryFilesUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	repoId := d.Get("repository_id").(string)
+	branch := d.Get("branch").(string)
+
+	if !d.HasChange("files") {
+		return resourceGitRepositoryFilesRead(d, m)
+	}
+
+	if err := checkRepositoryBranchExists(clients, repoId, branch); err != nil {
+		return err
+	}
+
+	oldFiles, newFiles := d.GetChange("files")
+	changes, err := expandGitFileChanges(clients, repoId, branch, oldFiles.(map[string]interface{}), newFiles.(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
+	if len(changes) > 0 {
+		message := commitMessageOrDefault(d, fmt.Sprintf("Update %d files", len(changes)))
+		if err := pushRepositoryFileChanges(clients, repoId, branch, message, changes, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("Update repository files failed, repositoryID: %s, branch: %s. Error:  %+v", repoId, branch, err)
+		}
+	}
+
+	return resourceGitRepositoryFilesRead(d, m)
+}
+
+func resourceGitRepositoryFilesDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+
+	repoId := d.Get("repository_id").(string)
+	branch := d.Get("branch").(string)
+
+	if err := checkRepositoryBranchExists(clients, repoId, branch); err != nil {
+		if utils.ResponseWasNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	changes, err := expandGitFileChanges(clients, repoId, branch, d.Get("files").(map[string]interface{}), map[string]interface{}{})
+	if err != nil {
+		return err
+	}
+
+	if len(changes) > 0 {
+		message := commitMessageOrDefault(d, fmt.Sprintf("Delete %d files", len(changes)))
+		if err := pushRepositoryFileChanges(clients, repoId, branch, message, changes, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return fmt.Errorf("Failed to destroy the repository files, repository ID: %s, branch: %s. Error %+v ", repoId, branch, err)
+		}
+	}
+	return nil
+}
+
+// expandGitFileChanges returns the changes needed to get from the old files to the new files. Files which no longer
+// exist in the repository are not deleted and files which were deleted outside of Terraform are added again.
+func expandGitFileChanges(clients *client.AggregatedClient, repoId string, branch string, oldFiles map[string]interface{}, newFiles map[string]interface{}) ([]interface{}, error) {
+	allFiles := map[string]interface{}{}
+	for file := range oldFiles {
+		allFiles[file] = nil
+	}
+	for file := range newFiles {
+		allFiles[file] = nil
+	}
+
+	changes := []interface{}{}
+	for _, file := range sortedFilePaths(allFiles) {
+		oldContent, inOld := oldFiles[file]
+		newContent, inNew := newFiles[file]
+		if inOld && inNew && oldContent == newContent {
+			continue
+		}
+
+		exists, err := repositoryFileExists(clients, repoId, file, branch)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case !inNew && exists:
+			changes = append(changes, &git.GitChange{
+				ChangeType: &git.VersionControlChangeTypeValues.Delete,
+				Item: git.GitItem{
+					Path: converter.String(file),
+				},
+			})
+		case inNew && exists:
+			changes = append(changes, newGitFileChange(file, newContent.(string), git.VersionControlChangeTypeValues.Edit))
+		case inNew:
+			changes = append(changes, newGitFileChange(file, newContent.(string), git.VersionControlChangeTypeValues.Add))
+		}
+	}
+	return changes, nil
+}
+
+// pushRepositoryFileChanges commits all changes with a single push. The push is retried if the branch was updated
+// by another client in the meantime.
+func pushRepositoryFileChanges(clients *client.AggregatedClient, repoId string, branch string, message string, changes []interface{}, timeout time.Duration) error {
+	ctx := context.Background()
+	return resource.Retry(timeout, func() *resource.RetryError { //nolint:staticcheck
+		objectID, err := getLastCommitId(clients, repoId, branch)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		_, err = clients.GitReposClient.CreatePush(ctx, git.CreatePushArgs{
+			RepositoryId: &repoId,
+			Push: &git.GitPush{
+				RefUpdates: &[]git.GitRefUpdate{
+					{
+						Name:        &branch,
+						OldObjectId: &objectID,
+					},
+				},
+				Commits: &[]git.GitCommitRef{
+					{
+						Comment: &message,
+						Changes: &changes,
+					},
+				},
+			},
+		})
+		if err != nil {
+			if utils.ResponseContainsStatusMessage(err, "has already been updated by another client") {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+}
+
+// repositoryFileExists tests if a file exists in the given branch of a repository.
+func repositoryFileExists(clients *client.AggregatedClient, repoId string, file string, branch string) (bool, error) {
+	err := checkRepositoryFileExists(clients, repoId, file, branch)
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("Query repository item failed, repositoryID: %s, branch: %s, file: %s . Error:  %+v", repoId, branch, file, err)
+	}
+	return true, nil
+}
+
+func newGitFileChange(file string, content string, changeType git.VersionControlChangeType) *git.GitChange {
+	return &git.GitChange{
+		ChangeType: &changeType,
+		Item: git.GitItem{
+			Path: converter.String(file),
+		},
+		NewContent: &git.ItemContent{
+			Content:     converter.String(content),
+			ContentType: &git.ItemContentTypeValues.RawText,
+		},
+	}
+}
+
+func commitMessageOrDefault(d *schema.ResourceData, defaultMessage string) string {
+	if commitMessage, ok := d.GetOk("commit_message"); ok {
+		return commitMessage.(string)
+	}
+	return defaultMessage
+}
+
+func sortedFilePaths(files map[string]interface{}) []string {
+	paths := make([]string, 0, len(files))
+	for file := range files {
+		paths = append(paths, file)
+	}
+	sort.Strings(paths)
+	return paths
+}
@@ -0,0 +1,182 @@
+//go:build (all || git || resource_git_repository_files) && (!exclude_git || !exclude_resource_git_repository_files)
+// +build all git resource_git_repository_files
+// +build !exclude_git !exclude_resource_git_repository_files
+
+package git
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var filesTestRepositoryID = uuid.New().String()
+
+func newGitRepositoryFilesResourceData(t *testing.T, files map[string]interface{}) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, ResourceGitRepositoryFiles().Schema, map[string]interface{}{
+		"repository_id":  filesTestRepositoryID,
+		"branch":         "refs/heads/main",
+		"files":          files,
+		"commit_message": "Scaffold repository",
+	})
+}
+
+func expectGitItem(gitClient *azdosdkmocks.MockGitClient, ctx context.Context, content map[string]string) {
+	gitClient.
+		EXPECT().
+		GetItem(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, args git.GetItemArgs) (*git.GitItem, error) {
+			if itemContent, ok := content[*args.Path]; ok {
+				return &git.GitItem{Path: args.Path, Content: converter.String(itemContent)}, nil
+			}
+			return nil, azuredevops.WrappedError{StatusCode: converter.Int(404)}
+		}).
+		AnyTimes()
+}
+
+func expectLastCommit(gitClient *azdosdkmocks.MockGitClient, ctx context.Context) {
+	gitClient.
+		EXPECT().
+		GetBranch(ctx, gomock.Any()).
+		Return(&git.GitBranchStats{}, nil).
+		AnyTimes()
+	gitClient.
+		EXPECT().
+		GetCommits(ctx, gomock.Any()).
+		Return(&[]git.GitCommitRef{{CommitId: converter.String("last-commit")}}, nil).
+		AnyTimes()
+}
+
+// verifies that all files are added with a single push
+func TestGitRepositoryFiles_Create_PushesAllFilesOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: gitClient, Ctx: context.Background()}
+
+	repoContent := map[string]string{}
+	expectLastCommit(gitClient, context.Background())
+	expectGitItem(gitClient, context.Background(), repoContent)
+	gitClient.
+		EXPECT().
+		CreatePush(context.Background(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, args git.CreatePushArgs) (*git.GitPush, error) {
+			require.Equal(t, "refs/heads/main", *(*args.Push.RefUpdates)[0].Name)
+			require.Equal(t, "last-commit", *(*args.Push.RefUpdates)[0].OldObjectId)
+			require.Len(t, *args.Push.Commits, 1)
+			commit := (*args.Push.Commits)[0]
+			require.Equal(t, "Scaffold repository", *commit.Comment)
+			require.Len(t, *commit.Changes, 2)
+			for _, change := range *commit.Changes {
+				gitChange := change.(*git.GitChange)
+				require.Equal(t, git.VersionControlChangeTypeValues.Add, *gitChange.ChangeType)
+				repoContent[*gitChange.Item.(git.GitItem).Path] = *gitChange.NewContent.Content
+			}
+			return &git.GitPush{}, nil
+		}).
+		Times(1)
+
+	resourceData := newGitRepositoryFilesResourceData(t, map[string]interface{}{
+		"README.md":  "# Readme",
+		"src/app.go": "package main",
+	})
+	err := resourceGitRepositoryFilesCreate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, filesTestRepositoryID+"/refs/heads/main", resourceData.Id())
+	require.Equal(t, map[string]interface{}{
+		"README.md":  "# Readme",
+		"src/app.go": "package main",
+	}, resourceData.Get("files"))
+}
+
+// verifies that existing files are not overwritten unless overwrite_on_create is set
+func TestGitRepositoryFiles_Create_RefusesToOverwrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: gitClient, Ctx: context.Background()}
+
+	expectLastCommit(gitClient, context.Background())
+	expectGitItem(gitClient, context.Background(), map[string]string{"README.md": "existing"})
+
+	err := resourceGitRepositoryFilesCreate(newGitRepositoryFilesResourceData(t, map[string]interface{}{"README.md": "# Readme"}), clients)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "Refusing to overwrite existing file README.md")
+}
+
+// verifies that changed files are edited, removed files are deleted, missing files are added again
+// and unchanged files are left alone
+func TestGitRepositoryFiles_ExpandGitFileChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: gitClient, Ctx: context.Background()}
+
+	expectGitItem(gitClient, context.Background(), map[string]string{
+		"changed.txt":   "old",
+		"removed.txt":   "removed",
+		"unchanged.txt": "same",
+	})
+
+	oldFiles := map[string]interface{}{
+		"changed.txt":   "old",
+		"removed.txt":   "removed",
+		"unchanged.txt": "same",
+		"deleted.txt":   "deleted outside of Terraform",
+	}
+	newFiles := map[string]interface{}{
+		"added.txt":     "added",
+		"changed.txt":   "new",
+		"unchanged.txt": "same",
+	}
+	changes, err := expandGitFileChanges(clients, filesTestRepositoryID, "refs/heads/main", oldFiles, newFiles)
+	require.Nil(t, err)
+	require.Len(t, changes, 3)
+
+	expected := []struct {
+		path       string
+		changeType git.VersionControlChangeType
+	}{
+		{"added.txt", git.VersionControlChangeTypeValues.Add},
+		{"changed.txt", git.VersionControlChangeTypeValues.Edit},
+		{"removed.txt", git.VersionControlChangeTypeValues.Delete},
+	}
+	for i, change := range changes {
+		gitChange := change.(*git.GitChange)
+		require.Equal(t, expected[i].path, *gitChange.Item.(git.GitItem).Path)
+		require.Equal(t, expected[i].changeType, *gitChange.ChangeType)
+	}
+}
+
+// verifies that files which no longer exist are removed from the state and that changed content is read back
+func TestGitRepositoryFiles_Read_DetectsDrift(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: gitClient, Ctx: context.Background()}
+
+	expectLastCommit(gitClient, context.Background())
+	expectGitItem(gitClient, context.Background(), map[string]string{"README.md": "changed outside of Terraform"})
+
+	resourceData := newGitRepositoryFilesResourceData(t, map[string]interface{}{
+		"README.md":  "# Readme",
+		"src/app.go": "package main",
+	})
+	resourceData.SetId(filesTestRepositoryID + "/refs/heads/main")
+	err := resourceGitRepositoryFilesRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{"README.md": "changed outside of Terraform"}, resourceData.Get("files"))
+}
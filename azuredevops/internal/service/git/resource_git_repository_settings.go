@@ -0,0 +1,370 @@
+package git
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/policy"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/security"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	securityhelper "github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/permissions/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/gitextras"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+// ResourceGitRepositorySettings schema and implementation for the settings of an existing git repository
+func ResourceGitRepositorySettings() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceGitRepositorySettingsCreate,
+		Read:     resourceGitRepositorySettingsRead,
+		Update:   resourceGitRepositorySettingsUpdate,
+		Delete:   resourceGitRepositorySettingsDelete,
+		Importer: tfhelper.ImportProjectQualifiedResource(),
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"repository_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"disabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"inherit_permissions": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"forks_allowed": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"create_pull_request_suggestion": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"commit_mention_linking": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"commit_mention_work_item_resolution": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// gitRepositorySettingsPolicyType is the policy type storing the settings of the repositories in its scope
+var gitRepositorySettingsPolicyType = uuid.MustParse("0517f88d-4ec5-4343-9d26-9930ebd53069")
+
+// gitRepositorySettingsPolicyKeys maps the attributes backed by the repository settings policy to their settings
+var gitRepositorySettingsPolicyKeys = map[string]string{
+	"forks_allowed":                       "forksAllowed",
+	"create_pull_request_suggestion":      "createPullRequestSuggestion",
+	"commit_mention_linking":              "commitMentionLinking",
+	"commit_mention_work_item_resolution": "commitMentionWorkItemResolution",
+}
+
+func resourceGitRepositorySettingsCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	repositoryID := d.Get("repository_id").(string)
+
+	if err := updateGitRepositoryDisabled(clients, projectID, repositoryID, d.Get("disabled").(bool)); err != nil {
+		return fmt.Errorf(" updating the disabled state of git repository %s: %+v", repositoryID, err)
+	}
+	if err := updateGitRepositoryInheritPermissions(clients, projectID, repositoryID, d.Get("inherit_permissions").(bool)); err != nil {
+		return err
+	}
+	if err := updateGitRepositorySettingsPolicy(clients, d, projectID, repositoryID, true); err != nil {
+		return err
+	}
+
+	d.SetId(repositoryID)
+	return resourceGitRepositorySettingsRead(d, m)
+}
+
+func resourceGitRepositorySettingsRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	repositoryID := d.Id()
+
+	repository, err := clients.GitClientExtras.GetRepository(clients.Ctx, gitextras.GetRepositoryArgs{
+		Project:      &projectID,
+		RepositoryId: &repositoryID,
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			log.Printf("[INFO] Git repository %s not found. Removing settings from state", repositoryID)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf(" reading git repository %s: %+v", repositoryID, err)
+	}
+
+	inheritPermissions, err := getGitRepositoryInheritPermissions(clients, projectID, repositoryID)
+	if err != nil {
+		return err
+	}
+
+	settingsPolicy, err := getGitRepositorySettingsPolicy(clients, projectID, repositoryID)
+	if err != nil {
+		return err
+	}
+
+	d.Set("repository_id", repositoryID)
+	d.Set("disabled", converter.ToBool(repository.IsDisabled, false))
+	d.Set("inherit_permissions", inheritPermissions)
+	if settingsPolicy != nil {
+		settings, _ := settingsPolicy.Settings.(map[string]interface{})
+		for attribute, key := range gitRepositorySettingsPolicyKeys {
+			if value, ok := settings[key].(bool); ok {
+				d.Set(attribute, value)
+			}
+		}
+	}
+	return nil
+}
+
+func resourceGitRepositorySettingsUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	repositoryID := d.Id()
+
+	if d.HasChange("disabled") {
+		if err := updateGitRepositoryDisabled(clients, projectID, repositoryID, d.Get("disabled").(bool)); err != nil {
+			return fmt.Errorf(" updating the disabled state of git repository %s: %+v", repositoryID, err)
+		}
+	}
+	if d.HasChange("inherit_permissions") {
+		if err := updateGitRepositoryInheritPermissions(clients, projectID, repositoryID, d.Get("inherit_permissions").(bool)); err != nil {
+			return err
+		}
+	}
+	if d.HasChanges("forks_allowed", "create_pull_request_suggestion", "commit_mention_linking", "commit_mention_work_item_resolution") {
+		if err := updateGitRepositorySettingsPolicy(clients, d, projectID, repositoryID, false); err != nil {
+			return err
+		}
+	}
+
+	return resourceGitRepositorySettingsRead(d, m)
+}
+
+// resourceGitRepositorySettingsDelete restores the defaults of the repository, i.e. the repository is enabled,
+// inherits the permissions from the project and uses the repository settings of the project
+func resourceGitRepositorySettingsDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get("project_id").(string)
+	repositoryID := d.Id()
+
+	if err := updateGitRepositoryDisabled(clients, projectID, repositoryID, false); err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf(" enabling git repository %s: %+v", repositoryID, err)
+	}
+	if err := updateGitRepositoryInheritPermissions(clients, projectID, repositoryID, true); err != nil {
+		return err
+	}
+
+	settingsPolicy, err := getGitRepositorySettingsPolicy(clients, projectID, repositoryID)
+	if err != nil {
+		return err
+	}
+	if settingsPolicy != nil {
+		err := clients.PolicyClient.DeletePolicyConfiguration(clients.Ctx, policy.DeletePolicyConfigurationArgs{
+			ConfigurationId: settingsPolicy.Id,
+			Project:         &projectID,
+		})
+		if err != nil {
+			return fmt.Errorf(" deleting the settings policy of git repository %s: %+v", repositoryID, err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func updateGitRepositoryDisabled(clients *client.AggregatedClient, projectID string, repositoryID string, disabled bool) error {
+	repositoryUUID, err := uuid.Parse(repositoryID)
+	if err != nil {
+		return fmt.Errorf("parsing repository ID %s: %+v", repositoryID, err)
+	}
+
+	_, err = clients.GitClientExtras.UpdateRepositoryDisabled(clients.Ctx, gitextras.UpdateRepositoryDisabledArgs{
+		IsDisabled:   &disabled,
+		RepositoryId: &repositoryUUID,
+		Project:      &projectID,
+	})
+	return err
+}
+
+func getGitRepositoryInheritPermissions(clients *client.AggregatedClient, projectID string, repositoryID string) (bool, error) {
+	acl, err := getGitRepositoryAccessControlList(clients, projectID, repositoryID)
+	if err != nil {
+		return false, err
+	}
+	if acl == nil {
+		return true, nil
+	}
+	return converter.ToBool(acl.InheritPermissions, true), nil
+}
+
+// updateGitRepositoryInheritPermissions sets the inheritance flag of the repository ACL, the existing entries are kept
+func updateGitRepositoryInheritPermissions(clients *client.AggregatedClient, projectID string, repositoryID string, inheritPermissions bool) error {
+	acl, err := getGitRepositoryAccessControlList(clients, projectID, repositoryID)
+	if err != nil {
+		return err
+	}
+	if acl == nil {
+		acl = &security.AccessControlList{
+			Token:          converter.String(gitRepositoryAclToken(projectID, repositoryID)),
+			AcesDictionary: &map[string]security.AccessControlEntry{},
+		}
+	}
+	acl.InheritPermissions = &inheritPermissions
+
+	namespaceID := uuid.UUID(securityhelper.SecurityNamespaceIDValues.GitRepositories)
+	err = clients.SecurityClient.SetAccessControlLists(clients.Ctx, security.SetAccessControlListsArgs{
+		SecurityNamespaceId: &namespaceID,
+		AccessControlLists: &azuredevops.VssJsonCollectionWrapper{
+			Count: converter.Int(1),
+			Value: &[]interface{}{acl},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf(" updating the permission inheritance of git repository %s: %+v", repositoryID, err)
+	}
+	return nil
+}
+
+func getGitRepositoryAccessControlList(clients *client.AggregatedClient, projectID string, repositoryID string) (*security.AccessControlList, error) {
+	namespaceID := uuid.UUID(securityhelper.SecurityNamespaceIDValues.GitRepositories)
+	acls, err := clients.SecurityClient.QueryAccessControlLists(clients.Ctx, security.QueryAccessControlListsArgs{
+		SecurityNamespaceId: &namespaceID,
+		Token:               converter.String(gitRepositoryAclToken(projectID, repositoryID)),
+		IncludeExtendedInfo: converter.Bool(false),
+	})
+	if err != nil {
+		return nil, fmt.Errorf(" reading the permissions of git repository %s: %+v", repositoryID, err)
+	}
+	if acls == nil || len(*acls) == 0 {
+		return nil, nil
+	}
+	return &(*acls)[0], nil
+}
+
+// getGitRepositorySettingsPolicy returns the repository settings policy scoped to the repository, or nil if the
+// repository uses the settings of the project
+func getGitRepositorySettingsPolicy(clients *client.AggregatedClient, projectID string, repositoryID string) (*policy.PolicyConfiguration, error) {
+	policies, err := clients.PolicyClient.GetPolicyConfigurations(clients.Ctx, policy.GetPolicyConfigurationsArgs{
+		Project:    &projectID,
+		PolicyType: &gitRepositorySettingsPolicyType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf(" reading the settings policy of git repository %s: %+v", repositoryID, err)
+	}
+	if policies == nil {
+		return nil, nil
+	}
+
+	for _, settingsPolicy := range policies.Value {
+		if converter.ToBool(settingsPolicy.IsDeleted, false) {
+			continue
+		}
+		settings, _ := settingsPolicy.Settings.(map[string]interface{})
+		scopes, _ := settings["scope"].([]interface{})
+		for _, scope := range scopes {
+			scopeMap, _ := scope.(map[string]interface{})
+			if scopeRepositoryID, ok := scopeMap["repositoryId"].(string); ok && strings.EqualFold(scopeRepositoryID, repositoryID) {
+				return &settingsPolicy, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// updateGitRepositorySettingsPolicy writes the configured repository settings to the settings policy of the
+// repository, which is created if needed. Settings which are not configured or not changed are left untouched.
+func updateGitRepositorySettingsPolicy(clients *client.AggregatedClient, d *schema.ResourceData, projectID string, repositoryID string, create bool) error {
+	configured := map[string]interface{}{}
+	for attribute, key := range gitRepositorySettingsPolicyKeys {
+		if create {
+			// the value of an attribute which is not configured is computed and does not exist yet
+			if value, ok := d.GetOkExists(attribute); ok { //nolint:staticcheck
+				configured[key] = value.(bool)
+			}
+		} else if d.HasChange(attribute) {
+			configured[key] = d.Get(attribute).(bool)
+		}
+	}
+	if len(configured) == 0 {
+		return nil
+	}
+
+	settingsPolicy, err := getGitRepositorySettingsPolicy(clients, projectID, repositoryID)
+	if err != nil {
+		return err
+	}
+
+	if settingsPolicy == nil {
+		settings := map[string]interface{}{
+			"scope": []interface{}{map[string]interface{}{"repositoryId": repositoryID}},
+		}
+		for key, value := range configured {
+			settings[key] = value
+		}
+		_, err = clients.PolicyClient.CreatePolicyConfiguration(clients.Ctx, policy.CreatePolicyConfigurationArgs{
+			Configuration: &policy.PolicyConfiguration{
+				IsEnabled:  converter.Bool(true),
+				IsBlocking: converter.Bool(false),
+				Type:       &policy.PolicyTypeRef{Id: &gitRepositorySettingsPolicyType},
+				Settings:   settings,
+			},
+			Project: &projectID,
+		})
+	} else {
+		settings, _ := settingsPolicy.Settings.(map[string]interface{})
+		if settings == nil {
+			settings = map[string]interface{}{}
+		}
+		for key, value := range configured {
+			settings[key] = value
+		}
+		settingsPolicy.Settings = settings
+		_, err = clients.PolicyClient.UpdatePolicyConfiguration(clients.Ctx, policy.UpdatePolicyConfigurationArgs{
+			Configuration:   settingsPolicy,
+			ConfigurationId: settingsPolicy.Id,
+			Project:         &projectID,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf(" updating the settings policy of git repository %s: %+v", repositoryID, err)
+	}
+	return nil
+}
+
+func gitRepositoryAclToken(projectID string, repositoryID string) string {
+	return fmt.Sprintf("repoV2/%s/%s", projectID, repositoryID)
+}
@@ -0,0 +1,286 @@
+//go:build (all || git || resource_git_repository_settings) && (!exclude_git || !exclude_resource_git_repository_settings)
+// +build all git resource_git_repository_settings
+// +build !exclude_git !exclude_resource_git_repository_settings
+
+package git
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/policy"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/security"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/gitextras"
+	mock_gitextras "github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/gitextras/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+var settingsTestProjectID = uuid.New().String()
+var settingsTestRepositoryID = uuid.New()
+
+func newGitRepositorySettingsResourceData(t *testing.T, disabled bool, inheritPermissions bool) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, ResourceGitRepositorySettings().Schema, map[string]interface{}{
+		"project_id":          settingsTestProjectID,
+		"repository_id":       settingsTestRepositoryID.String(),
+		"disabled":            disabled,
+		"inherit_permissions": inheritPermissions,
+	})
+}
+
+// expectGitRepositorySettingsPolicies returns the given repository settings policies of the test project
+func expectGitRepositorySettingsPolicies(policyClient *azdosdkmocks.MockPolicyClient, ctx context.Context, policies ...policy.PolicyConfiguration) *gomock.Call {
+	return policyClient.
+		EXPECT().
+		GetPolicyConfigurations(ctx, policy.GetPolicyConfigurationsArgs{
+			Project:    &settingsTestProjectID,
+			PolicyType: &gitRepositorySettingsPolicyType,
+		}).
+		Return(&policy.GetPolicyConfigurationsResponseValue{Value: policies}, nil).
+		AnyTimes()
+}
+
+func newGitRepositorySettingsPolicy(id int, repositoryID string, settings map[string]interface{}) policy.PolicyConfiguration {
+	settings["scope"] = []interface{}{map[string]interface{}{"repositoryId": repositoryID}}
+	return policy.PolicyConfiguration{
+		Id:        converter.Int(id),
+		IsDeleted: converter.Bool(false),
+		Type:      &policy.PolicyTypeRef{Id: &gitRepositorySettingsPolicyType},
+		Settings:  settings,
+	}
+}
+
+// verifies that the repository is disabled and the inheritance flag is set on the existing ACL on create
+func TestGitRepositorySettings_Create_UpdatesRepositoryAndAcl(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClientExtras := mock_gitextras.NewGitClientExtras(ctrl)
+	securityClient := azdosdkmocks.NewMockSecurityClient(ctrl)
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &client.AggregatedClient{
+		GitClientExtras: gitClientExtras,
+		SecurityClient:  securityClient,
+		PolicyClient:    policyClient,
+		Ctx:             context.Background(),
+	}
+	expectGitRepositorySettingsPolicies(policyClient, clients.Ctx)
+
+	token := "repoV2/" + settingsTestProjectID + "/" + settingsTestRepositoryID.String()
+	existingAces := map[string]security.AccessControlEntry{
+		"descriptor": {Descriptor: converter.String("descriptor"), Allow: converter.Int(2)},
+	}
+
+	gitClientExtras.
+		EXPECT().
+		UpdateRepositoryDisabled(clients.Ctx, gitextras.UpdateRepositoryDisabledArgs{
+			IsDisabled:   converter.Bool(true),
+			RepositoryId: &settingsTestRepositoryID,
+			Project:      &settingsTestProjectID,
+		}).
+		Return(&gitextras.GitRepository{IsDisabled: converter.Bool(true)}, nil).
+		Times(1)
+	gitClientExtras.
+		EXPECT().
+		GetRepository(clients.Ctx, gomock.Any()).
+		Return(&gitextras.GitRepository{IsDisabled: converter.Bool(true)}, nil).
+		Times(1)
+	securityClient.
+		EXPECT().
+		QueryAccessControlLists(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, args security.QueryAccessControlListsArgs) (*[]security.AccessControlList, error) {
+			require.Equal(t, token, *args.Token)
+			return &[]security.AccessControlList{{
+				Token:              &token,
+				InheritPermissions: converter.Bool(false),
+				AcesDictionary:     &existingAces,
+			}}, nil
+		}).
+		Times(2)
+	securityClient.
+		EXPECT().
+		SetAccessControlLists(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, args security.SetAccessControlListsArgs) error {
+			require.Equal(t, 1, *args.AccessControlLists.Count)
+			acl := (*args.AccessControlLists.Value)[0].(*security.AccessControlList)
+			require.False(t, *acl.InheritPermissions)
+			require.Equal(t, existingAces, *acl.AcesDictionary)
+			return nil
+		}).
+		Times(1)
+
+	resourceData := newGitRepositorySettingsResourceData(t, true, false)
+	err := resourceGitRepositorySettingsCreate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, settingsTestRepositoryID.String(), resourceData.Id())
+	require.True(t, resourceData.Get("disabled").(bool))
+	require.False(t, resourceData.Get("inherit_permissions").(bool))
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestGitRepositorySettings_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClientExtras := mock_gitextras.NewGitClientExtras(ctrl)
+	clients := &client.AggregatedClient{GitClientExtras: gitClientExtras, Ctx: context.Background()}
+
+	gitClientExtras.
+		EXPECT().
+		UpdateRepositoryDisabled(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("UpdateRepositoryDisabled() Failed")).
+		Times(1)
+
+	err := resourceGitRepositorySettingsCreate(newGitRepositorySettingsResourceData(t, true, true), clients)
+	require.Contains(t, err.Error(), "UpdateRepositoryDisabled() Failed")
+}
+
+// verifies that a repository without an explicit ACL is read as inheriting its permissions
+func TestGitRepositorySettings_Read_DefaultsToInheritedPermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClientExtras := mock_gitextras.NewGitClientExtras(ctrl)
+	securityClient := azdosdkmocks.NewMockSecurityClient(ctrl)
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &client.AggregatedClient{
+		GitClientExtras: gitClientExtras,
+		SecurityClient:  securityClient,
+		PolicyClient:    policyClient,
+		Ctx:             context.Background(),
+	}
+	expectGitRepositorySettingsPolicies(policyClient, clients.Ctx)
+
+	gitClientExtras.
+		EXPECT().
+		GetRepository(clients.Ctx, gomock.Any()).
+		Return(&gitextras.GitRepository{}, nil).
+		Times(1)
+	securityClient.
+		EXPECT().
+		QueryAccessControlLists(clients.Ctx, gomock.Any()).
+		Return(&[]security.AccessControlList{}, nil).
+		Times(1)
+
+	resourceData := newGitRepositorySettingsResourceData(t, true, false)
+	resourceData.SetId(settingsTestRepositoryID.String())
+	err := resourceGitRepositorySettingsRead(resourceData, clients)
+	require.Nil(t, err)
+	require.False(t, resourceData.Get("disabled").(bool))
+	require.True(t, resourceData.Get("inherit_permissions").(bool))
+}
+
+// verifies that the resource is removed from the state if the repository no longer exists
+func TestGitRepositorySettings_Read_RemovesDeletedRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClientExtras := mock_gitextras.NewGitClientExtras(ctrl)
+	clients := &client.AggregatedClient{GitClientExtras: gitClientExtras, Ctx: context.Background()}
+
+	gitClientExtras.
+		EXPECT().
+		GetRepository(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(404)}).
+		Times(1)
+
+	resourceData := newGitRepositorySettingsResourceData(t, false, true)
+	resourceData.SetId(settingsTestRepositoryID.String())
+	err := resourceGitRepositorySettingsRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that the configured repository settings are written to a new settings policy scoped to the repository
+func TestGitRepositorySettings_Create_CreatesSettingsPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClientExtras := mock_gitextras.NewGitClientExtras(ctrl)
+	securityClient := azdosdkmocks.NewMockSecurityClient(ctrl)
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &client.AggregatedClient{
+		GitClientExtras: gitClientExtras,
+		SecurityClient:  securityClient,
+		PolicyClient:    policyClient,
+		Ctx:             context.Background(),
+	}
+
+	gitClientExtras.EXPECT().UpdateRepositoryDisabled(clients.Ctx, gomock.Any()).Return(&gitextras.GitRepository{}, nil).Times(1)
+	gitClientExtras.EXPECT().GetRepository(clients.Ctx, gomock.Any()).Return(&gitextras.GitRepository{}, nil).Times(1)
+	securityClient.EXPECT().QueryAccessControlLists(clients.Ctx, gomock.Any()).Return(&[]security.AccessControlList{}, nil).Times(2)
+	securityClient.EXPECT().SetAccessControlLists(clients.Ctx, gomock.Any()).Return(nil).Times(1)
+
+	// another repository of the project has its own settings
+	otherPolicy := newGitRepositorySettingsPolicy(1, uuid.New().String(), map[string]interface{}{"forksAllowed": true})
+	getPolicies := expectGitRepositorySettingsPolicies(policyClient, clients.Ctx, otherPolicy).Times(1)
+	createdPolicy := newGitRepositorySettingsPolicy(2, settingsTestRepositoryID.String(), map[string]interface{}{
+		"forksAllowed":         false,
+		"commitMentionLinking": true,
+	})
+	create := policyClient.
+		EXPECT().
+		CreatePolicyConfiguration(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, args policy.CreatePolicyConfigurationArgs) (*policy.PolicyConfiguration, error) {
+			require.Equal(t, settingsTestProjectID, *args.Project)
+			require.Equal(t, gitRepositorySettingsPolicyType, *args.Configuration.Type.Id)
+			require.Equal(t, createdPolicy.Settings, args.Configuration.Settings)
+			return &createdPolicy, nil
+		}).
+		After(getPolicies).
+		Times(1)
+	expectGitRepositorySettingsPolicies(policyClient, clients.Ctx, otherPolicy, createdPolicy).After(create)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceGitRepositorySettings().Schema, map[string]interface{}{
+		"project_id":             settingsTestProjectID,
+		"repository_id":          settingsTestRepositoryID.String(),
+		"forks_allowed":          false,
+		"commit_mention_linking": true,
+	})
+	err := resourceGitRepositorySettingsCreate(resourceData, clients)
+	require.Nil(t, err)
+	require.False(t, resourceData.Get("forks_allowed").(bool))
+	require.True(t, resourceData.Get("commit_mention_linking").(bool))
+}
+
+// verifies that changed settings are merged into the existing settings policy of the repository
+func TestGitRepositorySettings_Update_UpdatesSettingsPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policyClient := azdosdkmocks.NewMockPolicyClient(ctrl)
+	clients := &client.AggregatedClient{PolicyClient: policyClient, Ctx: context.Background()}
+
+	existingPolicy := newGitRepositorySettingsPolicy(2, settingsTestRepositoryID.String(), map[string]interface{}{
+		"forksAllowed":         false,
+		"commitMentionLinking": true,
+	})
+	expectGitRepositorySettingsPolicies(policyClient, clients.Ctx, existingPolicy)
+	policyClient.
+		EXPECT().
+		UpdatePolicyConfiguration(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, args policy.UpdatePolicyConfigurationArgs) (*policy.PolicyConfiguration, error) {
+			require.Equal(t, 2, *args.ConfigurationId)
+			settings := args.Configuration.Settings.(map[string]interface{})
+			require.Equal(t, true, settings["forksAllowed"])
+			require.Equal(t, true, settings["commitMentionLinking"])
+			require.NotNil(t, settings["scope"])
+			return args.Configuration, nil
+		}).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceGitRepositorySettings().Schema, map[string]interface{}{
+		"project_id":    settingsTestProjectID,
+		"repository_id": settingsTestRepositoryID.String(),
+		"forks_allowed": true,
+	})
+	err := updateGitRepositorySettingsPolicy(clients, resourceData, settingsTestProjectID, settingsTestRepositoryID.String(), false)
+	require.Nil(t, err)
+}
@@ -0,0 +1,320 @@
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+// ResourceGitRepositoryTag schema to manage the lifecycle of a git repository tag
+func ResourceGitRepositoryTag() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGitRepositoryTagCreate,
+		ReadContext:   resourceGitRepositoryTagRead,
+		DeleteContext: resourceGitRepositoryTagDelete,
+		CustomizeDiff: customizeGitRepositoryTagDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"repository_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"ref_branch": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{"ref_branch", "ref_tag", "ref_commit_id"},
+			},
+			"ref_tag": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{"ref_branch", "ref_tag", "ref_commit_id"},
+			},
+			"ref_commit_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{"ref_branch", "ref_tag", "ref_commit_id"},
+			},
+			"message": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validation.StringIsNotWhiteSpace,
+				ConflictsWith: []string{"lightweight"},
+			},
+			"lightweight": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"object_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"commit_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceGitRepositoryTagCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clients := m.(*client.AggregatedClient)
+	repoId := d.Get("repository_id").(string)
+
+	name := d.Get("name").(string)
+	shortTagName := withoutPrefix(REF_TAG_PREFIX, name)
+	longTagName := withPrefix(REF_TAG_PREFIX, name)
+	if name != shortTagName {
+		return diag.Errorf("Tag name must be in short format without refs/tags/ prefix, got: %q", name)
+	}
+
+	lightweight := d.Get("lightweight").(bool)
+	message := d.Get("message").(string)
+
+	var commitId string
+	if v, ok := d.GetOk("ref_commit_id"); ok {
+		commitId = v.(string)
+	} else {
+		var rs string
+		if v, ok := d.GetOk("ref_branch"); ok {
+			rs = withPrefix(REF_BRANCH_PREFIX, v.(string))
+		}
+		if v, ok := d.GetOk("ref_tag"); ok {
+			rs = withPrefix(REF_TAG_PREFIX, v.(string))
+		}
+
+		objectId, err := getRefCommitId(clients, repoId, rs)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		commitId = objectId
+	}
+
+	if lightweight {
+		_, err := updateRefs(clients, git.UpdateRefsArgs{
+			RefUpdates: &[]git.GitRefUpdate{{
+				Name:        &longTagName,
+				NewObjectId: &commitId,
+				OldObjectId: converter.String("0000000000000000000000000000000000000000"),
+			}},
+			RepositoryId: converter.String(repoId),
+		})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("Error creating tag %q: %w", shortTagName, err))
+		}
+	} else {
+		projectId, err := getRepositoryProjectId(clients, repoId)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = clients.GitReposClient.CreateAnnotatedTag(clients.Ctx, git.CreateAnnotatedTagArgs{
+			TagObject: &git.GitAnnotatedTag{
+				Name:    &shortTagName,
+				Message: &message,
+				TaggedObject: &git.GitObject{
+					ObjectId: &commitId,
+				},
+			},
+			Project:      &projectId,
+			RepositoryId: converter.String(repoId),
+		})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("Error creating annotated tag %q: %w", shortTagName, err))
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", repoId, shortTagName))
+
+	return resourceGitRepositoryTagRead(ctx, d, m)
+}
+
+func resourceGitRepositoryTagRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clients := m.(*client.AggregatedClient)
+
+	repoId, name, err := tfhelper.ParseGitRepoBranchID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	gotRef, err := getTagRef(clients, repoId, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("Error reading tag %q: %w", name, err))
+	}
+	if gotRef == nil {
+		d.SetId("")
+		return nil
+	}
+
+	// only annotated tags are peeled to the tagged commit
+	lightweight := gotRef.PeeledObjectId == nil
+	d.Set("name", name)
+	d.Set("repository_id", repoId)
+	d.Set("object_id", gotRef.ObjectId)
+	d.Set("lightweight", lightweight)
+
+	commitId := gotRef.ObjectId
+	if !lightweight {
+		commitId = gotRef.PeeledObjectId
+	}
+	d.Set("commit_id", commitId)
+
+	// the ref of an imported tag is unknown, the tagged commit is used to avoid replacing the tag
+	if !hasTagRef(d) {
+		d.Set("ref_commit_id", commitId)
+	}
+
+	if lightweight {
+		d.Set("message", "")
+		return nil
+	}
+
+	projectId, err := getRepositoryProjectId(clients, repoId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	annotatedTag, err := clients.GitReposClient.GetAnnotatedTag(clients.Ctx, git.GetAnnotatedTagArgs{
+		Project:      &projectId,
+		RepositoryId: converter.String(repoId),
+		ObjectId:     gotRef.ObjectId,
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("Error reading annotated tag %q: %w", name, err))
+	}
+
+	d.Set("message", annotatedTag.Message)
+	return nil
+}
+
+func customizeGitRepositoryTagDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("message") || !d.NewValueKnown("lightweight") {
+		return nil
+	}
+	if !d.Get("lightweight").(bool) && d.Get("message").(string) == "" {
+		return fmt.Errorf("A message is required for the annotated tag %q, set `lightweight` to `true` to create a tag without a message", d.Get("name").(string))
+	}
+	return nil
+}
+
+func hasTagRef(d *schema.ResourceData) bool {
+	for _, key := range []string{"ref_branch", "ref_tag", "ref_commit_id"} {
+		if _, ok := d.GetOk(key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func resourceGitRepositoryTagDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	clients := m.(*client.AggregatedClient)
+
+	repoId, name, err := tfhelper.ParseGitRepoBranchID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	gotRef, err := getTagRef(clients, repoId, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("Error reading tag %q: %w", name, err))
+	}
+	if gotRef == nil {
+		return nil
+	}
+
+	_, err = updateRefs(clients, git.UpdateRefsArgs{
+		RefUpdates: &[]git.GitRefUpdate{{
+			Name:        converter.String(withPrefix(REF_TAG_PREFIX, name)),
+			OldObjectId: gotRef.ObjectId,
+			NewObjectId: converter.String("0000000000000000000000000000000000000000"),
+		}},
+		RepositoryId: converter.String(repoId),
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("Error deleting tag %q: %w", name, err))
+	}
+
+	return nil
+}
+
+// getTagRef returns the ref of the tag, nil is returned if the tag does not exist.
+func getTagRef(clients *client.AggregatedClient, repoId string, name string) (*git.GitRef, error) {
+	longTagName := withPrefix(REF_TAG_PREFIX, name)
+	gotRefs, err := getAllRefs(clients, git.GetRefsArgs{
+		RepositoryId: converter.String(repoId),
+		Filter:       converter.String(withoutPrefix("refs/", longTagName)),
+		PeelTags:     converter.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the filter matches all tags starting with the name
+	for _, gotRef := range gotRefs {
+		if gotRef.Name != nil && *gotRef.Name == longTagName {
+			return &gotRef, nil
+		}
+	}
+	return nil, nil
+}
+
+func getRepositoryProjectId(clients *client.AggregatedClient, repoId string) (string, error) {
+	repo, err := clients.GitReposClient.GetRepository(clients.Ctx, git.GetRepositoryArgs{
+		RepositoryId: converter.String(repoId),
+	})
+	if err != nil {
+		return "", fmt.Errorf("Error reading repository %s: %w", repoId, err)
+	}
+	if repo.Project == nil || repo.Project.Id == nil {
+		return "", fmt.Errorf("Repository %s has no project", repoId)
+	}
+	return repo.Project.Id.String(), nil
+}
+
+// getAllRefs returns all refs matching the arguments, following the continuation tokens.
+func getAllRefs(clients *client.AggregatedClient, args git.GetRefsArgs) ([]git.GitRef, error) {
+	refs := []git.GitRef{}
+	for {
+		gotRefs, err := clients.GitReposClient.GetRefs(clients.Ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, gotRefs.Value...)
+		if gotRefs.ContinuationToken == "" {
+			return refs, nil
+		}
+		args.ContinuationToken = converter.String(gotRefs.ContinuationToken)
+	}
+}
@@ -0,0 +1,258 @@
+//go:build (all || git || resource_git_repository_tag) && (!exclude_git || !exclude_resource_git_repository_tag)
+// +build all git resource_git_repository_tag
+// +build !exclude_git !exclude_resource_git_repository_tag
+
+package git
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/core"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/git"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var tagTestProjectID = uuid.New()
+var tagTestRepositoryID = uuid.New().String()
+
+func expectTagRepository(gitClient *azdosdkmocks.MockGitClient, ctx context.Context) {
+	gitClient.
+		EXPECT().
+		GetRepository(ctx, git.GetRepositoryArgs{RepositoryId: &tagTestRepositoryID}).
+		Return(&git.GitRepository{Project: &core.TeamProjectReference{Id: &tagTestProjectID}}, nil).
+		AnyTimes()
+}
+
+// verifies that an annotated tag is created for the commit of the referenced branch
+func TestGitRepositoryTag_Create_AnnotatedTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: gitClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceGitRepositoryTag().Schema, map[string]interface{}{
+		"name":          "v1.0.0",
+		"repository_id": tagTestRepositoryID,
+		"ref_branch":    "main",
+		"message":       "Baseline",
+	})
+
+	expectTagRepository(gitClient, clients.Ctx)
+	gitClient.
+		EXPECT().
+		GetRefs(clients.Ctx, git.GetRefsArgs{
+			RepositoryId: &tagTestRepositoryID,
+			Filter:       converter.String("heads/main"),
+			Top:          converter.Int(1),
+			PeelTags:     converter.Bool(true),
+		}).
+		Return(&git.GetRefsResponseValue{Value: []git.GitRef{{
+			Name:     converter.String("refs/heads/main"),
+			ObjectId: converter.String("a-commit"),
+		}}}, nil).
+		Times(1)
+	gitClient.
+		EXPECT().
+		CreateAnnotatedTag(clients.Ctx, git.CreateAnnotatedTagArgs{
+			TagObject: &git.GitAnnotatedTag{
+				Name:         converter.String("v1.0.0"),
+				Message:      converter.String("Baseline"),
+				TaggedObject: &git.GitObject{ObjectId: converter.String("a-commit")},
+			},
+			Project:      converter.String(tagTestProjectID.String()),
+			RepositoryId: &tagTestRepositoryID,
+		}).
+		Return(&git.GitAnnotatedTag{ObjectId: converter.String("a-tag-object")}, nil).
+		Times(1)
+	gitClient.
+		EXPECT().
+		GetRefs(clients.Ctx, git.GetRefsArgs{
+			RepositoryId: &tagTestRepositoryID,
+			Filter:       converter.String("tags/v1.0.0"),
+			PeelTags:     converter.Bool(true),
+		}).
+		Return(&git.GetRefsResponseValue{Value: []git.GitRef{{
+			Name:           converter.String("refs/tags/v1.0.0"),
+			ObjectId:       converter.String("a-tag-object"),
+			PeeledObjectId: converter.String("a-commit"),
+		}}}, nil).
+		Times(1)
+	gitClient.
+		EXPECT().
+		GetAnnotatedTag(clients.Ctx, git.GetAnnotatedTagArgs{
+			Project:      converter.String(tagTestProjectID.String()),
+			RepositoryId: &tagTestRepositoryID,
+			ObjectId:     converter.String("a-tag-object"),
+		}).
+		Return(&git.GitAnnotatedTag{Message: converter.String("Baseline")}, nil).
+		Times(1)
+
+	diags := resourceGitRepositoryTagCreate(clients.Ctx, resourceData, clients)
+	require.False(t, diags.HasError())
+	require.Equal(t, tagTestRepositoryID+":v1.0.0", resourceData.Id())
+	require.Equal(t, "a-tag-object", resourceData.Get("object_id"))
+	require.Equal(t, "a-commit", resourceData.Get("commit_id"))
+	require.Equal(t, "Baseline", resourceData.Get("message"))
+	require.False(t, resourceData.Get("lightweight").(bool))
+}
+
+// verifies that a lightweight tag is created as a ref pointing to the commit
+func TestGitRepositoryTag_Create_LightweightTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: gitClient, Ctx: context.Background()}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceGitRepositoryTag().Schema, map[string]interface{}{
+		"name":          "v1.0.0",
+		"repository_id": tagTestRepositoryID,
+		"ref_commit_id": "a-commit",
+		"lightweight":   true,
+	})
+
+	gitClient.
+		EXPECT().
+		UpdateRefs(clients.Ctx, git.UpdateRefsArgs{
+			RefUpdates: &[]git.GitRefUpdate{{
+				Name:        converter.String("refs/tags/v1.0.0"),
+				NewObjectId: converter.String("a-commit"),
+				OldObjectId: converter.String("0000000000000000000000000000000000000000"),
+			}},
+			RepositoryId: &tagTestRepositoryID,
+		}).
+		Return(&[]git.GitRefUpdateResult{{Success: converter.Bool(true)}}, nil).
+		Times(1)
+	gitClient.
+		EXPECT().
+		GetRefs(clients.Ctx, gomock.Any()).
+		Return(&git.GetRefsResponseValue{Value: []git.GitRef{{
+			Name:     converter.String("refs/tags/v1.0.0"),
+			ObjectId: converter.String("a-commit"),
+		}}}, nil).
+		Times(1)
+
+	diags := resourceGitRepositoryTagCreate(clients.Ctx, resourceData, clients)
+	require.False(t, diags.HasError())
+	require.Equal(t, "a-commit", resourceData.Get("commit_id"))
+	require.True(t, resourceData.Get("lightweight").(bool))
+}
+
+// verifies that an annotated tag without a message is rejected at plan time
+func TestGitRepositoryTag_Diff_AnnotatedTagRequiresMessage(t *testing.T) {
+	config := func(attributes map[string]interface{}) *terraform.ResourceConfig {
+		raw := map[string]interface{}{
+			"name":          "v1.0.0",
+			"repository_id": tagTestRepositoryID,
+			"ref_commit_id": "a-commit",
+		}
+		for k, v := range attributes {
+			raw[k] = v
+		}
+		return terraform.NewResourceConfigRaw(raw)
+	}
+
+	_, err := ResourceGitRepositoryTag().Diff(context.Background(), nil, config(nil), nil)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "A message is required for the annotated tag")
+
+	_, err = ResourceGitRepositoryTag().Diff(context.Background(), nil, config(map[string]interface{}{"message": "Baseline"}), nil)
+	require.Nil(t, err)
+
+	_, err = ResourceGitRepositoryTag().Diff(context.Background(), nil, config(map[string]interface{}{"lightweight": true}), nil)
+	require.Nil(t, err)
+}
+
+// verifies that an imported tag references the tagged commit
+func TestGitRepositoryTag_Read_ImportedTagReferencesCommit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: gitClient, Ctx: context.Background()}
+
+	expectTagRepository(gitClient, clients.Ctx)
+	gitClient.
+		EXPECT().
+		GetRefs(clients.Ctx, gomock.Any()).
+		Return(&git.GetRefsResponseValue{Value: []git.GitRef{{
+			Name:           converter.String("refs/tags/v1.0.0"),
+			ObjectId:       converter.String("a-tag-object"),
+			PeeledObjectId: converter.String("a-commit"),
+		}}}, nil).
+		Times(1)
+	gitClient.
+		EXPECT().
+		GetAnnotatedTag(clients.Ctx, gomock.Any()).
+		Return(&git.GitAnnotatedTag{Message: converter.String("Baseline")}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceGitRepositoryTag().Schema, nil)
+	resourceData.SetId(tagTestRepositoryID + ":v1.0.0")
+	diags := resourceGitRepositoryTagRead(clients.Ctx, resourceData, clients)
+	require.False(t, diags.HasError())
+	require.Equal(t, "a-commit", resourceData.Get("ref_commit_id"))
+	require.Equal(t, "a-commit", resourceData.Get("commit_id"))
+	require.Equal(t, "", resourceData.Get("ref_branch"))
+}
+
+// verifies that the configured ref of the tag is kept
+func TestGitRepositoryTag_Read_KeepsConfiguredRef(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: gitClient, Ctx: context.Background()}
+
+	gitClient.
+		EXPECT().
+		GetRefs(clients.Ctx, gomock.Any()).
+		Return(&git.GetRefsResponseValue{Value: []git.GitRef{{
+			Name:     converter.String("refs/tags/v1.0.0"),
+			ObjectId: converter.String("a-commit"),
+		}}}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceGitRepositoryTag().Schema, map[string]interface{}{
+		"ref_branch":  "main",
+		"lightweight": true,
+	})
+	resourceData.SetId(tagTestRepositoryID + ":v1.0.0")
+	diags := resourceGitRepositoryTagRead(clients.Ctx, resourceData, clients)
+	require.False(t, diags.HasError())
+	require.Equal(t, "main", resourceData.Get("ref_branch"))
+	require.Equal(t, "", resourceData.Get("ref_commit_id"))
+}
+
+// verifies that the tag is removed from the state if only tags with the same prefix exist
+func TestGitRepositoryTag_Read_RemovesDeletedTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gitClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: gitClient, Ctx: context.Background()}
+
+	gitClient.
+		EXPECT().
+		GetRefs(clients.Ctx, gomock.Any()).
+		Return(&git.GetRefsResponseValue{Value: []git.GitRef{{
+			Name:     converter.String("refs/tags/v1.0.0-rc1"),
+			ObjectId: converter.String("a-commit"),
+		}}}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceGitRepositoryTag().Schema, nil)
+	resourceData.SetId(tagTestRepositoryID + ":v1.0.0")
+	diags := resourceGitRepositoryTagRead(clients.Ctx, resourceData, clients)
+	require.False(t, diags.HasError())
+	require.Equal(t, "", resourceData.Id())
+}
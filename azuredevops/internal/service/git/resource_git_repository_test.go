@@ -9,12 +9,14 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/core"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/serviceendpoint"
 	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
@@ -304,3 +306,113 @@ func TestGitRepo_Read_UsesNameIfIdNotSet(t *testing.T) {
 
 	resourceGitRepositoryRead(resourceData, clients)
 }
+
+// verifies that the error message of a failed import request is surfaced
+func TestGitRepo_WaitForImportRequest_SurfacesErrorMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	clients := &client.AggregatedClient{GitReposClient: reposClient, Ctx: context.Background()}
+
+	reposClient.
+		EXPECT().
+		GetImportRequest(clients.Ctx, git.GetImportRequestArgs{
+			Project:         converter.String(testRepoProjectID.String()),
+			RepositoryId:    converter.String(testRepoID.String()),
+			ImportRequestId: converter.Int(7),
+		}).
+		Return(&git.GitImportRequest{
+			ImportRequestId: converter.Int(7),
+			Status:          &git.GitAsyncOperationStatusValues.Failed,
+			DetailedStatus: &git.GitImportStatusDetail{
+				ErrorMessage: converter.String("Authentication failed for the source repository"),
+			},
+		}, nil).
+		Times(1)
+
+	err := waitForImportRequest(clients, testRepoProjectID.String(), testRepoID.String(), converter.Int(7), time.Minute)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "Authentication failed for the source repository")
+}
+
+// verifies that an import with credentials uses a temporary service connection, waits for the import
+// to complete and deletes the service connection afterwards
+func TestGitRepo_Create_ImportWithCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceGitRepository().Schema, nil)
+	resourceData.Set("name", *testGitRepository.Name)
+	resourceData.Set("project_id", testRepoProjectID.String())
+	resourceData.Set("initialization", &[]map[string]interface{}{
+		{
+			"init_type":   "Import",
+			"source_type": "Git",
+			"source_url":  "https://example.com/private.git",
+			"username":    "user",
+			"password":    "secret",
+		},
+	})
+
+	reposClient := azdosdkmocks.NewMockGitClient(ctrl)
+	serviceEndpointClient := azdosdkmocks.NewMockServiceendpointClient(ctrl)
+	clients := &client.AggregatedClient{
+		GitReposClient:        reposClient,
+		ServiceEndpointClient: serviceEndpointClient,
+		Ctx:                   context.Background(),
+	}
+
+	serviceEndpointID := uuid.New()
+	importedRepository := testGitRepository
+	importedRepository.DefaultBranch = converter.String("refs/heads/main")
+
+	reposClient.
+		EXPECT().
+		CreateRepository(clients.Ctx, gomock.Any()).
+		Return(&testGitRepository, nil).
+		Times(1)
+	serviceEndpointClient.
+		EXPECT().
+		CreateServiceEndpoint(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, args serviceendpoint.CreateServiceEndpointArgs) (*serviceendpoint.ServiceEndpoint, error) {
+			require.Equal(t, "git", *args.Endpoint.Type)
+			require.Equal(t, "https://example.com/private.git", *args.Endpoint.Url)
+			require.Equal(t, "user", (*args.Endpoint.Authorization.Parameters)["username"])
+			require.Equal(t, "secret", (*args.Endpoint.Authorization.Parameters)["password"])
+			return &serviceendpoint.ServiceEndpoint{Id: &serviceEndpointID}, nil
+		}).
+		Times(1)
+	importRequest := reposClient.
+		EXPECT().
+		CreateImportRequest(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, args git.CreateImportRequestArgs) (*git.GitImportRequest, error) {
+			require.Equal(t, serviceEndpointID, *args.ImportRequest.Parameters.ServiceEndpointId)
+			return &git.GitImportRequest{ImportRequestId: converter.Int(7), Status: &git.GitAsyncOperationStatusValues.Queued}, nil
+		}).
+		Times(1)
+	reposClient.
+		EXPECT().
+		GetImportRequest(clients.Ctx, gomock.Any()).
+		Return(&git.GitImportRequest{ImportRequestId: converter.Int(7), Status: &git.GitAsyncOperationStatusValues.Completed}, nil).
+		After(importRequest).
+		Times(1)
+	serviceEndpointClient.
+		EXPECT().
+		DeleteServiceEndpoint(clients.Ctx, serviceendpoint.DeleteServiceEndpointArgs{
+			ProjectIds: &[]string{testRepoProjectID.String()},
+			EndpointId: &serviceEndpointID,
+		}).
+		Return(nil).
+		Times(1)
+	reposClient.
+		EXPECT().
+		GetRepository(clients.Ctx, gomock.Any()).
+		Return(&importedRepository, nil).
+		AnyTimes()
+
+	err := resourceGitRepositoryCreate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, testRepoID.String(), resourceData.Id())
+	require.Equal(t, "refs/heads/main", resourceData.Get("default_branch"))
+}
@@ -3,13 +3,12 @@ package permissions
 import (
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	securityhelper "github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/permissions/utils"
-	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/validate"
 )
 
 // ResourceGitPermissions schema and implementation for Git repository permission resource
@@ -33,11 +32,28 @@ func ResourceGitPermissions() *schema.Resource {
 				ForceNew:     true,
 			},
 			"branch_name": {
-				Type:         schema.TypeString,
-				ValidateFunc: validation.StringIsNotWhiteSpace,
-				Optional:     true,
-				ForceNew:     true,
-				RequiredWith: []string{"repository_id"},
+				Type:          schema.TypeString,
+				ValidateFunc:  validation.StringIsNotWhiteSpace,
+				Optional:      true,
+				ForceNew:      true,
+				RequiredWith:  []string{"repository_id"},
+				ConflictsWith: []string{"branch_folder", "tag_folder"},
+			},
+			"branch_folder": {
+				Type:          schema.TypeString,
+				ValidateFunc:  validation.All(validation.StringIsNotWhiteSpace, validate.GitRefFolder),
+				Optional:      true,
+				ForceNew:      true,
+				RequiredWith:  []string{"repository_id"},
+				ConflictsWith: []string{"branch_name", "tag_folder"},
+			},
+			"tag_folder": {
+				Type:          schema.TypeString,
+				ValidateFunc:  validation.All(validation.StringIsNotWhiteSpace, validate.GitRefFolder),
+				Optional:      true,
+				ForceNew:      true,
+				RequiredWith:  []string{"repository_id"},
+				ConflictsWith: []string{"branch_name", "branch_folder"},
 			},
 		}),
 	}
@@ -107,24 +123,35 @@ func createGitToken(d *schema.ResourceData, clients *client.AggregatedClient) (s
 	 * ACL for ALL Git repositories in a project:                 repoV2/#ProjectID#
 	 * ACL for a Git repository in a project:                     repoV2/#ProjectID#/#RepositoryID#
 	 * ACL for all branches inside a Git repository in a project: repoV2/#ProjectID#/#RepositoryID#/refs/heads
-	 * ACL for a branch inside a Git repository in a project:     repoV2/#ProjectID#/#RepositoryID#/refs/heads/#BranchID#
+	 * ACL for a branch inside a Git repository in a project:     repoV2/#ProjectID#/#RepositoryID#/refs/heads/#BranchPath#
+	 * ACL for all tags inside a Git repository in a project:     repoV2/#ProjectID#/#RepositoryID#/refs/tags
+	 * ACL for a tag folder inside a Git repository in a project: repoV2/#ProjectID#/#RepositoryID#/refs/tags/#FolderPath#
+	 *
+	 * Every segment of a branch or folder path is encoded separately
 	 */
 	aclToken := "repoV2/" + projectID.(string)
 	repositoryID, repoOk := d.GetOk("repository_id")
 	if repoOk {
 		aclToken += "/" + repositoryID.(string)
 	}
-	branchName, branchOk := d.GetOk("branch_name")
-	if branchOk {
+
+	refPrefix, refPath := "", ""
+	if branchName, ok := d.GetOk("branch_name"); ok {
+		refPrefix, refPath = securityhelper.GitBranchesRefPrefix, branchName.(string)
+	} else if branchFolder, ok := d.GetOk("branch_folder"); ok {
+		refPrefix, refPath = securityhelper.GitBranchesRefPrefix, branchFolder.(string)
+	} else if tagFolder, ok := d.GetOk("tag_folder"); ok {
+		refPrefix, refPath = securityhelper.GitTagsRefPrefix, tagFolder.(string)
+	}
+	if refPrefix != "" {
 		if !repoOk {
-			return "", fmt.Errorf("Unable to create ACL token for branch %s, because no repository is specified", branchName)
+			return "", fmt.Errorf("Unable to create ACL token for %s, because no repository is specified", refPath)
 		}
-		branchPath := strings.Split(branchName.(string), "/")
-		branchName, err := converter.EncodeUtf16HexString(branchPath[len(branchPath)-1])
+		refToken, err := securityhelper.CreateGitRefSecurityToken(refPrefix, refPath)
 		if err != nil {
 			return "", err
 		}
-		aclToken += "/refs/heads/" + branchName
+		aclToken += "/" + refToken
 	}
 	return aclToken, nil
 }
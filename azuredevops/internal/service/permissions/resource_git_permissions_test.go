@@ -79,6 +79,35 @@ func TestGitPermissions_CreateGitTokenWithBranch(t *testing.T) {
 	assert.Equal(t, gitTokenBranch, token)
 }
 
+func TestGitPermissions_CreateGitTokenWithRefFolders(t *testing.T) {
+	var tests = []struct {
+		attribute string
+		value     string
+		token     string
+	}{
+		{"branch_name", "refs/heads/master", gitTokenBranch},
+		{"branch_name", "release/v1", fmt.Sprintf("%s/refs/heads/%s/%s", gitTokenRepository, encodeBranchName("release"), encodeBranchName("v1"))},
+		{"branch_folder", "refs/heads/release/*", fmt.Sprintf("%s/refs/heads/%s", gitTokenRepository, encodeBranchName("release"))},
+		{"branch_folder", "*", gitTokenBranchAll},
+		{"tag_folder", "refs/tags/", fmt.Sprintf("%s/refs/tags", gitTokenRepository)},
+		{"tag_folder", "release", fmt.Sprintf("%s/refs/tags/%s", gitTokenRepository, encodeBranchName("release"))},
+	}
+
+	for _, test := range tests {
+		d := getGitPermissionsResource(t, gitProjectID, gitRepositoryID, "")
+		d.Set(test.attribute, test.value)
+		token, err := createGitToken(d, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, test.token, token, "%s = %q", test.attribute, test.value)
+	}
+
+	d := getGitPermissionsResource(t, gitProjectID, "", "")
+	d.Set("tag_folder", "release")
+	token, err := createGitToken(d, nil)
+	assert.Empty(t, token)
+	assert.NotNil(t, err)
+}
+
 func encodeBranchName(branchName string) string {
 	ret, _ := converter.EncodeUtf16HexString(branchName)
 	return ret
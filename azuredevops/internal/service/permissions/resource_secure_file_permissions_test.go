@@ -0,0 +1,51 @@
+//go:build (all || permissions || resource_secure_file_permissions) && (!exclude_permissions || !resource_secure_file_permissions)
+// +build all permissions resource_secure_file_permissions
+// +build !exclude_permissions !resource_secure_file_permissions
+
+package permissions
+
+// The tests in this file use the mock clients in mock_client.go to mock out
+// the Azure DevOps client operations.
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+/**
+ * Begin unit tests
+ */
+
+var secureFileID = "8f7bda2b-1a4e-4c3d-9d36-0a7a5f0c1e42"
+var secureFileToken = fmt.Sprintf("Library/%s/SecureFile/%s", projectID, secureFileID)
+
+func TestSecureFilesPermissions_CreateSecureFileToken(t *testing.T) {
+	var d *schema.ResourceData
+	var token string
+	var err error
+
+	d = getSecureFilePermissionsResource(t, projectID, secureFileID)
+	token, err = createSecureFileToken(d, nil)
+	assert.NotEmpty(t, token)
+	assert.Nil(t, err)
+	assert.Equal(t, secureFileToken, token)
+
+	d = getSecureFilePermissionsResource(t, "", "")
+	token, err = createSecureFileToken(d, nil)
+	assert.Empty(t, token)
+	assert.NotNil(t, err)
+}
+
+func getSecureFilePermissionsResource(t *testing.T, projectID string, secureFileID string) *schema.ResourceData {
+	d := schema.TestResourceDataRaw(t, ResourceSecureFilePermissions().Schema, nil)
+	if projectID != "" {
+		d.Set("project_id", projectID)
+	}
+	if secureFileID != "" {
+		d.Set("secure_file_id", secureFileID)
+	}
+	return d
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+)
+
+const (
+	// GitBranchesRefPrefix is the ref prefix of all branches inside a Git repository
+	GitBranchesRefPrefix = "refs/heads"
+	// GitTagsRefPrefix is the ref prefix of all tags inside a Git repository
+	GitTagsRefPrefix = "refs/tags"
+)
+
+// CreateGitRefSecurityToken creates the part of a Git repository security namespace token
+// for a branch, a tag or a folder of those below the given ref prefix. Every segment of the
+// path is encoded separately, so that a folder token is the parent of all tokens inside the folder.
+// An empty path or a path of `*` targets all refs below the prefix.
+func CreateGitRefSecurityToken(refPrefix string, path string) (string, error) {
+	path = strings.TrimPrefix(path, refPrefix+"/")
+	path = strings.TrimSuffix(strings.TrimSuffix(path, "*"), "/")
+
+	var sb strings.Builder
+	sb.WriteString(refPrefix)
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" {
+			continue
+		}
+		encodedSegment, err := converter.EncodeUtf16HexString(segment)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString("/" + encodedSegment)
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,34 @@
+//go:build all || utils || git_helper
+// +build all utils git_helper
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitHelper_CreateGitRefSecurityToken(t *testing.T) {
+	var tests = []struct {
+		refPrefix string
+		path      string
+		token     string
+	}{
+		{GitBranchesRefPrefix, "master", "refs/heads/6d0061007300740065007200"},
+		{GitBranchesRefPrefix, "refs/heads/master", "refs/heads/6d0061007300740065007200"},
+		{GitBranchesRefPrefix, "release/v1", "refs/heads/720065006c006500610073006500/76003100"},
+		{GitBranchesRefPrefix, "refs/heads/release/*", "refs/heads/720065006c006500610073006500"},
+		{GitBranchesRefPrefix, "release/", "refs/heads/720065006c006500610073006500"},
+		{GitBranchesRefPrefix, "*", "refs/heads"},
+		{GitBranchesRefPrefix, "", "refs/heads"},
+		{GitTagsRefPrefix, "refs/tags/", "refs/tags"},
+		{GitTagsRefPrefix, "v1", "refs/tags/76003100"},
+	}
+
+	for _, test := range tests {
+		token, err := CreateGitRefSecurityToken(test.refPrefix, test.path)
+		assert.Nil(t, err)
+		assert.Equal(t, test.token, token, "path %q", test.path)
+	}
+}
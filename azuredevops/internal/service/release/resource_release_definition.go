@@ -0,0 +1,1261 @@
+package release
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/release"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/webapi"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/validate"
+)
+
+const (
+	rdVariable              = "variable"
+	rdVariableName          = "name"
+	rdVariableValue         = "value"
+	rdSecretVariableValue   = "secret_value"
+	rdVariableIsSecret      = "is_secret"
+	rdVariableAllowOverride = "allow_override"
+)
+
+// Name of the condition that starts a stage once a release is created
+const releaseStartedCondition = "ReleaseStarted"
+
+// Value of an environment state condition that waits for the previous stage to succeed
+const stageSucceededConditionValue = "4"
+
+var scheduleDays = map[string]release.ScheduleDays{
+	"Mon": release.ScheduleDaysValues.Monday,
+	"Tue": release.ScheduleDaysValues.Tuesday,
+	"Wed": release.ScheduleDaysValues.Wednesday,
+	"Thu": release.ScheduleDaysValues.Thursday,
+	"Fri": release.ScheduleDaysValues.Friday,
+	"Sat": release.ScheduleDaysValues.Saturday,
+	"Sun": release.ScheduleDaysValues.Sunday,
+}
+
+var scheduleDayOrder = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+// ResourceReleaseDefinition schema and implementation for release definition resource
+func ResourceReleaseDefinition() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceReleaseDefinitionCreate,
+		Read:     resourceReleaseDefinitionRead,
+		Update:   resourceReleaseDefinitionUpdate,
+		Delete:   resourceReleaseDefinitionDelete,
+		Importer: tfhelper.ImportProjectQualifiedResourceInteger(),
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			"revision": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      `\`,
+				ValidateFunc: validate.Path,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"release_name_format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Release-$(rev:r)",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"variable_groups": variableGroupsSchema(),
+			rdVariable:        variableSchema(),
+			"artifact": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"alias": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"is_primary": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"definition_reference": {
+							Type:     schema.TypeMap,
+							Required: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"artifact_trigger": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"artifact_alias": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"branch_filter": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"branch": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringIsNotWhiteSpace,
+									},
+									"tags": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: validation.NoZeroValues,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"schedule": scheduleSchema(),
+			"stage": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"owner_id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IsUUID,
+						},
+						"trigger_manually": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"after_stages": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringIsNotWhiteSpace,
+							},
+						},
+						"job_name": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "Agent job",
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"queue_id": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"task":                 taskSchema(),
+						"pre_deploy_approval":  approvalSchema(),
+						"post_deploy_approval": approvalSchema(),
+						"pre_deploy_gate":      gateSchema(),
+						"post_deploy_gate":     gateSchema(),
+						"schedule":             scheduleSchema(),
+						"variable_groups":      variableGroupsSchema(),
+						rdVariable:             variableSchema(),
+						"retention_policy": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Computed: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"days_to_keep": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      30,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+									"releases_to_keep": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      3,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+									"retain_build": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func variableGroupsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeInt,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+	}
+}
+
+func variableSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				rdVariableName: {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				rdVariableValue: {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  "",
+				},
+				rdSecretVariableValue: {
+					Type:      schema.TypeString,
+					Optional:  true,
+					Sensitive: true,
+					Default:   "",
+				},
+				rdVariableIsSecret: {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				rdVariableAllowOverride: {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+			},
+		},
+	}
+}
+
+func scheduleSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"days_to_release": {
+					Type:     schema.TypeList,
+					Required: true,
+					MinItems: 1,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(scheduleDayOrder, false),
+					},
+				},
+				"start_hours": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntBetween(0, 23),
+				},
+				"start_minutes": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntBetween(0, 59),
+				},
+				"time_zone_id": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "UTC",
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				"schedule_only_with_changes": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+			},
+		},
+	}
+}
+
+func taskSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"task_id": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.IsUUID,
+				},
+				"version": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				"display_name": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  "",
+				},
+				"enabled": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"continue_on_error": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"condition": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  "succeeded()",
+				},
+				"timeout_in_minutes": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"inputs": {
+					Type:     schema.TypeMap,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+func approvalSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"approvers": {
+					Type:     schema.TypeList,
+					Required: true,
+					MinItems: 1,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.IsUUID,
+					},
+				},
+				"required_approver_count": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"execution_order": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  string(release.ApprovalExecutionOrderValues.BeforeGates),
+					ValidateFunc: validation.StringInSlice([]string{
+						string(release.ApprovalExecutionOrderValues.BeforeGates),
+						string(release.ApprovalExecutionOrderValues.AfterSuccessfulGates),
+						string(release.ApprovalExecutionOrderValues.AfterGatesAlways),
+					}, false),
+				},
+				"timeout_in_minutes": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      43200,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"release_creator_can_be_approver": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+			},
+		},
+	}
+}
+
+func gateSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"timeout_in_minutes": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      1440,
+					ValidateFunc: validation.IntAtLeast(6),
+				},
+				"sampling_interval_in_minutes": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      15,
+					ValidateFunc: validation.IntAtLeast(5),
+				},
+				"stabilization_time_in_minutes": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      5,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"minimum_success_duration_in_minutes": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"task": taskSchema(),
+			},
+		},
+	}
+}
+
+func resourceReleaseDefinitionCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	releaseDefinition, projectID, err := expandReleaseDefinition(d)
+	if err != nil {
+		return fmt.Errorf(" expanding release definition: %+v", err)
+	}
+
+	createdReleaseDefinition, err := clients.ReleaseClient.CreateReleaseDefinition(clients.Ctx, release.CreateReleaseDefinitionArgs{
+		ReleaseDefinition: releaseDefinition,
+		Project:           &projectID,
+	})
+	if err != nil {
+		return fmt.Errorf(" creating release definition: %+v", err)
+	}
+
+	d.SetId(strconv.Itoa(*createdReleaseDefinition.Id))
+	return resourceReleaseDefinitionRead(d, m)
+}
+
+func resourceReleaseDefinitionRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID, releaseDefinitionID, err := tfhelper.ParseProjectIDAndResourceID(d)
+	if err != nil {
+		return err
+	}
+
+	releaseDefinition, err := clients.ReleaseClient.GetReleaseDefinition(clients.Ctx, release.GetReleaseDefinitionArgs{
+		Project:      &projectID,
+		DefinitionId: &releaseDefinitionID,
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf(" reading release definition with ID %d: %+v", releaseDefinitionID, err)
+	}
+	if releaseDefinition.IsDeleted != nil && *releaseDefinition.IsDeleted {
+		d.SetId("")
+		return nil
+	}
+
+	return flattenReleaseDefinition(d, releaseDefinition, projectID)
+}
+
+func resourceReleaseDefinitionUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	releaseDefinition, projectID, err := expandReleaseDefinition(d)
+	if err != nil {
+		return fmt.Errorf(" expanding release definition: %+v", err)
+	}
+
+	_, err = clients.ReleaseClient.UpdateReleaseDefinition(clients.Ctx, release.UpdateReleaseDefinitionArgs{
+		ReleaseDefinition: releaseDefinition,
+		Project:           &projectID,
+	})
+	if err != nil {
+		return fmt.Errorf(" updating release definition with ID %s: %+v", d.Id(), err)
+	}
+
+	return resourceReleaseDefinitionRead(d, m)
+}
+
+func resourceReleaseDefinitionDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID, releaseDefinitionID, err := tfhelper.ParseProjectIDAndResourceID(d)
+	if err != nil {
+		return err
+	}
+
+	err = clients.ReleaseClient.DeleteReleaseDefinition(clients.Ctx, release.DeleteReleaseDefinitionArgs{
+		Project:      &projectID,
+		DefinitionId: &releaseDefinitionID,
+		ForceDelete:  converter.Bool(true),
+	})
+	if err != nil {
+		return fmt.Errorf(" deleting release definition with ID %d: %+v", releaseDefinitionID, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func expandReleaseDefinition(d *schema.ResourceData) (*release.ReleaseDefinition, string, error) {
+	projectID := d.Get("project_id").(string)
+
+	releaseDefinition := release.ReleaseDefinition{
+		Name:              converter.String(d.Get("name").(string)),
+		Path:              converter.String(d.Get("path").(string)),
+		Description:       converter.String(d.Get("description").(string)),
+		ReleaseNameFormat: converter.String(d.Get("release_name_format").(string)),
+		VariableGroups:    expandVariableGroups(d.Get("variable_groups").(*schema.Set)),
+		Variables:         expandVariables(d.Get(rdVariable).(*schema.Set)),
+		Artifacts:         expandArtifacts(d.Get("artifact").([]interface{})),
+		Triggers:          expandTriggers(d.Get("artifact_trigger").([]interface{}), d.Get("schedule").([]interface{})),
+	}
+
+	if d.Id() != "" {
+		releaseDefinitionID, err := strconv.Atoi(d.Id())
+		if err != nil {
+			return nil, "", fmt.Errorf(" parsing release definition ID: %+v", err)
+		}
+		releaseDefinition.Id = &releaseDefinitionID
+		releaseDefinition.Revision = converter.Int(d.Get("revision").(int))
+	}
+
+	environments, err := expandStages(d.Get("stage").([]interface{}))
+	if err != nil {
+		return nil, "", err
+	}
+	releaseDefinition.Environments = environments
+
+	return &releaseDefinition, projectID, nil
+}
+
+func expandVariableGroups(variableGroups *schema.Set) *[]int {
+	result := []int{}
+	for _, variableGroup := range variableGroups.List() {
+		result = append(result, variableGroup.(int))
+	}
+	sort.Ints(result)
+	return &result
+}
+
+func expandVariables(variables *schema.Set) *map[string]release.ConfigurationVariableValue {
+	result := map[string]release.ConfigurationVariableValue{}
+	for _, variable := range variables.List() {
+		variableAsMap := variable.(map[string]interface{})
+		isSecret := variableAsMap[rdVariableIsSecret].(bool)
+
+		value := variableAsMap[rdVariableValue].(string)
+		if isSecret {
+			value = variableAsMap[rdSecretVariableValue].(string)
+		}
+		result[variableAsMap[rdVariableName].(string)] = release.ConfigurationVariableValue{
+			Value:         converter.String(value),
+			IsSecret:      converter.Bool(isSecret),
+			AllowOverride: converter.Bool(variableAsMap[rdVariableAllowOverride].(bool)),
+		}
+	}
+	return &result
+}
+
+func expandArtifacts(artifacts []interface{}) *[]release.Artifact {
+	result := []release.Artifact{}
+	for _, artifact := range artifacts {
+		artifactAsMap := artifact.(map[string]interface{})
+
+		definitionReference := map[string]release.ArtifactSourceReference{}
+		for key, value := range artifactAsMap["definition_reference"].(map[string]interface{}) {
+			definitionReference[key] = release.ArtifactSourceReference{
+				Id: converter.String(value.(string)),
+			}
+		}
+
+		result = append(result, release.Artifact{
+			Alias:               converter.String(artifactAsMap["alias"].(string)),
+			Type:                converter.String(artifactAsMap["type"].(string)),
+			IsPrimary:           converter.Bool(artifactAsMap["is_primary"].(bool)),
+			DefinitionReference: &definitionReference,
+		})
+	}
+	return &result
+}
+
+func expandTriggers(artifactTriggers []interface{}, schedules []interface{}) *[]interface{} {
+	result := []interface{}{}
+	for _, artifactTrigger := range artifactTriggers {
+		artifactTriggerAsMap := artifactTrigger.(map[string]interface{})
+
+		conditions := []release.ArtifactFilter{}
+		for _, branchFilter := range artifactTriggerAsMap["branch_filter"].([]interface{}) {
+			branchFilterAsMap := branchFilter.(map[string]interface{})
+			conditions = append(conditions, release.ArtifactFilter{
+				SourceBranch:             converter.String(branchFilterAsMap["branch"].(string)),
+				Tags:                     converter.ToPtr(tfhelper.ExpandStringList(branchFilterAsMap["tags"].([]interface{}))),
+				UseBuildDefinitionBranch: converter.Bool(false),
+			})
+		}
+
+		result = append(result, release.ArtifactSourceTrigger{
+			TriggerType:       &release.ReleaseTriggerTypeValues.ArtifactSource,
+			ArtifactAlias:     converter.String(artifactTriggerAsMap["artifact_alias"].(string)),
+			TriggerConditions: &conditions,
+		})
+	}
+
+	for _, releaseSchedule := range *expandSchedules(schedules) {
+		releaseSchedule := releaseSchedule
+		result = append(result, release.ScheduledReleaseTrigger{
+			TriggerType: &release.ReleaseTriggerTypeValues.Schedule,
+			Schedule:    &releaseSchedule,
+		})
+	}
+	return &result
+}
+
+func expandSchedules(schedules []interface{}) *[]release.ReleaseSchedule {
+	result := []release.ReleaseSchedule{}
+	for _, releaseSchedule := range schedules {
+		scheduleAsMap := releaseSchedule.(map[string]interface{})
+
+		days := []string{}
+		for _, day := range scheduleAsMap["days_to_release"].([]interface{}) {
+			days = append(days, string(scheduleDays[day.(string)]))
+		}
+		daysToRelease := release.ScheduleDays(strings.Join(days, ", "))
+
+		result = append(result, release.ReleaseSchedule{
+			DaysToRelease:           &daysToRelease,
+			StartHours:              converter.Int(scheduleAsMap["start_hours"].(int)),
+			StartMinutes:            converter.Int(scheduleAsMap["start_minutes"].(int)),
+			TimeZoneId:              converter.String(scheduleAsMap["time_zone_id"].(string)),
+			ScheduleOnlyWithChanges: converter.Bool(scheduleAsMap["schedule_only_with_changes"].(bool)),
+		})
+	}
+	return &result
+}
+
+func expandStages(stages []interface{}) (*[]release.ReleaseDefinitionEnvironment, error) {
+	result := []release.ReleaseDefinitionEnvironment{}
+	stageNames := map[string]bool{}
+	for _, stage := range stages {
+		stageNames[stage.(map[string]interface{})["name"].(string)] = true
+	}
+
+	for rank, stage := range stages {
+		stageAsMap := stage.(map[string]interface{})
+		name := stageAsMap["name"].(string)
+
+		conditions, err := expandStageConditions(stageAsMap, stageNames)
+		if err != nil {
+			return nil, fmt.Errorf(" stage %s: %+v", name, err)
+		}
+
+		environment := release.ReleaseDefinitionEnvironment{
+			Name:                converter.String(name),
+			Rank:                converter.Int(rank + 1),
+			Conditions:          conditions,
+			DeployPhases:        expandDeployPhases(stageAsMap),
+			PreDeployApprovals:  expandApprovals(stageAsMap["pre_deploy_approval"].([]interface{})),
+			PostDeployApprovals: expandApprovals(stageAsMap["post_deploy_approval"].([]interface{})),
+			PreDeploymentGates:  expandGates(stageAsMap["pre_deploy_gate"].([]interface{})),
+			PostDeploymentGates: expandGates(stageAsMap["post_deploy_gate"].([]interface{})),
+			Schedules:           expandSchedules(stageAsMap["schedule"].([]interface{})),
+			VariableGroups:      expandVariableGroups(stageAsMap["variable_groups"].(*schema.Set)),
+			Variables:           expandVariables(stageAsMap[rdVariable].(*schema.Set)),
+			RetentionPolicy:     expandRetentionPolicy(stageAsMap["retention_policy"].([]interface{})),
+			DeployStep:          &release.ReleaseDefinitionDeployStep{},
+		}
+		if id := stageAsMap["id"].(int); id != 0 {
+			environment.Id = converter.Int(id)
+		}
+		if ownerID := stageAsMap["owner_id"].(string); ownerID != "" {
+			environment.Owner = &webapi.IdentityRef{Id: converter.String(ownerID)}
+		}
+
+		result = append(result, environment)
+	}
+	return &result, nil
+}
+
+func expandStageConditions(stage map[string]interface{}, stageNames map[string]bool) (*[]release.Condition, error) {
+	afterStages := tfhelper.ExpandStringList(stage["after_stages"].([]interface{}))
+	if stage["trigger_manually"].(bool) {
+		if len(afterStages) > 0 {
+			return nil, fmt.Errorf(" trigger_manually cannot be combined with after_stages")
+		}
+		return &[]release.Condition{}, nil
+	}
+
+	if len(afterStages) == 0 {
+		return &[]release.Condition{
+			{
+				ConditionType: &release.ConditionTypeValues.Event,
+				Name:          converter.String(releaseStartedCondition),
+				Value:         converter.String(""),
+			},
+		}, nil
+	}
+
+	conditions := []release.Condition{}
+	for _, afterStage := range afterStages {
+		if !stageNames[afterStage] {
+			return nil, fmt.Errorf(" after_stages references unknown stage %s", afterStage)
+		}
+		conditions = append(conditions, release.Condition{
+			ConditionType: &release.ConditionTypeValues.EnvironmentState,
+			Name:          converter.String(afterStage),
+			Value:         converter.String(stageSucceededConditionValue),
+		})
+	}
+	return &conditions, nil
+}
+
+func expandDeployPhases(stage map[string]interface{}) *[]interface{} {
+	return &[]interface{}{
+		release.AgentBasedDeployPhase{
+			Name:          converter.String(stage["job_name"].(string)),
+			Rank:          converter.Int(1),
+			PhaseType:     &release.DeployPhaseTypesValues.AgentBasedDeployment,
+			WorkflowTasks: expandWorkflowTasks(stage["task"].([]interface{})),
+			DeploymentInput: &release.AgentDeploymentInput{
+				QueueId: converter.Int(stage["queue_id"].(int)),
+				ParallelExecution: &release.ExecutionInput{
+					ParallelExecutionType: &release.ParallelExecutionTypesValues.None,
+				},
+			},
+		},
+	}
+}
+
+func expandWorkflowTasks(tasks []interface{}) *[]release.WorkflowTask {
+	result := []release.WorkflowTask{}
+	for _, task := range tasks {
+		taskAsMap := task.(map[string]interface{})
+		taskID := uuid.MustParse(taskAsMap["task_id"].(string))
+
+		inputs := map[string]string{}
+		for key, value := range taskAsMap["inputs"].(map[string]interface{}) {
+			inputs[key] = value.(string)
+		}
+
+		result = append(result, release.WorkflowTask{
+			TaskId:           &taskID,
+			Version:          converter.String(taskAsMap["version"].(string)),
+			Name:             converter.String(taskAsMap["display_name"].(string)),
+			Enabled:          converter.Bool(taskAsMap["enabled"].(bool)),
+			ContinueOnError:  converter.Bool(taskAsMap["continue_on_error"].(bool)),
+			Condition:        converter.String(taskAsMap["condition"].(string)),
+			TimeoutInMinutes: converter.Int(taskAsMap["timeout_in_minutes"].(int)),
+			DefinitionType:   converter.String("task"),
+			Inputs:           &inputs,
+		})
+	}
+	return &result
+}
+
+// expandApprovals converts an approval block. Stages without approvers get an automated approval.
+func expandApprovals(approvals []interface{}) *release.ReleaseDefinitionApprovals {
+	if len(approvals) == 0 || approvals[0] == nil {
+		return &release.ReleaseDefinitionApprovals{
+			Approvals: &[]release.ReleaseDefinitionApprovalStep{
+				{
+					IsAutomated:      converter.Bool(true),
+					IsNotificationOn: converter.Bool(false),
+					Rank:             converter.Int(1),
+				},
+			},
+		}
+	}
+
+	approvalAsMap := approvals[0].(map[string]interface{})
+	steps := []release.ReleaseDefinitionApprovalStep{}
+	for rank, approver := range tfhelper.ExpandStringList(approvalAsMap["approvers"].([]interface{})) {
+		steps = append(steps, release.ReleaseDefinitionApprovalStep{
+			Approver:         &webapi.IdentityRef{Id: converter.String(approver)},
+			IsAutomated:      converter.Bool(false),
+			IsNotificationOn: converter.Bool(false),
+			Rank:             converter.Int(rank + 1),
+		})
+	}
+
+	executionOrder := release.ApprovalExecutionOrder(approvalAsMap["execution_order"].(string))
+	return &release.ReleaseDefinitionApprovals{
+		Approvals: &steps,
+		ApprovalOptions: &release.ApprovalOptions{
+			RequiredApproverCount:       converter.Int(approvalAsMap["required_approver_count"].(int)),
+			ExecutionOrder:              &executionOrder,
+			TimeoutInMinutes:            converter.Int(approvalAsMap["timeout_in_minutes"].(int)),
+			ReleaseCreatorCanBeApprover: converter.Bool(approvalAsMap["release_creator_can_be_approver"].(bool)),
+		},
+	}
+}
+
+func expandGates(gates []interface{}) *release.ReleaseDefinitionGatesStep {
+	if len(gates) == 0 || gates[0] == nil {
+		return &release.ReleaseDefinitionGatesStep{
+			Gates: &[]release.ReleaseDefinitionGate{},
+			GatesOptions: &release.ReleaseDefinitionGatesOptions{
+				IsEnabled: converter.Bool(false),
+			},
+		}
+	}
+
+	gateAsMap := gates[0].(map[string]interface{})
+	return &release.ReleaseDefinitionGatesStep{
+		Gates: &[]release.ReleaseDefinitionGate{
+			{Tasks: expandWorkflowTasks(gateAsMap["task"].([]interface{}))},
+		},
+		GatesOptions: &release.ReleaseDefinitionGatesOptions{
+			IsEnabled:              converter.Bool(true),
+			Timeout:                converter.Int(gateAsMap["timeout_in_minutes"].(int)),
+			SamplingInterval:       converter.Int(gateAsMap["sampling_interval_in_minutes"].(int)),
+			StabilizationTime:      converter.Int(gateAsMap["stabilization_time_in_minutes"].(int)),
+			MinimumSuccessDuration: converter.Int(gateAsMap["minimum_success_duration_in_minutes"].(int)),
+		},
+	}
+}
+
+func expandRetentionPolicy(retentionPolicies []interface{}) *release.EnvironmentRetentionPolicy {
+	if len(retentionPolicies) == 0 || retentionPolicies[0] == nil {
+		return &release.EnvironmentRetentionPolicy{
+			DaysToKeep:     converter.Int(30),
+			ReleasesToKeep: converter.Int(3),
+			RetainBuild:    converter.Bool(true),
+		}
+	}
+
+	retentionPolicyAsMap := retentionPolicies[0].(map[string]interface{})
+	return &release.EnvironmentRetentionPolicy{
+		DaysToKeep:     converter.Int(retentionPolicyAsMap["days_to_keep"].(int)),
+		ReleasesToKeep: converter.Int(retentionPolicyAsMap["releases_to_keep"].(int)),
+		RetainBuild:    converter.Bool(retentionPolicyAsMap["retain_build"].(bool)),
+	}
+}
+
+func flattenReleaseDefinition(d *schema.ResourceData, releaseDefinition *release.ReleaseDefinition, projectID string) error {
+	d.SetId(strconv.Itoa(*releaseDefinition.Id))
+	d.Set("project_id", projectID)
+	d.Set("name", releaseDefinition.Name)
+	d.Set("path", releaseDefinition.Path)
+	d.Set("description", converter.ToString(releaseDefinition.Description, ""))
+	d.Set("release_name_format", releaseDefinition.ReleaseNameFormat)
+	d.Set("revision", converter.ToInt(releaseDefinition.Revision, 0))
+	d.Set("variable_groups", flattenVariableGroups(releaseDefinition.VariableGroups))
+	d.Set(rdVariable, flattenVariables(d.Get(rdVariable).(*schema.Set), releaseDefinition.Variables))
+	d.Set("artifact", flattenArtifacts(releaseDefinition.Artifacts))
+
+	artifactTriggers, schedules, err := flattenTriggers(releaseDefinition.Triggers)
+	if err != nil {
+		return err
+	}
+	d.Set("artifact_trigger", artifactTriggers)
+	d.Set("schedule", schedules)
+
+	stages, err := flattenStages(d, releaseDefinition.Environments)
+	if err != nil {
+		return err
+	}
+	return d.Set("stage", stages)
+}
+
+func flattenVariableGroups(variableGroups *[]int) []int {
+	if variableGroups == nil {
+		return nil
+	}
+	return *variableGroups
+}
+
+// Return an interface suitable for serialization into the resource state. This function ensures that
+// any secrets, for which values will not be returned by the service, are not overridden with null or
+// empty values
+func flattenVariables(stateVariables *schema.Set, variables *map[string]release.ConfigurationVariableValue) []interface{} {
+	if variables == nil {
+		return nil
+	}
+
+	result := []interface{}{}
+	for name, value := range *variables {
+		isSecret := converter.ToBool(value.IsSecret, false)
+		variable := map[string]interface{}{
+			rdVariableName:          name,
+			rdVariableValue:         converter.ToString(value.Value, ""),
+			rdSecretVariableValue:   "",
+			rdVariableIsSecret:      isSecret,
+			rdVariableAllowOverride: converter.ToBool(value.AllowOverride, false),
+		}
+
+		if isSecret {
+			variable[rdVariableValue] = ""
+			for _, stateVariable := range stateVariables.List() {
+				stateVariableAsMap := stateVariable.(map[string]interface{})
+				if stateVariableAsMap[rdVariableName].(string) == name {
+					variable[rdSecretVariableValue] = stateVariableAsMap[rdSecretVariableValue]
+				}
+			}
+		}
+		result = append(result, variable)
+	}
+	return result
+}
+
+func flattenArtifacts(artifacts *[]release.Artifact) []interface{} {
+	if artifacts == nil {
+		return nil
+	}
+
+	result := []interface{}{}
+	for _, artifact := range *artifacts {
+		definitionReference := map[string]interface{}{}
+		if artifact.DefinitionReference != nil {
+			for key, value := range *artifact.DefinitionReference {
+				definitionReference[key] = converter.ToString(value.Id, "")
+			}
+		}
+
+		result = append(result, map[string]interface{}{
+			"alias":                converter.ToString(artifact.Alias, ""),
+			"type":                 converter.ToString(artifact.Type, ""),
+			"is_primary":           converter.ToBool(artifact.IsPrimary, false),
+			"definition_reference": definitionReference,
+		})
+	}
+	return result
+}
+
+// flattenTriggers converts the triggers. The service returns them as untyped objects which are
+// converted to the trigger type indicated by their trigger type.
+func flattenTriggers(triggers *[]interface{}) ([]interface{}, []interface{}, error) {
+	if triggers == nil {
+		return nil, nil, nil
+	}
+
+	artifactTriggers := []interface{}{}
+	schedules := []release.ReleaseSchedule{}
+	for _, trigger := range *triggers {
+		triggerAsJSON, err := json.Marshal(trigger)
+		if err != nil {
+			return nil, nil, fmt.Errorf(" serializing release trigger: %+v", err)
+		}
+
+		var triggerBase release.ReleaseTriggerBase
+		if err := json.Unmarshal(triggerAsJSON, &triggerBase); err != nil {
+			return nil, nil, fmt.Errorf(" deserializing release trigger: %+v", err)
+		}
+		if triggerBase.TriggerType == nil {
+			continue
+		}
+
+		switch *triggerBase.TriggerType {
+		case release.ReleaseTriggerTypeValues.ArtifactSource:
+			var artifactTrigger release.ArtifactSourceTrigger
+			if err := json.Unmarshal(triggerAsJSON, &artifactTrigger); err != nil {
+				return nil, nil, fmt.Errorf(" deserializing artifact trigger: %+v", err)
+			}
+			artifactTriggers = append(artifactTriggers, flattenArtifactTrigger(&artifactTrigger))
+		case release.ReleaseTriggerTypeValues.Schedule:
+			var scheduledTrigger release.ScheduledReleaseTrigger
+			if err := json.Unmarshal(triggerAsJSON, &scheduledTrigger); err != nil {
+				return nil, nil, fmt.Errorf(" deserializing scheduled trigger: %+v", err)
+			}
+			if scheduledTrigger.Schedule != nil {
+				schedules = append(schedules, *scheduledTrigger.Schedule)
+			}
+		}
+	}
+	return artifactTriggers, flattenSchedules(&schedules), nil
+}
+
+func flattenArtifactTrigger(trigger *release.ArtifactSourceTrigger) map[string]interface{} {
+	branchFilters := []interface{}{}
+	if trigger.TriggerConditions != nil {
+		for _, condition := range *trigger.TriggerConditions {
+			tags := []string{}
+			if condition.Tags != nil {
+				tags = *condition.Tags
+			}
+			branchFilters = append(branchFilters, map[string]interface{}{
+				"branch": converter.ToString(condition.SourceBranch, ""),
+				"tags":   tags,
+			})
+		}
+	}
+
+	return map[string]interface{}{
+		"artifact_alias": converter.ToString(trigger.ArtifactAlias, ""),
+		"branch_filter":  branchFilters,
+	}
+}
+
+func flattenSchedules(schedules *[]release.ReleaseSchedule) []interface{} {
+	if schedules == nil {
+		return nil
+	}
+
+	result := []interface{}{}
+	for _, releaseSchedule := range *schedules {
+		result = append(result, map[string]interface{}{
+			"days_to_release":            flattenScheduleDays(releaseSchedule.DaysToRelease),
+			"start_hours":                converter.ToInt(releaseSchedule.StartHours, 0),
+			"start_minutes":              converter.ToInt(releaseSchedule.StartMinutes, 0),
+			"time_zone_id":               converter.ToString(releaseSchedule.TimeZoneId, ""),
+			"schedule_only_with_changes": converter.ToBool(releaseSchedule.ScheduleOnlyWithChanges, false),
+		})
+	}
+	return result
+}
+
+func flattenScheduleDays(days *release.ScheduleDays) []string {
+	if days == nil {
+		return nil
+	}
+
+	releaseDays := map[string]bool{}
+	for _, day := range strings.Split(string(*days), ",") {
+		releaseDays[strings.ToLower(strings.TrimSpace(day))] = true
+	}
+
+	result := []string{}
+	for _, day := range scheduleDayOrder {
+		if releaseDays[string(release.ScheduleDaysValues.All)] || releaseDays[string(scheduleDays[day])] {
+			result = append(result, day)
+		}
+	}
+	return result
+}
+
+func flattenStages(d *schema.ResourceData, environments *[]release.ReleaseDefinitionEnvironment) ([]interface{}, error) {
+	if environments == nil {
+		return nil, nil
+	}
+
+	sortedEnvironments := make([]release.ReleaseDefinitionEnvironment, len(*environments))
+	copy(sortedEnvironments, *environments)
+	sort.SliceStable(sortedEnvironments, func(i, j int) bool {
+		return converter.ToInt(sortedEnvironments[i].Rank, 0) < converter.ToInt(sortedEnvironments[j].Rank, 0)
+	})
+
+	result := []interface{}{}
+	for index, environment := range sortedEnvironments {
+		stage := map[string]interface{}{
+			"id":                   converter.ToInt(environment.Id, 0),
+			"name":                 converter.ToString(environment.Name, ""),
+			"pre_deploy_approval":  flattenApprovals(environment.PreDeployApprovals),
+			"post_deploy_approval": flattenApprovals(environment.PostDeployApprovals),
+			"pre_deploy_gate":      flattenGates(environment.PreDeploymentGates),
+			"post_deploy_gate":     flattenGates(environment.PostDeploymentGates),
+			"schedule":             flattenSchedules(environment.Schedules),
+			"variable_groups":      flattenVariableGroups(environment.VariableGroups),
+			"retention_policy":     flattenRetentionPolicy(environment.RetentionPolicy),
+		}
+		if environment.Owner != nil {
+			stage["owner_id"] = converter.ToString(environment.Owner.Id, "")
+		}
+
+		stateVariables := schema.NewSet(schema.HashResource(variableSchema().Elem.(*schema.Resource)), nil)
+		if stateStageVariables, ok := d.GetOk(fmt.Sprintf("stage.%d.%s", index, rdVariable)); ok {
+			stateVariables = stateStageVariables.(*schema.Set)
+		}
+		stage[rdVariable] = flattenVariables(stateVariables, environment.Variables)
+
+		triggerManually, afterStages := flattenStageConditions(environment.Conditions)
+		stage["trigger_manually"] = triggerManually
+		stage["after_stages"] = afterStages
+
+		if err := flattenDeployPhases(stage, environment.DeployPhases); err != nil {
+			return nil, fmt.Errorf(" stage %s: %+v", converter.ToString(environment.Name, ""), err)
+		}
+		result = append(result, stage)
+	}
+	return result, nil
+}
+
+func flattenStageConditions(conditions *[]release.Condition) (bool, []string) {
+	if conditions == nil || len(*conditions) == 0 {
+		return true, nil
+	}
+
+	afterStages := []string{}
+	for _, condition := range *conditions {
+		if condition.ConditionType != nil && *condition.ConditionType == release.ConditionTypeValues.EnvironmentState {
+			afterStages = append(afterStages, converter.ToString(condition.Name, ""))
+		}
+	}
+	return false, afterStages
+}
+
+// flattenDeployPhases sets the agent job of the stage. The service returns deploy phases as untyped objects.
+func flattenDeployPhases(stage map[string]interface{}, deployPhases *[]interface{}) error {
+	if deployPhases == nil || len(*deployPhases) == 0 {
+		return nil
+	}
+
+	phaseAsJSON, err := json.Marshal((*deployPhases)[0])
+	if err != nil {
+		return fmt.Errorf(" serializing deploy phase: %+v", err)
+	}
+	var phase release.AgentBasedDeployPhase
+	if err := json.Unmarshal(phaseAsJSON, &phase); err != nil {
+		return fmt.Errorf(" deserializing deploy phase: %+v", err)
+	}
+
+	stage["job_name"] = converter.ToString(phase.Name, "")
+	stage["task"] = flattenWorkflowTasks(phase.WorkflowTasks)
+	if phase.DeploymentInput != nil {
+		stage["queue_id"] = converter.ToInt(phase.DeploymentInput.QueueId, 0)
+	}
+	return nil
+}
+
+func flattenWorkflowTasks(tasks *[]release.WorkflowTask) []interface{} {
+	if tasks == nil {
+		return nil
+	}
+
+	result := []interface{}{}
+	for _, task := range *tasks {
+		taskID := ""
+		if task.TaskId != nil {
+			taskID = task.TaskId.String()
+		}
+		inputs := map[string]string{}
+		if task.Inputs != nil {
+			inputs = *task.Inputs
+		}
+
+		result = append(result, map[string]interface{}{
+			"task_id":            taskID,
+			"version":            converter.ToString(task.Version, ""),
+			"display_name":       converter.ToString(task.Name, ""),
+			"enabled":            converter.ToBool(task.Enabled, true),
+			"continue_on_error":  converter.ToBool(task.ContinueOnError, false),
+			"condition":          converter.ToString(task.Condition, ""),
+			"timeout_in_minutes": converter.ToInt(task.TimeoutInMinutes, 0),
+			"inputs":             inputs,
+		})
+	}
+	return result
+}
+
+func flattenApprovals(approvals *release.ReleaseDefinitionApprovals) []interface{} {
+	if approvals == nil || approvals.Approvals == nil {
+		return nil
+	}
+
+	approvers := []string{}
+	for _, step := range *approvals.Approvals {
+		if converter.ToBool(step.IsAutomated, false) || step.Approver == nil {
+			continue
+		}
+		approvers = append(approvers, converter.ToString(step.Approver.Id, ""))
+	}
+	if len(approvers) == 0 {
+		return nil
+	}
+
+	approval := map[string]interface{}{
+		"approvers": approvers,
+	}
+	if options := approvals.ApprovalOptions; options != nil {
+		approval["required_approver_count"] = converter.ToInt(options.RequiredApproverCount, 0)
+		approval["timeout_in_minutes"] = converter.ToInt(options.TimeoutInMinutes, 0)
+		approval["release_creator_can_be_approver"] = converter.ToBool(options.ReleaseCreatorCanBeApprover, false)
+		if options.ExecutionOrder != nil {
+			approval["execution_order"] = string(*options.ExecutionOrder)
+		}
+	}
+	return []interface{}{approval}
+}
+
+func flattenGates(gates *release.ReleaseDefinitionGatesStep) []interface{} {
+	if gates == nil || gates.GatesOptions == nil || !converter.ToBool(gates.GatesOptions.IsEnabled, false) {
+		return nil
+	}
+
+	tasks := []interface{}{}
+	if gates.Gates != nil {
+		for _, gate := range *gates.Gates {
+			tasks = append(tasks, flattenWorkflowTasks(gate.Tasks)...)
+		}
+	}
+
+	options := gates.GatesOptions
+	return []interface{}{
+		map[string]interface{}{
+			"timeout_in_minutes":                  converter.ToInt(options.Timeout, 0),
+			"sampling_interval_in_minutes":        converter.ToInt(options.SamplingInterval, 0),
+			"stabilization_time_in_minutes":       converter.ToInt(options.StabilizationTime, 0),
+			"minimum_success_duration_in_minutes": converter.ToInt(options.MinimumSuccessDuration, 0),
+			"task":                                tasks,
+		},
+	}
+}
+
+func flattenRetentionPolicy(retentionPolicy *release.EnvironmentRetentionPolicy) []interface{} {
+	if retentionPolicy == nil {
+		return nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"days_to_keep":     converter.ToInt(retentionPolicy.DaysToKeep, 0),
+			"releases_to_keep": converter.ToInt(retentionPolicy.ReleasesToKeep, 0),
+			"retain_build":     converter.ToBool(retentionPolicy.RetainBuild, false),
+		},
+	}
+}
@@ -0,0 +1,259 @@
+//go:build (all || resource_release_definition) && !exclude_resource_release_definition
+// +build all resource_release_definition
+// +build !exclude_resource_release_definition
+
+package release
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/release"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/stretchr/testify/require"
+)
+
+var releaseDefinitionTestProjectID = uuid.New().String()
+var releaseDefinitionTestTaskID = uuid.New()
+var releaseDefinitionTestApproverID = uuid.New().String()
+
+func newReleaseDefinitionResourceData(t *testing.T) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, ResourceReleaseDefinition().Schema, map[string]interface{}{
+		"project_id": releaseDefinitionTestProjectID,
+		"name":       "release",
+		"artifact": []interface{}{
+			map[string]interface{}{
+				"alias":      "_build",
+				"type":       "Build",
+				"is_primary": true,
+				"definition_reference": map[string]interface{}{
+					"project":    releaseDefinitionTestProjectID,
+					"definition": "12",
+				},
+			},
+		},
+		"artifact_trigger": []interface{}{
+			map[string]interface{}{
+				"artifact_alias": "_build",
+				"branch_filter": []interface{}{
+					map[string]interface{}{"branch": "main", "tags": []interface{}{"release"}},
+				},
+			},
+		},
+		"schedule": []interface{}{
+			map[string]interface{}{
+				"days_to_release": []interface{}{"Mon", "Fri"},
+				"start_hours":     3,
+			},
+		},
+		"stage": []interface{}{
+			map[string]interface{}{
+				"name":     "dev",
+				"queue_id": 5,
+				"task": []interface{}{
+					map[string]interface{}{
+						"task_id": releaseDefinitionTestTaskID.String(),
+						"version": "2.*",
+						"inputs":  map[string]interface{}{"script": "echo dev"},
+					},
+				},
+			},
+			map[string]interface{}{
+				"name":         "prod",
+				"queue_id":     5,
+				"after_stages": []interface{}{"dev"},
+				"pre_deploy_approval": []interface{}{
+					map[string]interface{}{
+						"approvers":               []interface{}{releaseDefinitionTestApproverID},
+						"required_approver_count": 1,
+					},
+				},
+				"post_deploy_gate": []interface{}{
+					map[string]interface{}{
+						"timeout_in_minutes": 60,
+						"task": []interface{}{
+							map[string]interface{}{
+								"task_id": releaseDefinitionTestTaskID.String(),
+								"version": "1.*",
+							},
+						},
+					},
+				},
+				"retention_policy": []interface{}{
+					map[string]interface{}{"days_to_keep": 60, "releases_to_keep": 5, "retain_build": false},
+				},
+			},
+		},
+	})
+}
+
+// toUntyped mimics the service response in which triggers and deploy phases are untyped objects
+func toUntyped(t *testing.T, values *[]interface{}) *[]interface{} {
+	result := []interface{}{}
+	for _, value := range *values {
+		valueAsJSON, err := json.Marshal(value)
+		require.Nil(t, err)
+		var untyped map[string]interface{}
+		require.Nil(t, json.Unmarshal(valueAsJSON, &untyped))
+		result = append(result, untyped)
+	}
+	return &result
+}
+
+// verifies that stages, approvals, gates, triggers and retention are expanded
+func TestReleaseDefinition_Expand(t *testing.T) {
+	releaseDefinition, projectID, err := expandReleaseDefinition(newReleaseDefinitionResourceData(t))
+	require.Nil(t, err)
+	require.Equal(t, releaseDefinitionTestProjectID, projectID)
+	require.Nil(t, releaseDefinition.Id)
+
+	require.Len(t, *releaseDefinition.Artifacts, 1)
+	require.Equal(t, "12", *(*(*releaseDefinition.Artifacts)[0].DefinitionReference)["definition"].Id)
+
+	require.Len(t, *releaseDefinition.Triggers, 2)
+	artifactTrigger := (*releaseDefinition.Triggers)[0].(release.ArtifactSourceTrigger)
+	require.Equal(t, "_build", *artifactTrigger.ArtifactAlias)
+	require.Equal(t, "main", *(*artifactTrigger.TriggerConditions)[0].SourceBranch)
+	scheduledTrigger := (*releaseDefinition.Triggers)[1].(release.ScheduledReleaseTrigger)
+	require.Equal(t, release.ScheduleDays("monday, friday"), *scheduledTrigger.Schedule.DaysToRelease)
+	require.Equal(t, 3, *scheduledTrigger.Schedule.StartHours)
+
+	environments := *releaseDefinition.Environments
+	require.Len(t, environments, 2)
+
+	dev := environments[0]
+	require.Equal(t, 1, *dev.Rank)
+	require.Equal(t, release.ConditionTypeValues.Event, *(*dev.Conditions)[0].ConditionType)
+	require.True(t, *(*dev.PreDeployApprovals.Approvals)[0].IsAutomated)
+	require.False(t, *dev.PreDeploymentGates.GatesOptions.IsEnabled)
+	require.Equal(t, 30, *dev.RetentionPolicy.DaysToKeep)
+	phase := (*dev.DeployPhases)[0].(release.AgentBasedDeployPhase)
+	require.Equal(t, 5, *phase.DeploymentInput.QueueId)
+	require.Equal(t, "echo dev", (*(*phase.WorkflowTasks)[0].Inputs)["script"])
+
+	prod := environments[1]
+	require.Equal(t, release.ConditionTypeValues.EnvironmentState, *(*prod.Conditions)[0].ConditionType)
+	require.Equal(t, "dev", *(*prod.Conditions)[0].Name)
+	require.Equal(t, releaseDefinitionTestApproverID, *(*prod.PreDeployApprovals.Approvals)[0].Approver.Id)
+	require.Equal(t, 1, *prod.PreDeployApprovals.ApprovalOptions.RequiredApproverCount)
+	require.True(t, *prod.PostDeploymentGates.GatesOptions.IsEnabled)
+	require.Equal(t, 60, *prod.PostDeploymentGates.GatesOptions.Timeout)
+	require.Equal(t, 5, *prod.RetentionPolicy.ReleasesToKeep)
+	require.False(t, *prod.RetentionPolicy.RetainBuild)
+}
+
+// verifies that a stage cannot depend on a stage that is not part of the definition
+func TestReleaseDefinition_Expand_UnknownAfterStage(t *testing.T) {
+	resourceData := newReleaseDefinitionResourceData(t)
+	resourceData.Set("stage", []interface{}{
+		map[string]interface{}{"name": "prod", "queue_id": 5, "after_stages": []interface{}{"qa"}},
+	})
+
+	_, _, err := expandReleaseDefinition(resourceData)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "unknown stage qa")
+}
+
+// verifies that the untyped triggers and deploy phases returned by the service are flattened
+func TestReleaseDefinition_ExpandFlatten_Roundtrip(t *testing.T) {
+	releaseDefinition, _, err := expandReleaseDefinition(newReleaseDefinitionResourceData(t))
+	require.Nil(t, err)
+	releaseDefinition.Id = converter.Int(7)
+	releaseDefinition.Revision = converter.Int(2)
+	releaseDefinition.Triggers = toUntyped(t, releaseDefinition.Triggers)
+	for i := range *releaseDefinition.Environments {
+		environment := &(*releaseDefinition.Environments)[i]
+		environment.Id = converter.Int(i + 1)
+		environment.DeployPhases = toUntyped(t, environment.DeployPhases)
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, ResourceReleaseDefinition().Schema, nil)
+	require.Nil(t, flattenReleaseDefinition(resourceData, releaseDefinition, releaseDefinitionTestProjectID))
+
+	expected := newReleaseDefinitionResourceData(t)
+	require.Equal(t, "7", resourceData.Id())
+	require.Equal(t, 2, resourceData.Get("revision"))
+	require.Equal(t, expected.Get("artifact"), resourceData.Get("artifact"))
+	require.Equal(t, expected.Get("artifact_trigger"), resourceData.Get("artifact_trigger"))
+	require.Equal(t, expected.Get("schedule"), resourceData.Get("schedule"))
+	require.Equal(t, 2, resourceData.Get("stage.1.id"))
+	require.Equal(t, []interface{}{"dev"}, resourceData.Get("stage.1.after_stages"))
+	require.Equal(t, expected.Get("stage.0.task"), resourceData.Get("stage.0.task"))
+	require.Equal(t, expected.Get("stage.1.pre_deploy_approval"), resourceData.Get("stage.1.pre_deploy_approval"))
+	require.Equal(t, expected.Get("stage.1.post_deploy_gate"), resourceData.Get("stage.1.post_deploy_gate"))
+	require.Equal(t, expected.Get("stage.1.retention_policy"), resourceData.Get("stage.1.retention_policy"))
+	require.Empty(t, resourceData.Get("stage.0.pre_deploy_approval"))
+	require.Empty(t, resourceData.Get("stage.0.pre_deploy_gate"))
+}
+
+// verifies that if an error is produced on create, the error is not swallowed
+func TestReleaseDefinition_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	releaseClient := azdosdkmocks.NewMockReleaseClient(ctrl)
+	clients := &client.AggregatedClient{ReleaseClient: releaseClient, Ctx: context.Background()}
+
+	releaseClient.
+		EXPECT().
+		CreateReleaseDefinition(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("CreateReleaseDefinition() Failed")).
+		Times(1)
+
+	err := resourceReleaseDefinitionCreate(newReleaseDefinitionResourceData(t), clients)
+	require.Contains(t, err.Error(), "CreateReleaseDefinition() Failed")
+}
+
+// verifies that the update sends the ID and revision of the definition
+func TestReleaseDefinition_Update_SendsIDAndRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	releaseClient := azdosdkmocks.NewMockReleaseClient(ctrl)
+	clients := &client.AggregatedClient{ReleaseClient: releaseClient, Ctx: context.Background()}
+
+	releaseClient.
+		EXPECT().
+		UpdateReleaseDefinition(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, args release.UpdateReleaseDefinitionArgs) (*release.ReleaseDefinition, error) {
+			require.Equal(t, 7, *args.ReleaseDefinition.Id)
+			require.Equal(t, 3, *args.ReleaseDefinition.Revision)
+			return nil, errors.New("UpdateReleaseDefinition() Failed")
+		}).
+		Times(1)
+
+	resourceData := newReleaseDefinitionResourceData(t)
+	resourceData.SetId("7")
+	resourceData.Set("revision", 3)
+	err := resourceReleaseDefinitionUpdate(resourceData, clients)
+	require.Contains(t, err.Error(), "UpdateReleaseDefinition() Failed")
+}
+
+// verifies that a release definition that no longer exists is removed from the state
+func TestReleaseDefinition_Read_RemovesDeletedDefinition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	releaseClient := azdosdkmocks.NewMockReleaseClient(ctrl)
+	clients := &client.AggregatedClient{ReleaseClient: releaseClient, Ctx: context.Background()}
+
+	releaseClient.
+		EXPECT().
+		GetReleaseDefinition(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(404)}).
+		Times(1)
+
+	resourceData := newReleaseDefinitionResourceData(t)
+	resourceData.SetId("7")
+	err := resourceReleaseDefinitionRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
@@ -1,19 +1,30 @@
 package serviceendpoint
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/serviceendpoint"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
 )
 
+const (
+	azureRMAuthSchemeServicePrincipal           = "ServicePrincipal"
+	azureRMAuthSchemeWorkloadIdentityFederation = "WorkloadIdentityFederation"
+)
+
 // ResourceServiceEndpointAzureRM schema and implementation for AzureRM service endpoint resource
 func ResourceServiceEndpointAzureRM() *schema.Resource {
 	r := genBaseServiceEndpointResource(flattenServiceEndpointAzureRM, expandServiceEndpointAzureRM)
+	r.Update = resourceServiceEndpointAzureRMUpdate
+	r.CustomizeDiff = customizeServiceEndpointAzureRMDiff
 	makeUnprotectedSchema(r, "azurerm_spn_tenantid", "ARM_TENANT_ID", "The service principal tenant id which should be used.")
 
 	r.Schema["resource_group"] = &schema.Schema{
@@ -46,13 +57,30 @@ func ResourceServiceEndpointAzureRM() *schema.Resource {
 				},
 				"serviceprincipalkey": {
 					Type:        schema.TypeString,
-					Required:    true,
-					Description: "The service principal secret which should be used.",
+					Optional:    true,
+					Description: "The service principal secret which should be used. Not used with workload identity federation.",
 					Sensitive:   true,
 				},
 			},
 		},
 	}
+	r.Schema["service_endpoint_authentication_scheme"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      azureRMAuthSchemeServicePrincipal,
+		Description:  "The authentication scheme used by the service endpoint",
+		ValidateFunc: validation.StringInSlice([]string{azureRMAuthSchemeServicePrincipal, azureRMAuthSchemeWorkloadIdentityFederation}, false),
+	}
+	r.Schema["workload_identity_federation_issuer"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The issuer of the federated credential, when the workload identity federation scheme is used",
+	}
+	r.Schema["workload_identity_federation_subject"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The subject of the federated credential, when the workload identity federation scheme is used",
+	}
 	r.Schema["environment"] = &schema.Schema{
 		Type:         schema.TypeString,
 		Optional:     true,
@@ -64,6 +92,24 @@ func ResourceServiceEndpointAzureRM() *schema.Resource {
 	return r
 }
 
+// customizeServiceEndpointAzureRMDiff requires the service principal secret of manually created endpoints,
+// unless workload identity federation is used
+func customizeServiceEndpointAzureRMDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if _, ok := d.GetOk("credentials"); !ok {
+		return nil
+	}
+	if d.Get("service_endpoint_authentication_scheme").(string) == azureRMAuthSchemeWorkloadIdentityFederation {
+		return nil
+	}
+	if !d.NewValueKnown("credentials.0.serviceprincipalkey") {
+		return nil
+	}
+	if d.Get("credentials.0.serviceprincipalkey").(string) == "" {
+		return fmt.Errorf("credentials.0.serviceprincipalkey is required with the %s authentication scheme", azureRMAuthSchemeServicePrincipal)
+	}
+	return nil
+}
+
 // Convert internal Terraform data structure to an AzDO data structure
 func expandServiceEndpointAzureRM(d *schema.ResourceData) (*serviceendpoint.ServiceEndpoint, *uuid.UUID, error) {
 	serviceEndpoint, projectID := doBaseExpansion(d)
@@ -97,14 +143,26 @@ func expandServiceEndpointAzureRM(d *schema.ResourceData) (*serviceendpoint.Serv
 		}
 	}
 
-	serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
-		Parameters: &map[string]string{
-			"authenticationType":  "spnKey",
-			"serviceprincipalid":  "",
-			"serviceprincipalkey": "",
-			"tenantid":            d.Get("azurerm_spn_tenantid").(string),
-		},
-		Scheme: converter.String("ServicePrincipal"),
+	authScheme := d.Get("service_endpoint_authentication_scheme").(string)
+	if authScheme == azureRMAuthSchemeWorkloadIdentityFederation {
+		// Workload identity federation never uses a secret, the issuer and subject are generated by the service
+		serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
+			Parameters: &map[string]string{
+				"serviceprincipalid": "",
+				"tenantid":           d.Get("azurerm_spn_tenantid").(string),
+			},
+			Scheme: converter.String(azureRMAuthSchemeWorkloadIdentityFederation),
+		}
+	} else {
+		serviceEndpoint.Authorization = &serviceendpoint.EndpointAuthorization{
+			Parameters: &map[string]string{
+				"authenticationType":  "spnKey",
+				"serviceprincipalid":  "",
+				"serviceprincipalkey": "",
+				"tenantid":            d.Get("azurerm_spn_tenantid").(string),
+			},
+			Scheme: converter.String(azureRMAuthSchemeServicePrincipal),
+		}
 	}
 	var endpointUrl string
 	if environment == "AzureCloud" {
@@ -136,7 +194,13 @@ func expandServiceEndpointAzureRM(d *schema.ResourceData) (*serviceendpoint.Serv
 	if _, ok := d.GetOk("credentials"); ok {
 		credentials := d.Get("credentials").([]interface{})[0].(map[string]interface{})
 		(*serviceEndpoint.Authorization.Parameters)["serviceprincipalid"] = credentials["serviceprincipalid"].(string)
-		(*serviceEndpoint.Authorization.Parameters)["serviceprincipalkey"] = credentials["serviceprincipalkey"].(string)
+		if authScheme == azureRMAuthSchemeWorkloadIdentityFederation {
+			if credentials["serviceprincipalkey"].(string) != "" {
+				return nil, nil, fmt.Errorf("serviceprincipalkey can not be used with the %s authentication scheme", azureRMAuthSchemeWorkloadIdentityFederation)
+			}
+		} else {
+			(*serviceEndpoint.Authorization.Parameters)["serviceprincipalkey"] = credentials["serviceprincipalkey"].(string)
+		}
 		(*serviceEndpoint.Data)["creationMode"] = "Manual"
 	}
 
@@ -175,6 +239,12 @@ func flattenServiceEndpointAzureRM(d *schema.ResourceData, serviceEndpoint *serv
 
 	d.Set("azurerm_spn_tenantid", (*serviceEndpoint.Authorization.Parameters)["tenantid"])
 
+	if serviceEndpoint.Authorization.Scheme != nil {
+		d.Set("service_endpoint_authentication_scheme", *serviceEndpoint.Authorization.Scheme)
+	}
+	d.Set("workload_identity_federation_issuer", (*serviceEndpoint.Authorization.Parameters)["workloadIdentityFederationIssuer"])
+	d.Set("workload_identity_federation_subject", (*serviceEndpoint.Authorization.Parameters)["workloadIdentityFederationSubject"])
+
 	if _, ok := (*serviceEndpoint.Data)["managementGroupId"]; ok {
 		d.Set("azurerm_management_group_id", (*serviceEndpoint.Data)["managementGroupId"])
 		d.Set("azurerm_management_group_name", (*serviceEndpoint.Data)["managementGroupName"])
@@ -186,6 +256,48 @@ func flattenServiceEndpointAzureRM(d *schema.ResourceData, serviceEndpoint *serv
 	}
 }
 
+// resourceServiceEndpointAzureRMUpdate updates the endpoint, switching the authentication scheme
+// of an existing endpoint is done in place by the service through a conversion operation
+func resourceServiceEndpointAzureRMUpdate(d *schema.ResourceData, m interface{}) error {
+	oldScheme, newScheme := d.GetChange("service_endpoint_authentication_scheme")
+	if oldScheme.(string) == "" || oldScheme.(string) == newScheme.(string) {
+		return genServiceEndpointUpdateFunc(flattenServiceEndpointAzureRM, expandServiceEndpointAzureRM)(d, m)
+	}
+
+	clients := m.(*client.AggregatedClient)
+	serviceEndpoint, projectID, err := expandServiceEndpointAzureRM(d)
+	if err != nil {
+		return fmt.Errorf(errMsgTfConfigRead, err)
+	}
+
+	_, err = clients.ServiceEndpointClient.UpdateServiceEndpoint(
+		clients.Ctx,
+		serviceendpoint.UpdateServiceEndpointArgs{
+			Endpoint:   serviceEndpoint,
+			EndpointId: serviceEndpoint.Id,
+			Operation:  converter.String("ConvertAuthenticationScheme"),
+		})
+	if err != nil {
+		return fmt.Errorf("Error converting the authentication scheme of service endpoint in Azure DevOps: %+v", err)
+	}
+
+	// The conversion of endpoints which were created automatically also updates the service principal in Azure AD
+	stateConf := &resource.StateChangeConf{
+		ContinuousTargetOccurence: 1,
+		Delay:                     5 * time.Second,
+		MinTimeout:                5 * time.Second,
+		Pending:                   []string{opState.InProgress},
+		Target:                    []string{opState.Ready, opState.Failed},
+		Refresh:                   getServiceEndpoint(clients, serviceEndpoint.Id, projectID),
+		Timeout:                   d.Timeout(schema.TimeoutUpdate),
+	}
+	if _, err := stateConf.WaitForState(); err != nil { //nolint:staticcheck
+		return fmt.Errorf(" waiting for service endpoint ready. %v ", err)
+	}
+
+	return genServiceEndpointReadFunc(flattenServiceEndpointAzureRM)(d, m)
+}
+
 // Validation function to ensure either Subscription or ManagementGroup scopeLevels are set correctly
 func validateScopeLevel(scopeMap map[string][]string) error {
 	// Check for empty
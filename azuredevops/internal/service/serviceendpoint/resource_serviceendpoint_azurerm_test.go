@@ -12,6 +12,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/serviceendpoint"
 	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
@@ -255,6 +256,135 @@ func TestServiceEndpointAzureRM_Update_DoesNotSwallowError(t *testing.T) {
 	}
 }
 
+func getWorkloadIdentityFederationServiceEndpoint() serviceendpoint.ServiceEndpoint {
+	endpoint := getManualAuthServiceEndpoint()
+	endpoint.Authorization = &serviceendpoint.EndpointAuthorization{
+		Parameters: &map[string]string{
+			"serviceprincipalid": "e31eaaac-47da-4156-b433-9b0538c94b7e", //fake value
+			"tenantid":           "aba07645-051c-44b4-b806-c34d33f3dcd1", //fake value
+		},
+		Scheme: converter.String("WorkloadIdentityFederation"),
+	}
+	return endpoint
+}
+
+// verifies that the issuer and subject generated by the service are exposed and never sent back
+func TestServiceEndpointAzureRM_WorkloadIdentityFederation_ExpandFlatten_Roundtrip(t *testing.T) {
+	expectedEndpoint := getWorkloadIdentityFederationServiceEndpoint()
+	endpoint := getWorkloadIdentityFederationServiceEndpoint()
+	(*endpoint.Authorization.Parameters)["workloadIdentityFederationIssuer"] = "https://vstoken.dev.azure.com/00000000-0000-0000-0000-000000000000"
+	(*endpoint.Authorization.Parameters)["workloadIdentityFederationSubject"] = "sc://organization/project/_AZURERM_UNIT_TEST_CONN_NAME"
+
+	resourceData := getResourceData(t, endpoint)
+	flattenServiceEndpointAzureRM(resourceData, &endpoint, azurermTestServiceEndpointAzureRMProjectID)
+	require.Equal(t, "WorkloadIdentityFederation", resourceData.Get("service_endpoint_authentication_scheme"))
+	require.Equal(t, "https://vstoken.dev.azure.com/00000000-0000-0000-0000-000000000000", resourceData.Get("workload_identity_federation_issuer"))
+	require.Equal(t, "sc://organization/project/_AZURERM_UNIT_TEST_CONN_NAME", resourceData.Get("workload_identity_federation_subject"))
+
+	serviceEndpointAfterRoundTrip, projectID, err := expandServiceEndpointAzureRM(resourceData)
+	require.Nil(t, err)
+	require.Equal(t, expectedEndpoint, *serviceEndpointAfterRoundTrip)
+	require.Equal(t, azurermTestServiceEndpointAzureRMProjectID, projectID)
+}
+
+// verifies that a secret can not be configured together with workload identity federation
+func TestServiceEndpointAzureRM_WorkloadIdentityFederation_RejectsSecret(t *testing.T) {
+	endpoint := getWorkloadIdentityFederationServiceEndpoint()
+	resourceData := getResourceData(t, endpoint)
+	flattenServiceEndpointAzureRM(resourceData, &endpoint, azurermTestServiceEndpointAzureRMProjectID)
+	resourceData.Set("credentials", []map[string]interface{}{{
+		"serviceprincipalid":  (*endpoint.Authorization.Parameters)["serviceprincipalid"],
+		"serviceprincipalkey": "secret",
+	}})
+
+	_, _, err := expandServiceEndpointAzureRM(resourceData)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "serviceprincipalkey")
+}
+
+// verifies that the service principal secret is required for manual endpoints, unless workload identity federation is used
+func TestServiceEndpointAzureRM_Diff_RequiresSecretForServicePrincipal(t *testing.T) {
+	r := ResourceServiceEndpointAzureRM()
+	config := func(authScheme string, credentials map[string]interface{}) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"project_id":                             azurermTestServiceEndpointAzureRMProjectID.String(),
+			"service_endpoint_name":                  "_AZURERM_UNIT_TEST_CONN_NAME",
+			"azurerm_spn_tenantid":                   "aba07645-051c-44b4-b806-c34d33f3dcd1",
+			"azurerm_subscription_id":                "42125daf-72fd-417c-9ea7-080690625ad3",
+			"azurerm_subscription_name":              "SUBSCRIPTION_TEST",
+			"service_endpoint_authentication_scheme": authScheme,
+			"credentials":                            []interface{}{credentials},
+		})
+	}
+
+	_, err := r.Diff(context.Background(), nil, config("ServicePrincipal", map[string]interface{}{
+		"serviceprincipalid": "e31eaaac-47da-4156-b433-9b0538c94b7e",
+	}), nil)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "serviceprincipalkey is required")
+
+	_, err = r.Diff(context.Background(), nil, config("ServicePrincipal", map[string]interface{}{
+		"serviceprincipalid":  "e31eaaac-47da-4156-b433-9b0538c94b7e",
+		"serviceprincipalkey": "secret",
+	}), nil)
+	require.Nil(t, err)
+
+	_, err = r.Diff(context.Background(), nil, config("WorkloadIdentityFederation", map[string]interface{}{
+		"serviceprincipalid": "e31eaaac-47da-4156-b433-9b0538c94b7e",
+	}), nil)
+	require.Nil(t, err)
+}
+
+// verifies that a change of the authentication scheme converts the existing endpoint
+func TestServiceEndpointAzureRM_Update_ConvertsAuthenticationScheme(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := ResourceServiceEndpointAzureRM()
+	state := &terraform.InstanceState{
+		ID: azurermTestServiceEndpointAzureRMID.String(),
+		Attributes: map[string]string{
+			"project_id":                             azurermTestServiceEndpointAzureRMProjectID.String(),
+			"service_endpoint_name":                  "_AZURERM_UNIT_TEST_CONN_NAME",
+			"service_endpoint_authentication_scheme": "ServicePrincipal",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id":                             azurermTestServiceEndpointAzureRMProjectID.String(),
+		"service_endpoint_name":                  "_AZURERM_UNIT_TEST_CONN_NAME",
+		"azurerm_spn_tenantid":                   "aba07645-051c-44b4-b806-c34d33f3dcd1",
+		"azurerm_subscription_id":                "42125daf-72fd-417c-9ea7-080690625ad3",
+		"azurerm_subscription_name":              "SUBSCRIPTION_TEST",
+		"service_endpoint_authentication_scheme": "WorkloadIdentityFederation",
+	})
+	diff, err := r.Diff(context.Background(), state, config, nil)
+	require.Nil(t, err)
+	resourceData, err := schema.InternalMap(r.Schema).Data(state, diff)
+	require.Nil(t, err)
+
+	endpoint, _, err := expandServiceEndpointAzureRM(resourceData)
+	require.Nil(t, err)
+	require.Equal(t, "WorkloadIdentityFederation", *endpoint.Authorization.Scheme)
+
+	buildClient := azdosdkmocks.NewMockServiceendpointClient(ctrl)
+	clients := &client.AggregatedClient{ServiceEndpointClient: buildClient, Ctx: context.Background()}
+
+	expectedArgs := serviceendpoint.UpdateServiceEndpointArgs{
+		Endpoint:   endpoint,
+		EndpointId: endpoint.Id,
+		Operation:  converter.String("ConvertAuthenticationScheme"),
+	}
+
+	buildClient.
+		EXPECT().
+		UpdateServiceEndpoint(clients.Ctx, expectedArgs).
+		Return(nil, errors.New("UpdateServiceEndpoint() Failed")).
+		Times(1)
+
+	err = r.Update(resourceData, clients)
+	require.Contains(t, err.Error(), "UpdateServiceEndpoint() Failed")
+}
+
 // This is a little different than most. The steps done, along with the motivation behind each, are as follows:
 //	(1) The service endpoint is configured. The `serviceprincipalkey` is set to `""`, which matches
 //		the Azure DevOps API behavior. The service will intentionally hide the value of
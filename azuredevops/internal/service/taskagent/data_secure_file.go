@@ -0,0 +1,67 @@
+package taskagent
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/taskagentextras"
+)
+
+// DataSecureFile schema and implementation for secure file data source
+func DataSecureFile() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSecureFileRead,
+		Schema: map[string]*schema.Schema{
+			vgProjectID: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			vgName: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			sfProperties: {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSecureFileRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get(vgProjectID).(string)
+	name := d.Get(vgName).(string)
+
+	secureFiles, err := clients.TaskAgentClientExtras.GetSecureFiles(clients.Ctx, taskagentextras.GetSecureFilesArgs{
+		Project:     &projectID,
+		NamePattern: &name,
+	})
+	if err != nil {
+		return fmt.Errorf(" reading secure files: %+v", err)
+	}
+
+	if secureFiles != nil {
+		for _, secureFile := range *secureFiles {
+			if secureFile.Name == nil || !strings.EqualFold(*secureFile.Name, name) {
+				continue
+			}
+
+			d.SetId(secureFile.Id.String())
+			d.Set(vgName, secureFile.Name)
+			if secureFile.Properties != nil {
+				d.Set(sfProperties, *secureFile.Properties)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("Unable to find secure file with name: %s", name)
+}
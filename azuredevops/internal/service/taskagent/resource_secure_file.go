@@ -0,0 +1,335 @@
+package taskagent
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/build"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/taskagentextras"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/tfhelper"
+)
+
+const (
+	sfFilePath      = "file_path"
+	sfContentBase64 = "content_base64"
+	sfContentSha256 = "content_sha256"
+	sfProperties    = "properties"
+
+	secureFileResourceType = "securefile"
+)
+
+// ResourceSecureFile schema and implementation for secure file resource
+func ResourceSecureFile() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceSecureFileCreate,
+		Read:          resourceSecureFileRead,
+		Update:        resourceSecureFileUpdate,
+		Delete:        resourceSecureFileDelete,
+		CustomizeDiff: customizeSecureFileDiff,
+		Importer:      tfhelper.ImportProjectQualifiedResourceUUID(),
+		Schema: map[string]*schema.Schema{
+			vgProjectID: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+			vgName: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			sfFilePath: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				ExactlyOneOf: []string{sfFilePath, sfContentBase64},
+			},
+			sfContentBase64: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsBase64,
+			},
+			sfContentSha256: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			sfProperties: {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			vgAllowAccess: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+// customizeSecureFileDiff detects content changes by comparing the hash of the configured content with the hash of
+// the uploaded content. Secure files cannot be overwritten, so a content change replaces the secure file.
+func customizeSecureFileDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown(sfFilePath) || !d.NewValueKnown(sfContentBase64) {
+		if err := d.SetNewComputed(sfContentSha256); err != nil || d.Id() == "" {
+			return err
+		}
+		return d.ForceNew(sfContentSha256)
+	}
+
+	content, err := readSecureFileContent(d.Get(sfFilePath).(string), d.Get(sfContentBase64).(string))
+	if err != nil {
+		return err
+	}
+
+	contentSha256 := hashSecureFileContent(content)
+	if d.Get(sfContentSha256).(string) == contentSha256 {
+		return nil
+	}
+	if err := d.SetNew(sfContentSha256, contentSha256); err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	return d.ForceNew(sfContentSha256)
+}
+
+func resourceSecureFileCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get(vgProjectID).(string)
+
+	content, err := readSecureFileContent(d.Get(sfFilePath).(string), d.Get(sfContentBase64).(string))
+	if err != nil {
+		return err
+	}
+
+	secureFile, err := clients.TaskAgentClientExtras.UploadSecureFile(clients.Ctx, taskagentextras.UploadSecureFileArgs{
+		UploadStream: bytes.NewReader(content),
+		Project:      &projectID,
+		Name:         converter.String(d.Get(vgName).(string)),
+	})
+	if err != nil {
+		return fmt.Errorf(" uploading secure file: %+v", err)
+	}
+	d.SetId(secureFile.Id.String())
+
+	// Properties cannot be set on upload
+	if properties := expandSecureFileProperties(d); properties != nil {
+		secureFile.Properties = properties
+		_, err = clients.TaskAgentClientExtras.UpdateSecureFile(clients.Ctx, taskagentextras.UpdateSecureFileArgs{
+			SecureFile:   secureFile,
+			Project:      &projectID,
+			SecureFileId: secureFile.Id,
+		})
+		if err != nil {
+			return fmt.Errorf(" setting properties of secure file: %+v", err)
+		}
+	}
+
+	if err := updateSecureFileAllowAccess(d, clients, secureFile); err != nil {
+		return fmt.Errorf(" authorizing secure file: %+v", err)
+	}
+
+	return resourceSecureFileRead(d, m)
+}
+
+func resourceSecureFileRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get(vgProjectID).(string)
+
+	secureFileID, err := uuid.Parse(d.Id())
+	if err != nil {
+		return fmt.Errorf(" parsing secure file ID: %+v", err)
+	}
+
+	secureFile, err := clients.TaskAgentClientExtras.GetSecureFile(clients.Ctx, taskagentextras.GetSecureFileArgs{
+		Project:               &projectID,
+		SecureFileId:          &secureFileID,
+		IncludeDownloadTicket: converter.Bool(true),
+	})
+	if err != nil {
+		if utils.ResponseWasNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf(" reading secure file with ID %s: %+v", d.Id(), err)
+	}
+	if secureFile == nil || secureFile.Id == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(vgName, secureFile.Name)
+	if secureFile.Properties != nil {
+		d.Set(sfProperties, *secureFile.Properties)
+	} else {
+		d.Set(sfProperties, nil)
+	}
+
+	if secureFile.Ticket != nil && *secureFile.Ticket != "" {
+		contentSha256, err := downloadSecureFileSha256(clients, projectID, secureFile)
+		if err != nil {
+			return fmt.Errorf(" downloading secure file with ID %s: %+v", d.Id(), err)
+		}
+		d.Set(sfContentSha256, contentSha256)
+	} else {
+		log.Printf("[WARN] No download ticket returned for secure file with ID %s. Skipping content drift detection", d.Id())
+	}
+
+	projectResources, err := clients.BuildClient.GetProjectResources(clients.Ctx, build.GetProjectResourcesArgs{
+		Project: &projectID,
+		Type:    converter.String(secureFileResourceType),
+		Id:      converter.String(d.Id()),
+	})
+	if err != nil {
+		return fmt.Errorf(" looking up project resources for secure file with ID %s: %+v", d.Id(), err)
+	}
+	flattenAllowAccess(d, projectResources)
+	return nil
+}
+
+func resourceSecureFileUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get(vgProjectID).(string)
+
+	secureFileID, err := uuid.Parse(d.Id())
+	if err != nil {
+		return fmt.Errorf(" parsing secure file ID: %+v", err)
+	}
+
+	secureFile := &taskagent.SecureFile{
+		Id:         &secureFileID,
+		Name:       converter.String(d.Get(vgName).(string)),
+		Properties: expandSecureFileProperties(d),
+	}
+	if secureFile.Properties == nil {
+		secureFile.Properties = &map[string]string{}
+	}
+
+	if d.HasChanges(vgName, sfProperties) {
+		_, err = clients.TaskAgentClientExtras.UpdateSecureFile(clients.Ctx, taskagentextras.UpdateSecureFileArgs{
+			SecureFile:   secureFile,
+			Project:      &projectID,
+			SecureFileId: &secureFileID,
+		})
+		if err != nil {
+			return fmt.Errorf(" updating secure file with ID %s: %+v", d.Id(), err)
+		}
+	}
+
+	if d.HasChange(vgAllowAccess) {
+		if err := updateSecureFileAllowAccess(d, clients, secureFile); err != nil {
+			return fmt.Errorf(" authorizing secure file: %+v", err)
+		}
+	}
+
+	return resourceSecureFileRead(d, m)
+}
+
+func resourceSecureFileDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(*client.AggregatedClient)
+	projectID := d.Get(vgProjectID).(string)
+
+	secureFileID, err := uuid.Parse(d.Id())
+	if err != nil {
+		return fmt.Errorf(" parsing secure file ID: %+v", err)
+	}
+
+	err = clients.TaskAgentClientExtras.DeleteSecureFile(clients.Ctx, taskagentextras.DeleteSecureFileArgs{
+		Project:      &projectID,
+		SecureFileId: &secureFileID,
+	})
+	if err != nil {
+		return fmt.Errorf(" deleting secure file with ID %s: %+v", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func readSecureFileContent(filePath string, contentBase64 string) ([]byte, error) {
+	if filePath != "" {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf(" reading secure file content from %s: %+v", filePath, err)
+		}
+		return content, nil
+	}
+
+	content, err := base64.StdEncoding.DecodeString(contentBase64)
+	if err != nil {
+		return nil, fmt.Errorf(" decoding secure file content: %+v", err)
+	}
+	return content, nil
+}
+
+func hashSecureFileContent(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+func downloadSecureFileSha256(clients *client.AggregatedClient, projectID string, secureFile *taskagent.SecureFile) (string, error) {
+	content, err := clients.TaskAgentClientExtras.DownloadSecureFile(clients.Ctx, taskagentextras.DownloadSecureFileArgs{
+		Project:      &projectID,
+		SecureFileId: secureFile.Id,
+		Ticket:       secureFile.Ticket,
+	})
+	if err != nil {
+		return "", err
+	}
+	defer content.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func expandSecureFileProperties(d *schema.ResourceData) *map[string]string {
+	properties, ok := d.GetOk(sfProperties)
+	if !ok {
+		return nil
+	}
+
+	result := map[string]string{}
+	for key, value := range properties.(map[string]interface{}) {
+		result[key] = value.(string)
+	}
+	return &result
+}
+
+func updateSecureFileAllowAccess(d *schema.ResourceData, clients *client.AggregatedClient, secureFile *taskagent.SecureFile) error {
+	projectID := d.Get(vgProjectID).(string)
+	_, err := clients.BuildClient.AuthorizeProjectResources(clients.Ctx, build.AuthorizeProjectResourcesArgs{
+		Resources: &[]build.DefinitionResourceReference{
+			{
+				Type:       converter.String(secureFileResourceType),
+				Authorized: converter.Bool(d.Get(vgAllowAccess).(bool)),
+				Name:       secureFile.Name,
+				Id:         converter.String(secureFile.Id.String()),
+			},
+		},
+		Project: &projectID,
+	})
+	return err
+}
@@ -0,0 +1,244 @@
+//go:build (all || resource_secure_file) && !exclude_resource_secure_file
+// +build all resource_secure_file
+// +build !exclude_resource_secure_file
+
+package taskagent
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/build"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/taskagent"
+	"github.com/microsoft/terraform-provider-azuredevops/azdosdkmocks"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/client"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/converter"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/taskagentextras"
+	mock_taskagentextras "github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/taskagentextras/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+var secureFileTestProjectID = uuid.New().String()
+var secureFileTestID = uuid.New()
+
+const secureFileTestContent = "apiVersion: v1\nkind: Config\n"
+
+// sha256 of secureFileTestContent
+var secureFileTestContentSha256 = hashSecureFileContent([]byte(secureFileTestContent))
+
+func newSecureFileTestClients(ctrl *gomock.Controller) (*client.AggregatedClient, *mock_taskagentextras.TaskAgentClientExtras, *azdosdkmocks.MockBuildClient) {
+	taskAgentClient := mock_taskagentextras.NewTaskAgentClientExtras(ctrl)
+	buildClient := azdosdkmocks.NewMockBuildClient(ctrl)
+	clients := &client.AggregatedClient{
+		TaskAgentClientExtras: taskAgentClient,
+		BuildClient:           buildClient,
+		Ctx:                   context.Background(),
+	}
+	return clients, taskAgentClient, buildClient
+}
+
+func newSecureFileResourceData(t *testing.T) *schema.ResourceData {
+	resourceData := schema.TestResourceDataRaw(t, ResourceSecureFile().Schema, nil)
+	resourceData.Set(vgProjectID, secureFileTestProjectID)
+	resourceData.Set(vgName, "kubeconfig")
+	resourceData.Set(sfContentBase64, base64.StdEncoding.EncodeToString([]byte(secureFileTestContent)))
+	return resourceData
+}
+
+// verifies that the content is uploaded and that properties and access are applied after the upload
+func TestSecureFile_Create_UploadsContentAndSetsProperties(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clients, taskAgentClient, buildClient := newSecureFileTestClients(ctrl)
+	secureFile := &taskagent.SecureFile{
+		Id:     &secureFileTestID,
+		Name:   converter.String("kubeconfig"),
+		Ticket: converter.String("ticket"),
+	}
+
+	taskAgentClient.
+		EXPECT().
+		UploadSecureFile(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, args taskagentextras.UploadSecureFileArgs) (*taskagent.SecureFile, error) {
+			require.Equal(t, secureFileTestProjectID, *args.Project)
+			require.Equal(t, "kubeconfig", *args.Name)
+			content, err := io.ReadAll(args.UploadStream)
+			require.Nil(t, err)
+			require.Equal(t, secureFileTestContent, string(content))
+			return secureFile, nil
+		}).
+		Times(1)
+	taskAgentClient.
+		EXPECT().
+		UpdateSecureFile(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, args taskagentextras.UpdateSecureFileArgs) (*taskagent.SecureFile, error) {
+			require.Equal(t, map[string]string{"cluster": "prod"}, *args.SecureFile.Properties)
+			return args.SecureFile, nil
+		}).
+		Times(1)
+	buildClient.
+		EXPECT().
+		AuthorizeProjectResources(clients.Ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, args build.AuthorizeProjectResourcesArgs) (*[]build.DefinitionResourceReference, error) {
+			require.Len(t, *args.Resources, 1)
+			require.Equal(t, "securefile", *(*args.Resources)[0].Type)
+			require.Equal(t, secureFileTestID.String(), *(*args.Resources)[0].Id)
+			require.True(t, *(*args.Resources)[0].Authorized)
+			return args.Resources, nil
+		}).
+		Times(1)
+	taskAgentClient.
+		EXPECT().
+		GetSecureFile(clients.Ctx, gomock.Any()).
+		Return(&taskagent.SecureFile{
+			Id:         &secureFileTestID,
+			Name:       converter.String("kubeconfig"),
+			Properties: &map[string]string{"cluster": "prod"},
+			Ticket:     converter.String("ticket"),
+		}, nil).
+		Times(1)
+	taskAgentClient.
+		EXPECT().
+		DownloadSecureFile(clients.Ctx, gomock.Any()).
+		Return(io.NopCloser(strings.NewReader(secureFileTestContent)), nil).
+		Times(1)
+	buildClient.
+		EXPECT().
+		GetProjectResources(clients.Ctx, gomock.Any()).
+		Return(&[]build.DefinitionResourceReference{
+			{Id: converter.String(secureFileTestID.String()), Authorized: converter.Bool(true)},
+		}, nil).
+		Times(1)
+
+	resourceData := newSecureFileResourceData(t)
+	resourceData.Set(sfProperties, map[string]interface{}{"cluster": "prod"})
+	resourceData.Set(vgAllowAccess, true)
+
+	err := resourceSecureFileCreate(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, secureFileTestID.String(), resourceData.Id())
+	require.Equal(t, secureFileTestContentSha256, resourceData.Get(sfContentSha256))
+	require.True(t, resourceData.Get(vgAllowAccess).(bool))
+}
+
+// verifies that if an error is produced on upload, the error is not swallowed
+func TestSecureFile_Create_DoesNotSwallowError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clients, taskAgentClient, _ := newSecureFileTestClients(ctrl)
+	taskAgentClient.
+		EXPECT().
+		UploadSecureFile(clients.Ctx, gomock.Any()).
+		Return(nil, errors.New("UploadSecureFile() Failed")).
+		Times(1)
+
+	err := resourceSecureFileCreate(newSecureFileResourceData(t), clients)
+	require.Contains(t, err.Error(), "UploadSecureFile() Failed")
+}
+
+// verifies that the content is read from the file path
+func TestSecureFile_ReadSecureFileContent_FromFilePath(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "kubeconfig")
+	require.Nil(t, os.WriteFile(filePath, []byte(secureFileTestContent), 0600))
+
+	content, err := readSecureFileContent(filePath, "")
+	require.Nil(t, err)
+	require.Equal(t, secureFileTestContent, string(content))
+
+	_, err = readSecureFileContent(filepath.Join(t.TempDir(), "missing"), "")
+	require.NotNil(t, err)
+}
+
+// verifies that a secure file that no longer exists is removed from the state
+func TestSecureFile_Read_RemovesDeletedSecureFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clients, taskAgentClient, _ := newSecureFileTestClients(ctrl)
+	taskAgentClient.
+		EXPECT().
+		GetSecureFile(clients.Ctx, gomock.Any()).
+		Return(nil, azuredevops.WrappedError{StatusCode: converter.Int(404)}).
+		Times(1)
+
+	resourceData := newSecureFileResourceData(t)
+	resourceData.SetId(secureFileTestID.String())
+	err := resourceSecureFileRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, "", resourceData.Id())
+}
+
+// verifies that a change of the content replaces the secure file while a change of the name does not
+func TestSecureFile_Diff_ContentChangeForcesNew(t *testing.T) {
+	r := ResourceSecureFile()
+	state := &terraform.InstanceState{
+		ID: secureFileTestID.String(),
+		Attributes: map[string]string{
+			vgProjectID:     secureFileTestProjectID,
+			vgName:          "kubeconfig",
+			sfContentBase64: base64.StdEncoding.EncodeToString([]byte(secureFileTestContent)),
+			sfContentSha256: secureFileTestContentSha256,
+			vgAllowAccess:   "false",
+		},
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		vgProjectID:     secureFileTestProjectID,
+		vgName:          "renamed",
+		sfContentBase64: base64.StdEncoding.EncodeToString([]byte(secureFileTestContent)),
+	}), nil)
+	require.Nil(t, err)
+	require.False(t, diff.RequiresNew())
+	require.Nil(t, diff.Attributes[sfContentSha256])
+
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		vgProjectID:     secureFileTestProjectID,
+		vgName:          "kubeconfig",
+		sfContentBase64: base64.StdEncoding.EncodeToString([]byte("changed")),
+	}), nil)
+	require.Nil(t, err)
+	require.True(t, diff.RequiresNew())
+	require.Equal(t, hashSecureFileContent([]byte("changed")), diff.Attributes[sfContentSha256].New)
+}
+
+// verifies that the data source matches the secure file by name
+func TestDataSecureFile_Read_MatchesName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clients, taskAgentClient, _ := newSecureFileTestClients(ctrl)
+	otherID := uuid.New()
+	taskAgentClient.
+		EXPECT().
+		GetSecureFiles(clients.Ctx, taskagentextras.GetSecureFilesArgs{
+			Project:     &secureFileTestProjectID,
+			NamePattern: converter.String("kubeconfig"),
+		}).
+		Return(&[]taskagent.SecureFile{
+			{Id: &otherID, Name: converter.String("kubeconfig-old")},
+			{Id: &secureFileTestID, Name: converter.String("kubeconfig"), Properties: &map[string]string{"cluster": "prod"}},
+		}, nil).
+		Times(1)
+
+	resourceData := schema.TestResourceDataRaw(t, DataSecureFile().Schema, nil)
+	resourceData.Set(vgProjectID, secureFileTestProjectID)
+	resourceData.Set(vgName, "kubeconfig")
+
+	err := dataSourceSecureFileRead(resourceData, clients)
+	require.Nil(t, err)
+	require.Equal(t, secureFileTestID.String(), resourceData.Id())
+	require.Equal(t, map[string]interface{}{"cluster": "prod"}, resourceData.Get(sfProperties))
+}
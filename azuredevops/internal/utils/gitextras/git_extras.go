@@ -0,0 +1,116 @@
+package gitextras
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/git"
+)
+
+var ResourceAreaId, _ = uuid.Parse("4e080c62-fa21-4fbc-8fef-2a10a2b38049")
+
+// The disabled state of a repository is not covered by the SDK models.
+var repositoriesLocationId, _ = uuid.Parse("225f7195-f9c7-4d14-ab28-a83f7ff77e1f")
+
+type Client interface {
+	// Retrieve a git repository including its disabled state.
+	GetRepository(context.Context, GetRepositoryArgs) (*GitRepository, error)
+	// Enable or disable a git repository.
+	UpdateRepositoryDisabled(context.Context, UpdateRepositoryDisabledArgs) (*GitRepository, error)
+}
+
+type ClientImpl struct {
+	Client azuredevops.Client
+}
+
+func NewClient(ctx context.Context, connection *azuredevops.Connection) (Client, error) {
+	client, err := connection.GetClientByResourceAreaId(ctx, ResourceAreaId)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientImpl{
+		Client: *client,
+	}, nil
+}
+
+// GitRepository extends the SDK git repository with the disabled state
+type GitRepository struct {
+	git.GitRepository
+	// True if the repository is disabled. False otherwise.
+	IsDisabled *bool `json:"isDisabled,omitempty"`
+}
+
+// Retrieve a git repository including its disabled state.
+func (client *ClientImpl) GetRepository(ctx context.Context, args GetRepositoryArgs) (*GitRepository, error) {
+	routeValues := make(map[string]string)
+	if args.Project != nil && *args.Project != "" {
+		routeValues["project"] = *args.Project
+	}
+	if args.RepositoryId == nil || *args.RepositoryId == "" {
+		return nil, &azuredevops.ArgumentNilOrEmptyError{ArgumentName: "args.RepositoryId"}
+	}
+	routeValues["repositoryId"] = *args.RepositoryId
+
+	resp, err := client.Client.Send(ctx, http.MethodGet, repositoriesLocationId, "6.0", routeValues, nil, nil, "", "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseValue GitRepository
+	err = client.Client.UnmarshalBody(resp, &responseValue)
+	return &responseValue, err
+}
+
+// Arguments for the GetRepository function
+type GetRepositoryArgs struct {
+	// (required) The name or ID of the repository.
+	RepositoryId *string
+	// (optional) Project ID or project name
+	Project *string
+}
+
+// Enable or disable a git repository.
+func (client *ClientImpl) UpdateRepositoryDisabled(ctx context.Context, args UpdateRepositoryDisabledArgs) (*GitRepository, error) {
+	if args.IsDisabled == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "args.IsDisabled"}
+	}
+	routeValues := make(map[string]string)
+	if args.Project != nil && *args.Project != "" {
+		routeValues["project"] = *args.Project
+	}
+	if args.RepositoryId == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "args.RepositoryId"}
+	}
+	routeValues["repositoryId"] = (*args.RepositoryId).String()
+
+	body, marshalErr := json.Marshal(struct {
+		IsDisabled *bool `json:"isDisabled"`
+	}{
+		IsDisabled: args.IsDisabled,
+	})
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	resp, err := client.Client.Send(ctx, http.MethodPatch, repositoriesLocationId, "6.0", routeValues, nil, bytes.NewReader(body), "application/json", "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseValue GitRepository
+	err = client.Client.UnmarshalBody(resp, &responseValue)
+	return &responseValue, err
+}
+
+// Arguments for the UpdateRepositoryDisabled function
+type UpdateRepositoryDisabledArgs struct {
+	// (required) True to disable the repository, false to enable it.
+	IsDisabled *bool
+	// (required) The ID of the git repository.
+	RepositoryId *uuid.UUID
+	// (optional) Project ID or project name
+	Project *string
+}
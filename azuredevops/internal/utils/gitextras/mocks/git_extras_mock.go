@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: azuredevops/internal/utils/gitextras/git_extras.go
+
+// Package mock_gitextras is a generated GoMock package.
+package mock_gitextras
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	gitextras "github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/gitextras"
+)
+
+// GitClientExtras is a mock of Client interface.
+type GitClientExtras struct {
+	ctrl     *gomock.Controller
+	recorder *GitClientExtrasMockRecorder
+}
+
+// GitClientExtrasMockRecorder is the mock recorder for GitClientExtras.
+type GitClientExtrasMockRecorder struct {
+	mock *GitClientExtras
+}
+
+// NewGitClientExtras creates a new mock instance.
+func NewGitClientExtras(ctrl *gomock.Controller) *GitClientExtras {
+	mock := &GitClientExtras{ctrl: ctrl}
+	mock.recorder = &GitClientExtrasMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *GitClientExtras) EXPECT() *GitClientExtrasMockRecorder {
+	return m.recorder
+}
+
+// GetRepository mocks base method.
+func (m *GitClientExtras) GetRepository(arg0 context.Context, arg1 gitextras.GetRepositoryArgs) (*gitextras.GitRepository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepository", arg0, arg1)
+	ret0, _ := ret[0].(*gitextras.GitRepository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepository indicates an expected call of GetRepository.
+func (mr *GitClientExtrasMockRecorder) GetRepository(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepository", reflect.TypeOf((*GitClientExtras)(nil).GetRepository), arg0, arg1)
+}
+
+// UpdateRepositoryDisabled mocks base method.
+func (m *GitClientExtras) UpdateRepositoryDisabled(arg0 context.Context, arg1 gitextras.UpdateRepositoryDisabledArgs) (*gitextras.GitRepository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRepositoryDisabled", arg0, arg1)
+	ret0, _ := ret[0].(*gitextras.GitRepository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRepositoryDisabled indicates an expected call of UpdateRepositoryDisabled.
+func (mr *GitClientExtrasMockRecorder) UpdateRepositoryDisabled(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRepositoryDisabled", reflect.TypeOf((*GitClientExtras)(nil).UpdateRepositoryDisabled), arg0, arg1)
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: azuredevops/internal/utils/pipelineschecksextras/pipelineschecks_extras.go
+
+// Package mock_pipelineschecksextras is a generated GoMock package.
+package mock_pipelineschecksextras
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	pipelineschecks "github.com/microsoft/azure-devops-go-api/azuredevops/pipelineschecks"
+	pipelineschecksextras "github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/pipelineschecksextras"
+)
+
+// PipelinesChecksClientExtrasV5 is a mock of Client interface.
+type PipelinesChecksClientExtrasV5 struct {
+	ctrl     *gomock.Controller
+	recorder *PipelinesChecksClientExtrasV5MockRecorder
+}
+
+// PipelinesChecksClientExtrasV5MockRecorder is the mock recorder for PipelinesChecksClientExtrasV5.
+type PipelinesChecksClientExtrasV5MockRecorder struct {
+	mock *PipelinesChecksClientExtrasV5
+}
+
+// NewPipelinesChecksClientExtrasV5 creates a new mock instance.
+func NewPipelinesChecksClientExtrasV5(ctrl *gomock.Controller) *PipelinesChecksClientExtrasV5 {
+	mock := &PipelinesChecksClientExtrasV5{ctrl: ctrl}
+	mock.recorder = &PipelinesChecksClientExtrasV5MockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *PipelinesChecksClientExtrasV5) EXPECT() *PipelinesChecksClientExtrasV5MockRecorder {
+	return m.recorder
+}
+
+// AddCheckConfiguration mocks base method.
+func (m *PipelinesChecksClientExtrasV5) AddCheckConfiguration(arg0 context.Context, arg1 pipelineschecksextras.AddCheckConfigurationArgs) (*pipelineschecksextras.CheckConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCheckConfiguration", arg0, arg1)
+	ret0, _ := ret[0].(*pipelineschecksextras.CheckConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCheckConfiguration indicates an expected call of AddCheckConfiguration.
+func (mr *PipelinesChecksClientExtrasV5MockRecorder) AddCheckConfiguration(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCheckConfiguration", reflect.TypeOf((*PipelinesChecksClientExtrasV5)(nil).AddCheckConfiguration), arg0, arg1)
+}
+
+// GetCheckConfiguration mocks base method.
+func (m *PipelinesChecksClientExtrasV5) GetCheckConfiguration(arg0 context.Context, arg1 pipelineschecks.GetCheckConfigurationArgs) (*pipelineschecksextras.CheckConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckConfiguration", arg0, arg1)
+	ret0, _ := ret[0].(*pipelineschecksextras.CheckConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCheckConfiguration indicates an expected call of GetCheckConfiguration.
+func (mr *PipelinesChecksClientExtrasV5MockRecorder) GetCheckConfiguration(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckConfiguration", reflect.TypeOf((*PipelinesChecksClientExtrasV5)(nil).GetCheckConfiguration), arg0, arg1)
+}
+
+// GetCheckConfigurationsOnResource mocks base method.
+func (m *PipelinesChecksClientExtrasV5) GetCheckConfigurationsOnResource(arg0 context.Context, arg1 pipelineschecks.GetCheckConfigurationsOnResourceArgs) (*[]pipelineschecksextras.CheckConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckConfigurationsOnResource", arg0, arg1)
+	ret0, _ := ret[0].(*[]pipelineschecksextras.CheckConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCheckConfigurationsOnResource indicates an expected call of GetCheckConfigurationsOnResource.
+func (mr *PipelinesChecksClientExtrasV5MockRecorder) GetCheckConfigurationsOnResource(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckConfigurationsOnResource", reflect.TypeOf((*PipelinesChecksClientExtrasV5)(nil).GetCheckConfigurationsOnResource), arg0, arg1)
+}
+
+// UpdateCheckConfiguration mocks base method.
+func (m *PipelinesChecksClientExtrasV5) UpdateCheckConfiguration(arg0 context.Context, arg1 pipelineschecksextras.UpdateCheckConfigurationArgs) (*pipelineschecksextras.CheckConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCheckConfiguration", arg0, arg1)
+	ret0, _ := ret[0].(*pipelineschecksextras.CheckConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCheckConfiguration indicates an expected call of UpdateCheckConfiguration.
+func (mr *PipelinesChecksClientExtrasV5MockRecorder) UpdateCheckConfiguration(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCheckConfiguration", reflect.TypeOf((*PipelinesChecksClientExtrasV5)(nil).UpdateCheckConfiguration), arg0, arg1)
+}
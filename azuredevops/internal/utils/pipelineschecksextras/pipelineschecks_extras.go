@@ -1,7 +1,9 @@
 package pipelineschecksextras
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -13,9 +15,23 @@ import (
 
 var ResourceAreaId, _ = uuid.Parse("4a933897-0488-45af-bd82-6fd3ad33f46a")
 
+// CheckConfiguration extends the SDK check configuration with the properties
+// that the service returns but which are missing from the 5.1 models.
+type CheckConfiguration struct {
+	pipelineschecks.CheckConfiguration
+	// Timeout in minutes for the check.
+	Timeout *int `json:"timeout,omitempty"`
+}
+
 type Client interface {
+	// [Preview API] Add a check configuration
+	AddCheckConfiguration(context.Context, AddCheckConfigurationArgs) (*CheckConfiguration, error)
 	// [Preview API] Get Check configuration by Id
-	GetCheckConfiguration(context.Context, pipelineschecks.GetCheckConfigurationArgs) (*pipelineschecks.CheckConfiguration, error)
+	GetCheckConfiguration(context.Context, pipelineschecks.GetCheckConfigurationArgs) (*CheckConfiguration, error)
+	// [Preview API] Get Check configuration by resource type and id
+	GetCheckConfigurationsOnResource(context.Context, pipelineschecks.GetCheckConfigurationsOnResourceArgs) (*[]CheckConfiguration, error)
+	// [Preview API] Update check configuration
+	UpdateCheckConfiguration(context.Context, UpdateCheckConfigurationArgs) (*CheckConfiguration, error)
 }
 
 type ClientImpl struct {
@@ -32,8 +48,42 @@ func NewClient(ctx context.Context, connection *azuredevops.Connection) (Client,
 	}, nil
 }
 
+// [Preview API] Add a check configuration
+func (client *ClientImpl) AddCheckConfiguration(ctx context.Context, args AddCheckConfigurationArgs) (*CheckConfiguration, error) {
+	if args.Configuration == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "args.Configuration"}
+	}
+	routeValues := make(map[string]string)
+	if args.Project == nil || *args.Project == "" {
+		return nil, &azuredevops.ArgumentNilOrEmptyError{ArgumentName: "args.Project"}
+	}
+	routeValues["project"] = *args.Project
+
+	body, marshalErr := json.Marshal(*args.Configuration)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	locationId, _ := uuid.Parse("86c8381e-5aee-4cde-8ae4-25c0c7f5eaea")
+	resp, err := client.Client.Send(ctx, http.MethodPost, locationId, "5.1-preview.1", routeValues, nil, bytes.NewReader(body), "application/json", "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseValue CheckConfiguration
+	err = client.Client.UnmarshalBody(resp, &responseValue)
+	return &responseValue, err
+}
+
+// Arguments for the AddCheckConfiguration function
+type AddCheckConfigurationArgs struct {
+	// (required)
+	Configuration *CheckConfiguration
+	// (required) Project ID or project name
+	Project *string
+}
+
 // [Preview API] Get Check configuration by Id
-func (client *ClientImpl) GetCheckConfiguration(ctx context.Context, args pipelineschecks.GetCheckConfigurationArgs) (*pipelineschecks.CheckConfiguration, error) {
+func (client *ClientImpl) GetCheckConfiguration(ctx context.Context, args pipelineschecks.GetCheckConfigurationArgs) (*CheckConfiguration, error) {
 	routeValues := make(map[string]string)
 	if args.Project == nil || *args.Project == "" {
 		return nil, &azuredevops.ArgumentNilOrEmptyError{ArgumentName: "args.Project"}
@@ -53,7 +103,77 @@ func (client *ClientImpl) GetCheckConfiguration(ctx context.Context, args pipeli
 		return nil, err
 	}
 
-	var responseValue pipelineschecks.CheckConfiguration
+	var responseValue CheckConfiguration
+	err = client.Client.UnmarshalBody(resp, &responseValue)
+	return &responseValue, err
+}
+
+// [Preview API] Get Check configuration by resource type and id
+func (client *ClientImpl) GetCheckConfigurationsOnResource(ctx context.Context, args pipelineschecks.GetCheckConfigurationsOnResourceArgs) (*[]CheckConfiguration, error) {
+	routeValues := make(map[string]string)
+	if args.Project == nil || *args.Project == "" {
+		return nil, &azuredevops.ArgumentNilOrEmptyError{ArgumentName: "args.Project"}
+	}
+	routeValues["project"] = *args.Project
+
+	queryParams := url.Values{}
+	if args.ResourceType == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "resourceType"}
+	}
+	queryParams.Add("resourceType", *args.ResourceType)
+	if args.ResourceId == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "resourceId"}
+	}
+	queryParams.Add("resourceId", *args.ResourceId)
+	queryParams.Add("$expand", "settings")
+
+	locationId, _ := uuid.Parse("86c8381e-5aee-4cde-8ae4-25c0c7f5eaea")
+	resp, err := client.Client.Send(ctx, http.MethodGet, locationId, "5.1-preview.1", routeValues, queryParams, nil, "", "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseValue []CheckConfiguration
+	err = client.Client.UnmarshalCollectionBody(resp, &responseValue)
+	return &responseValue, err
+}
+
+// [Preview API] Update check configuration
+func (client *ClientImpl) UpdateCheckConfiguration(ctx context.Context, args UpdateCheckConfigurationArgs) (*CheckConfiguration, error) {
+	if args.Configuration == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "args.Configuration"}
+	}
+	routeValues := make(map[string]string)
+	if args.Project == nil || *args.Project == "" {
+		return nil, &azuredevops.ArgumentNilOrEmptyError{ArgumentName: "args.Project"}
+	}
+	routeValues["project"] = *args.Project
+	if args.Id == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "args.Id"}
+	}
+	routeValues["id"] = strconv.Itoa(*args.Id)
+
+	body, marshalErr := json.Marshal(*args.Configuration)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	locationId, _ := uuid.Parse("86c8381e-5aee-4cde-8ae4-25c0c7f5eaea")
+	resp, err := client.Client.Send(ctx, http.MethodPatch, locationId, "5.1-preview.1", routeValues, nil, bytes.NewReader(body), "application/json", "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseValue CheckConfiguration
 	err = client.Client.UnmarshalBody(resp, &responseValue)
 	return &responseValue, err
 }
+
+// Arguments for the UpdateCheckConfiguration function
+type UpdateCheckConfigurationArgs struct {
+	// (required) check configuration
+	Configuration *CheckConfiguration
+	// (required) Project ID or project name
+	Project *string
+	// (required) check configuration id
+	Id *int
+}
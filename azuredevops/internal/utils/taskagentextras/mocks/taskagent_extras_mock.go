@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: azuredevops/internal/utils/taskagentextras/taskagent_extras.go
+
+// Package mock_taskagentextras is a generated GoMock package.
+package mock_taskagentextras
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	taskagent "github.com/microsoft/azure-devops-go-api/azuredevops/v6/taskagent"
+	taskagentextras "github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/utils/taskagentextras"
+)
+
+// TaskAgentClientExtras is a mock of Client interface.
+type TaskAgentClientExtras struct {
+	ctrl     *gomock.Controller
+	recorder *TaskAgentClientExtrasMockRecorder
+}
+
+// TaskAgentClientExtrasMockRecorder is the mock recorder for TaskAgentClientExtras.
+type TaskAgentClientExtrasMockRecorder struct {
+	mock *TaskAgentClientExtras
+}
+
+// NewTaskAgentClientExtras creates a new mock instance.
+func NewTaskAgentClientExtras(ctrl *gomock.Controller) *TaskAgentClientExtras {
+	mock := &TaskAgentClientExtras{ctrl: ctrl}
+	mock.recorder = &TaskAgentClientExtrasMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *TaskAgentClientExtras) EXPECT() *TaskAgentClientExtrasMockRecorder {
+	return m.recorder
+}
+
+// DeleteSecureFile mocks base method.
+func (m *TaskAgentClientExtras) DeleteSecureFile(arg0 context.Context, arg1 taskagentextras.DeleteSecureFileArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecureFile", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecureFile indicates an expected call of DeleteSecureFile.
+func (mr *TaskAgentClientExtrasMockRecorder) DeleteSecureFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecureFile", reflect.TypeOf((*TaskAgentClientExtras)(nil).DeleteSecureFile), arg0, arg1)
+}
+
+// DownloadSecureFile mocks base method.
+func (m *TaskAgentClientExtras) DownloadSecureFile(arg0 context.Context, arg1 taskagentextras.DownloadSecureFileArgs) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadSecureFile", arg0, arg1)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadSecureFile indicates an expected call of DownloadSecureFile.
+func (mr *TaskAgentClientExtrasMockRecorder) DownloadSecureFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadSecureFile", reflect.TypeOf((*TaskAgentClientExtras)(nil).DownloadSecureFile), arg0, arg1)
+}
+
+// GetSecureFile mocks base method.
+func (m *TaskAgentClientExtras) GetSecureFile(arg0 context.Context, arg1 taskagentextras.GetSecureFileArgs) (*taskagent.SecureFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecureFile", arg0, arg1)
+	ret0, _ := ret[0].(*taskagent.SecureFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecureFile indicates an expected call of GetSecureFile.
+func (mr *TaskAgentClientExtrasMockRecorder) GetSecureFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecureFile", reflect.TypeOf((*TaskAgentClientExtras)(nil).GetSecureFile), arg0, arg1)
+}
+
+// GetSecureFiles mocks base method.
+func (m *TaskAgentClientExtras) GetSecureFiles(arg0 context.Context, arg1 taskagentextras.GetSecureFilesArgs) (*[]taskagent.SecureFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecureFiles", arg0, arg1)
+	ret0, _ := ret[0].(*[]taskagent.SecureFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecureFiles indicates an expected call of GetSecureFiles.
+func (mr *TaskAgentClientExtrasMockRecorder) GetSecureFiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecureFiles", reflect.TypeOf((*TaskAgentClientExtras)(nil).GetSecureFiles), arg0, arg1)
+}
+
+// GetTaskDefinitions mocks base method.
+func (m *TaskAgentClientExtras) GetTaskDefinitions(arg0 context.Context, arg1 taskagentextras.GetTaskDefinitionsArgs) (*[]taskagent.TaskDefinition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskDefinitions", arg0, arg1)
+	ret0, _ := ret[0].(*[]taskagent.TaskDefinition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskDefinitions indicates an expected call of GetTaskDefinitions.
+func (mr *TaskAgentClientExtrasMockRecorder) GetTaskDefinitions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskDefinitions", reflect.TypeOf((*TaskAgentClientExtras)(nil).GetTaskDefinitions), arg0, arg1)
+}
+
+// UpdateSecureFile mocks base method.
+func (m *TaskAgentClientExtras) UpdateSecureFile(arg0 context.Context, arg1 taskagentextras.UpdateSecureFileArgs) (*taskagent.SecureFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecureFile", arg0, arg1)
+	ret0, _ := ret[0].(*taskagent.SecureFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecureFile indicates an expected call of UpdateSecureFile.
+func (mr *TaskAgentClientExtrasMockRecorder) UpdateSecureFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecureFile", reflect.TypeOf((*TaskAgentClientExtras)(nil).UpdateSecureFile), arg0, arg1)
+}
+
+// UploadSecureFile mocks base method.
+func (m *TaskAgentClientExtras) UploadSecureFile(arg0 context.Context, arg1 taskagentextras.UploadSecureFileArgs) (*taskagent.SecureFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadSecureFile", arg0, arg1)
+	ret0, _ := ret[0].(*taskagent.SecureFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadSecureFile indicates an expected call of UploadSecureFile.
+func (mr *TaskAgentClientExtrasMockRecorder) UploadSecureFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadSecureFile", reflect.TypeOf((*TaskAgentClientExtras)(nil).UploadSecureFile), arg0, arg1)
+}
@@ -0,0 +1,300 @@
+package taskagentextras
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v6/taskagent"
+)
+
+var ResourceAreaId, _ = uuid.Parse("a85b8835-c1a1-4aac-ae97-1c3d0ba72dbd")
+
+// The secure files API is not covered by the SDK.
+var secureFilesLocationId, _ = uuid.Parse("adcfd8bc-b184-43ba-bd84-7c8c6a2ff421")
+
+// The task definitions API is not covered by the SDK.
+var tasksLocationId, _ = uuid.Parse("60aac929-f0cd-4bc8-9ce4-6b30e8f1b1bd")
+
+type Client interface {
+	// [Preview API] Upload a secure file
+	UploadSecureFile(context.Context, UploadSecureFileArgs) (*taskagent.SecureFile, error)
+	// [Preview API] Get a secure file
+	GetSecureFile(context.Context, GetSecureFileArgs) (*taskagent.SecureFile, error)
+	// [Preview API] Get secure files
+	GetSecureFiles(context.Context, GetSecureFilesArgs) (*[]taskagent.SecureFile, error)
+	// [Preview API] Update the name or properties of a secure file
+	UpdateSecureFile(context.Context, UpdateSecureFileArgs) (*taskagent.SecureFile, error)
+	// [Preview API] Delete a secure file
+	DeleteSecureFile(context.Context, DeleteSecureFileArgs) error
+	// [Preview API] Download the content of a secure file
+	DownloadSecureFile(context.Context, DownloadSecureFileArgs) (io.ReadCloser, error)
+	// Get the definitions of a task
+	GetTaskDefinitions(context.Context, GetTaskDefinitionsArgs) (*[]taskagent.TaskDefinition, error)
+}
+
+type ClientImpl struct {
+	Client azuredevops.Client
+}
+
+func NewClient(ctx context.Context, connection *azuredevops.Connection) (Client, error) {
+	client, err := connection.GetClientByResourceAreaId(ctx, ResourceAreaId)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientImpl{
+		Client: *client,
+	}, nil
+}
+
+// [Preview API] Upload a secure file
+func (client *ClientImpl) UploadSecureFile(ctx context.Context, args UploadSecureFileArgs) (*taskagent.SecureFile, error) {
+	if args.UploadStream == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "args.UploadStream"}
+	}
+	routeValues := make(map[string]string)
+	if args.Project == nil || *args.Project == "" {
+		return nil, &azuredevops.ArgumentNilOrEmptyError{ArgumentName: "args.Project"}
+	}
+	routeValues["project"] = *args.Project
+
+	queryParams := url.Values{}
+	if args.Name == nil || *args.Name == "" {
+		return nil, &azuredevops.ArgumentNilOrEmptyError{ArgumentName: "args.Name"}
+	}
+	queryParams.Add("name", *args.Name)
+	if args.AuthorizePipelines != nil {
+		queryParams.Add("authorizePipelines", strconv.FormatBool(*args.AuthorizePipelines))
+	}
+
+	resp, err := client.Client.Send(ctx, http.MethodPost, secureFilesLocationId, "6.0-preview.1", routeValues, queryParams, args.UploadStream, "application/octet-stream", "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseValue taskagent.SecureFile
+	err = client.Client.UnmarshalBody(resp, &responseValue)
+	return &responseValue, err
+}
+
+// Arguments for the UploadSecureFile function
+type UploadSecureFileArgs struct {
+	// (required) Stream to upload
+	UploadStream io.Reader
+	// (required) Project ID or project name
+	Project *string
+	// (required) Name of the file to upload
+	Name *string
+	// (optional) If authorizePipelines is true, then the secure file is authorized for use by all pipelines in the project.
+	AuthorizePipelines *bool
+}
+
+// [Preview API] Get a secure file
+func (client *ClientImpl) GetSecureFile(ctx context.Context, args GetSecureFileArgs) (*taskagent.SecureFile, error) {
+	routeValues := make(map[string]string)
+	if args.Project == nil || *args.Project == "" {
+		return nil, &azuredevops.ArgumentNilOrEmptyError{ArgumentName: "args.Project"}
+	}
+	routeValues["project"] = *args.Project
+	if args.SecureFileId == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "args.SecureFileId"}
+	}
+	routeValues["secureFileId"] = (*args.SecureFileId).String()
+
+	queryParams := url.Values{}
+	if args.IncludeDownloadTicket != nil {
+		queryParams.Add("includeDownloadTicket", strconv.FormatBool(*args.IncludeDownloadTicket))
+	}
+
+	resp, err := client.Client.Send(ctx, http.MethodGet, secureFilesLocationId, "6.0-preview.1", routeValues, queryParams, nil, "", "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseValue taskagent.SecureFile
+	err = client.Client.UnmarshalBody(resp, &responseValue)
+	return &responseValue, err
+}
+
+// Arguments for the GetSecureFile function
+type GetSecureFileArgs struct {
+	// (required) Project ID or project name
+	Project *string
+	// (required) The unique secure file Id
+	SecureFileId *uuid.UUID
+	// (optional) If includeDownloadTicket is true and the caller has permissions, a download ticket is included in the response.
+	IncludeDownloadTicket *bool
+}
+
+// [Preview API] Get secure files
+func (client *ClientImpl) GetSecureFiles(ctx context.Context, args GetSecureFilesArgs) (*[]taskagent.SecureFile, error) {
+	routeValues := make(map[string]string)
+	if args.Project == nil || *args.Project == "" {
+		return nil, &azuredevops.ArgumentNilOrEmptyError{ArgumentName: "args.Project"}
+	}
+	routeValues["project"] = *args.Project
+
+	queryParams := url.Values{}
+	if args.NamePattern != nil {
+		queryParams.Add("namePattern", *args.NamePattern)
+	}
+	if args.IncludeDownloadTicket != nil {
+		queryParams.Add("includeDownloadTicket", strconv.FormatBool(*args.IncludeDownloadTicket))
+	}
+
+	resp, err := client.Client.Send(ctx, http.MethodGet, secureFilesLocationId, "6.0-preview.1", routeValues, queryParams, nil, "", "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseValue []taskagent.SecureFile
+	err = client.Client.UnmarshalCollectionBody(resp, &responseValue)
+	return &responseValue, err
+}
+
+// Arguments for the GetSecureFiles function
+type GetSecureFilesArgs struct {
+	// (required) Project ID or project name
+	Project *string
+	// (optional) Name of the secure file to match. Can include wildcards to match multiple files.
+	NamePattern *string
+	// (optional) If includeDownloadTicket is true and the caller has permissions, a download ticket for each secure file is included in the response.
+	IncludeDownloadTicket *bool
+}
+
+// [Preview API] Update the name or properties of a secure file
+func (client *ClientImpl) UpdateSecureFile(ctx context.Context, args UpdateSecureFileArgs) (*taskagent.SecureFile, error) {
+	if args.SecureFile == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "args.SecureFile"}
+	}
+	routeValues := make(map[string]string)
+	if args.Project == nil || *args.Project == "" {
+		return nil, &azuredevops.ArgumentNilOrEmptyError{ArgumentName: "args.Project"}
+	}
+	routeValues["project"] = *args.Project
+	if args.SecureFileId == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "args.SecureFileId"}
+	}
+	routeValues["secureFileId"] = (*args.SecureFileId).String()
+
+	body, marshalErr := json.Marshal(*args.SecureFile)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	resp, err := client.Client.Send(ctx, http.MethodPatch, secureFilesLocationId, "6.0-preview.1", routeValues, nil, bytes.NewReader(body), "application/json", "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseValue taskagent.SecureFile
+	err = client.Client.UnmarshalBody(resp, &responseValue)
+	return &responseValue, err
+}
+
+// Arguments for the UpdateSecureFile function
+type UpdateSecureFileArgs struct {
+	// (required) The secure file with updated name and/or properties
+	SecureFile *taskagent.SecureFile
+	// (required) Project ID or project name
+	Project *string
+	// (required) The unique secure file Id
+	SecureFileId *uuid.UUID
+}
+
+// [Preview API] Delete a secure file
+func (client *ClientImpl) DeleteSecureFile(ctx context.Context, args DeleteSecureFileArgs) error {
+	routeValues := make(map[string]string)
+	if args.Project == nil || *args.Project == "" {
+		return &azuredevops.ArgumentNilOrEmptyError{ArgumentName: "args.Project"}
+	}
+	routeValues["project"] = *args.Project
+	if args.SecureFileId == nil {
+		return &azuredevops.ArgumentNilError{ArgumentName: "args.SecureFileId"}
+	}
+	routeValues["secureFileId"] = (*args.SecureFileId).String()
+
+	_, err := client.Client.Send(ctx, http.MethodDelete, secureFilesLocationId, "6.0-preview.1", routeValues, nil, nil, "", "application/json", nil)
+	return err
+}
+
+// Arguments for the DeleteSecureFile function
+type DeleteSecureFileArgs struct {
+	// (required) Project ID or project name
+	Project *string
+	// (required) The unique secure file Id
+	SecureFileId *uuid.UUID
+}
+
+// [Preview API] Download the content of a secure file
+func (client *ClientImpl) DownloadSecureFile(ctx context.Context, args DownloadSecureFileArgs) (io.ReadCloser, error) {
+	routeValues := make(map[string]string)
+	if args.Project == nil || *args.Project == "" {
+		return nil, &azuredevops.ArgumentNilOrEmptyError{ArgumentName: "args.Project"}
+	}
+	routeValues["project"] = *args.Project
+	if args.SecureFileId == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "args.SecureFileId"}
+	}
+	routeValues["secureFileId"] = (*args.SecureFileId).String()
+
+	queryParams := url.Values{}
+	if args.Ticket == nil || *args.Ticket == "" {
+		return nil, &azuredevops.ArgumentNilOrEmptyError{ArgumentName: "args.Ticket"}
+	}
+	queryParams.Add("ticket", *args.Ticket)
+	queryParams.Add("download", "true")
+
+	resp, err := client.Client.Send(ctx, http.MethodGet, secureFilesLocationId, "6.0-preview.1", routeValues, queryParams, nil, "", "application/octet-stream", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, err
+}
+
+// Arguments for the DownloadSecureFile function
+type DownloadSecureFileArgs struct {
+	// (required) Project ID or project name
+	Project *string
+	// (required) The unique secure file Id
+	SecureFileId *uuid.UUID
+	// (required) A valid download ticket
+	Ticket *string
+}
+
+// Get the definitions of a task
+func (client *ClientImpl) GetTaskDefinitions(ctx context.Context, args GetTaskDefinitionsArgs) (*[]taskagent.TaskDefinition, error) {
+	routeValues := make(map[string]string)
+	if args.TaskId == nil {
+		return nil, &azuredevops.ArgumentNilError{ArgumentName: "args.TaskId"}
+	}
+	routeValues["taskId"] = (*args.TaskId).String()
+
+	queryParams := url.Values{}
+	if args.AllVersions != nil {
+		queryParams.Add("allVersions", strconv.FormatBool(*args.AllVersions))
+	}
+
+	resp, err := client.Client.Send(ctx, http.MethodGet, tasksLocationId, "6.0", routeValues, queryParams, nil, "", "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseValue []taskagent.TaskDefinition
+	err = client.Client.UnmarshalCollectionBody(resp, &responseValue)
+	return &responseValue, err
+}
+
+// Arguments for the GetTaskDefinitions function
+type GetTaskDefinitionsArgs struct {
+	// (required) The ID of the task
+	TaskId *uuid.UUID
+	// (optional) If allVersions is true, all versions of the task are returned, otherwise only the latest version of every major version.
+	AllVersions *bool
+}
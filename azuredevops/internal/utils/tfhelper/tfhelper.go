@@ -178,6 +178,9 @@ func GetRealProjectId(projectNameOrID string, meta interface{}) (string, error)
 	// If request params is project name, try get the project ID
 	if _, err := uuid.ParseUUID(projectNameOrID); err != nil {
 		clients := meta.(*client.AggregatedClient)
+		if projectID, ok := clients.ReadCache.ProjectID(projectNameOrID); ok {
+			return projectID, nil
+		}
 		project, err := clients.CoreClient.GetProject(clients.Ctx, core.GetProjectArgs{
 			ProjectId:           &projectNameOrID,
 			IncludeCapabilities: converter.Bool(true),
@@ -186,6 +189,7 @@ func GetRealProjectId(projectNameOrID string, meta interface{}) (string, error)
 		if err != nil {
 			return "", fmt.Errorf(" Failed to get the project with specified projectNameOrID: %s , %+v", projectNameOrID, err)
 		}
+		clients.ReadCache.SetProjectID(projectNameOrID, (*project.Id).String())
 		return (*project.Id).String(), nil
 	}
 	return projectNameOrID, nil
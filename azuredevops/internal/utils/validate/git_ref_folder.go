@@ -0,0 +1,26 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GitRefFolder validates that the string only contains a wildcard as the last path segment (e.g. `release/*` or `*`)
+func GitRefFolder(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	folder := v
+	if folder == "*" {
+		folder = ""
+	}
+	folder = strings.TrimSuffix(folder, "/*")
+	if strings.Contains(folder, "*") {
+		errors = append(errors, fmt.Errorf("%q can only contain * as the trailing /* of the folder, got: %q", k, v))
+	}
+
+	return warnings, errors
+}
@@ -0,0 +1,35 @@
+//go:build all || utils || git_ref_folder
+// +build all utils git_ref_folder
+
+package validate
+
+import (
+	"testing"
+)
+
+func TestGitRefFolderValidation(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{Value: "release", ErrCount: 0},
+		{Value: "release/", ErrCount: 0},
+		{Value: "release/*", ErrCount: 0},
+		{Value: "refs/heads/release/*", ErrCount: 0},
+		{Value: "refs/tags/", ErrCount: 0},
+		{Value: "*", ErrCount: 0},
+		{Value: "release*", ErrCount: 1},
+		{Value: "rel*ease/v1", ErrCount: 1},
+		{Value: "*/release", ErrCount: 1},
+		{Value: "release/**", ErrCount: 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Value, func(t *testing.T) {
+			_, errors := GitRefFolder(tc.Value, "branch_folder")
+			if len(errors) != tc.ErrCount {
+				t.Fatalf("Expected GitRefFolder to have %d not %d errors for %q", tc.ErrCount, len(errors), tc.Value)
+			}
+		})
+	}
+}
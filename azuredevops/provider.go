@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
@@ -31,6 +32,7 @@ import (
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/permissions"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/policy/branch"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/policy/repository"
+	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/release"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/serviceendpoint"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/taskagent"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops/internal/service/workitemtracking"
@@ -40,18 +42,24 @@ type GHIdTokenResponse struct {
 	Value string `json:"value"`
 }
 
+type AzurePipelinesIdTokenResponse struct {
+	OidcToken string `json:"oidcToken"`
+}
+
 type HCPWorkloadToken struct {
 	RunPhase string `json:"terraform_run_phase"`
 }
 
 // Provider - The top level Azure DevOps Provider definition.
 func Provider() *schema.Provider {
-	servicePrincipalAuthFields := []string{"sp_oidc_token", "sp_oidc_token_path", "sp_oidc_github_actions", "sp_oidc_hcp", "sp_client_certificate_path", "sp_client_certificate", "sp_client_secret", "sp_client_secret_path"}
-	allAuthFields := append([]string{"personal_access_token"}, servicePrincipalAuthFields...)
+	servicePrincipalAuthFields := []string{"sp_oidc_token", "sp_oidc_token_path", "sp_oidc_github_actions", "sp_oidc_azure_pipelines", "sp_oidc_hcp", "sp_client_certificate_path", "sp_client_certificate", "sp_client_secret", "sp_client_secret_path"}
+	allAuthFields := append([]string{"personal_access_token", "use_msi", "use_cli"}, servicePrincipalAuthFields...)
 
 	p := &schema.Provider{
 		ResourcesMap: map[string]*schema.Resource{
 			"azuredevops_resource_authorization":                 build.ResourceResourceAuthorization(),
+			"azuredevops_pipeline_authorization":                 build.ResourcePipelineAuthorization(),
+			"azuredevops_pipeline_run":                           build.ResourcePipelineRun(),
 			"azuredevops_branch_policy_build_validation":         branch.ResourceBranchPolicyBuildValidation(),
 			"azuredevops_branch_policy_min_reviewers":            branch.ResourceBranchPolicyMinReviewers(),
 			"azuredevops_branch_policy_auto_reviewers":           branch.ResourceBranchPolicyAutoReviewers(),
@@ -60,12 +68,15 @@ func Provider() *schema.Provider {
 			"azuredevops_branch_policy_merge_types":              branch.ResourceBranchPolicyMergeTypes(),
 			"azuredevops_branch_policy_status_check":             branch.ResourceBranchPolicyStatusCheck(),
 			"azuredevops_build_definition":                       build.ResourceBuildDefinition(),
+			"azuredevops_build_definition_tags":                  build.ResourceBuildDefinitionTags(),
 			"azuredevops_build_folder":                           build.ResourceBuildFolder(),
+			"azuredevops_release_definition":                     release.ResourceReleaseDefinition(),
 			"azuredevops_library_permissions":                    permissions.ResourceLibraryPermissions(),
 			"azuredevops_project":                                core.ResourceProject(),
 			"azuredevops_project_features":                       core.ResourceProjectFeatures(),
 			"azuredevops_project_pipeline_settings":              core.ResourceProjectPipelineSettings(),
 			"azuredevops_variable_group":                         taskagent.ResourceVariableGroup(),
+			"azuredevops_secure_file":                            taskagent.ResourceSecureFile(),
 			"azuredevops_repository_policy_author_email_pattern": repository.ResourceRepositoryPolicyAuthorEmailPatterns(),
 			"azuredevops_repository_policy_file_path_pattern":    repository.ResourceRepositoryFilePathPatterns(),
 			"azuredevops_repository_policy_case_enforcement":     repository.ResourceRepositoryEnforceConsistentCase(),
@@ -73,8 +84,14 @@ func Provider() *schema.Provider {
 			"azuredevops_repository_policy_max_path_length":      repository.ResourceRepositoryMaxPathLength(),
 			"azuredevops_repository_policy_max_file_size":        repository.ResourceRepositoryMaxFileSize(),
 			"azuredevops_repository_policy_check_credentials":    repository.ResourceRepositoryPolicyCheckCredentials(),
+			"azuredevops_check_approval":                         approvalsandchecks.ResourceCheckApproval(),
+			"azuredevops_check_azure_function":                   approvalsandchecks.ResourceCheckAzureFunction(),
 			"azuredevops_check_branch_control":                   approvalsandchecks.ResourceCheckBranchControl(),
 			"azuredevops_check_business_hours":                   approvalsandchecks.ResourceCheckBusinessHours(),
+			"azuredevops_check_exclusive_lock":                   approvalsandchecks.ResourceCheckExclusiveLock(),
+			"azuredevops_check_query_azure_monitor_alerts":       approvalsandchecks.ResourceCheckQueryAzureMonitorAlerts(),
+			"azuredevops_check_required_template":                approvalsandchecks.ResourceCheckRequiredTemplate(),
+			"azuredevops_check_rest_api":                         approvalsandchecks.ResourceCheckRestAPI(),
 			"azuredevops_serviceendpoint_argocd":                 serviceendpoint.ResourceServiceEndpointArgoCD(),
 			"azuredevops_serviceendpoint_artifactory":            serviceendpoint.ResourceServiceEndpointArtifactory(),
 			"azuredevops_serviceendpoint_jfrog_artifactory_v2":   serviceendpoint.ResourceServiceEndpointJFrogArtifactoryV2(),
@@ -105,6 +122,9 @@ func Provider() *schema.Provider {
 			"azuredevops_git_repository":                         git.ResourceGitRepository(),
 			"azuredevops_git_repository_branch":                  git.ResourceGitRepositoryBranch(),
 			"azuredevops_git_repository_file":                    git.ResourceGitRepositoryFile(),
+			"azuredevops_git_repository_files":                   git.ResourceGitRepositoryFiles(),
+			"azuredevops_git_repository_settings":                git.ResourceGitRepositorySettings(),
+			"azuredevops_git_repository_tag":                     git.ResourceGitRepositoryTag(),
 			"azuredevops_user_entitlement":                       memberentitlementmanagement.ResourceUserEntitlement(),
 			"azuredevops_group_membership":                       graph.ResourceGroupMembership(),
 			"azuredevops_agent_pool":                             taskagent.ResourceAgentPool(),
@@ -124,20 +144,25 @@ func Provider() *schema.Provider {
 			"azuredevops_servicehook_permissions":                permissions.ResourceServiceHookPermissions(),
 			"azuredevops_tagging_permissions":                    permissions.ResourceTaggingPermissions(),
 			"azuredevops_variable_group_permissions":             permissions.ResourceVariableGroupPermissions(),
+			"azuredevops_secure_file_permissions":                permissions.ResourceSecureFilePermissions(),
 			"azuredevops_environment":                            taskagent.ResourceEnvironment(),
 			"azuredevops_workitem":                               workitemtracking.ResourceWorkItem(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
+			"azuredevops_build":                   build.DataBuild(),
 			"azuredevops_build_definition":        build.DataBuildDefinition(),
+			"azuredevops_build_folders":           build.DataBuildFolders(),
 			"azuredevops_agent_pool":              taskagent.DataAgentPool(),
 			"azuredevops_agent_pools":             taskagent.DataAgentPools(),
 			"azuredevops_agent_queue":             taskagent.DataAgentQueue(),
+			"azuredevops_checks":                  approvalsandchecks.DataChecks(),
 			"azuredevops_client_config":           service.DataClientConfig(),
 			"azuredevops_group":                   graph.DataGroup(),
 			"azuredevops_project":                 core.DataProject(),
 			"azuredevops_projects":                core.DataProjects(),
 			"azuredevops_git_repositories":        git.DataGitRepositories(),
 			"azuredevops_git_repository":          git.DataGitRepository(),
+			"azuredevops_git_repository_refs":     git.DataGitRepositoryRefs(),
 			"azuredevops_users":                   graph.DataUsers(),
 			"azuredevops_area":                    workitemtracking.DataArea(),
 			"azuredevops_iteration":               workitemtracking.DataIteration(),
@@ -145,6 +170,7 @@ func Provider() *schema.Provider {
 			"azuredevops_teams":                   core.DataTeams(),
 			"azuredevops_groups":                  graph.DataGroups(),
 			"azuredevops_variable_group":          taskagent.DataVariableGroup(),
+			"azuredevops_secure_file":             taskagent.DataSecureFile(),
 			"azuredevops_serviceendpoint_azurerm": serviceendpoint.DataServiceEndpointAzureRM(),
 			"azuredevops_serviceendpoint_github":  serviceendpoint.DataServiceEndpointGithub(),
 		},
@@ -155,6 +181,40 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("AZDO_ORG_SERVICE_URL", nil),
 				Description: "The url of the Azure DevOps instance which should be used.",
 			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AZDO_MAX_RETRIES", 5),
+				Description:  "The maximum number of times a throttled or failed request is retried.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_min_backoff": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AZDO_RETRY_MIN_BACKOFF", 1),
+				Description:  "The number of seconds to wait before the first retry, when the service does not ask for a specific delay.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_max_backoff": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AZDO_RETRY_MAX_BACKOFF", 60),
+				Description:  "The maximum number of seconds to wait between retries, when the service does not ask for a specific delay.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AZDO_MAX_CONCURRENT_REQUESTS", 0),
+				Description:  "The maximum number of outstanding requests to Azure DevOps. 0 means unlimited.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"enable_read_cache": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("AZDO_ENABLE_READ_CACHE", nil),
+				Description: "Cache project ids, subject descriptors, identities and security namespaces for the duration of a Terraform operation.",
+			},
 			"personal_access_token": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -166,9 +226,8 @@ func Provider() *schema.Provider {
 				Type:         schema.TypeString,
 				Optional:     true,
 				DefaultFunc:  schema.EnvDefaultFunc("AZDO_SP_CLIENT_ID", nil),
-				Description:  "The service principal client id which should be used.",
+				Description:  "The service principal client id which should be used, or the client id of a user-assigned managed identity with use_msi.",
 				ValidateFunc: validation.IsUUID,
-				RequiredWith: []string{"sp_client_id", "sp_tenant_id"},
 			},
 			"sp_tenant_id": {
 				Type:         schema.TypeString,
@@ -176,7 +235,6 @@ func Provider() *schema.Provider {
 				DefaultFunc:  schema.EnvDefaultFunc("AZDO_SP_TENANT_ID", nil),
 				Description:  "The service principal tenant id which should be used.",
 				ValidateFunc: validation.IsUUID,
-				RequiredWith: []string{"sp_client_id", "sp_tenant_id"},
 			},
 			"sp_client_id_plan": {
 				Type:         schema.TypeString,
@@ -206,7 +264,7 @@ func Provider() *schema.Provider {
 				Type:         schema.TypeString,
 				Optional:     true,
 				DefaultFunc:  schema.EnvDefaultFunc("AZDO_SP_TENANT_ID_APPLY", nil),
-				Description:  "The service principal tenant id which should be used during an apply operation in Terraform Cloud..",
+				Description:  "The service principal tenant id which should be used during an apply operation in Terraform Cloud.",
 				ValidateFunc: validation.IsUUID,
 				RequiredWith: []string{"sp_client_id_plan", "sp_tenant_id_plan", "sp_client_id_apply", "sp_tenant_id_apply"},
 			},
@@ -242,6 +300,22 @@ func Provider() *schema.Provider {
 				Description:  "Set the audience for the github actions ODIC token.",
 				RequiredWith: []string{"sp_oidc_github_actions_audience", "sp_oidc_github_actions"},
 			},
+			"sp_oidc_azure_pipelines": {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AZDO_SP_OIDC_AZURE_PIPELINES", nil),
+				Description:  "Use the Azure Pipelines OIDC token of a service connection to authenticate to a service principal.",
+				ExactlyOneOf: allAuthFields,
+				RequiredWith: []string{"sp_oidc_azure_pipelines", "sp_oidc_azure_pipelines_service_connection_id", "sp_client_id", "sp_tenant_id"},
+			},
+			"sp_oidc_azure_pipelines_service_connection_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AZDO_SP_OIDC_AZURE_PIPELINES_SERVICE_CONNECTION_ID", nil),
+				Description:  "The id of the service connection to request the Azure Pipelines OIDC token for.",
+				ValidateFunc: validation.IsUUID,
+				RequiredWith: []string{"sp_oidc_azure_pipelines_service_connection_id", "sp_oidc_azure_pipelines"},
+			},
 			"sp_oidc_hcp": {
 				Type:         schema.TypeBool,
 				Optional:     true,
@@ -249,6 +323,20 @@ func Provider() *schema.Provider {
 				Description:  "Use dynamic provider credentials in HCP to authenticate as a service principal.",
 				ExactlyOneOf: allAuthFields,
 			},
+			"use_msi": {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AZDO_USE_MSI", nil),
+				Description:  "Use a managed identity to authenticate. Set sp_client_id to use a user-assigned identity.",
+				ExactlyOneOf: allAuthFields,
+			},
+			"use_cli": {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("AZDO_USE_CLI", nil),
+				Description:  "Use the identity logged in to the Azure CLI to authenticate.",
+				ExactlyOneOf: allAuthFields,
+			},
 			"sp_client_certificate_path": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -339,6 +427,55 @@ func getGitHubOIDCToken(d *schema.ResourceData) (string, error) {
 	return response_interface.Value, nil
 }
 
+func getAzurePipelinesOIDCToken(d *schema.ResourceData) (string, error) {
+	requestUrl := os.Getenv("SYSTEM_OIDCREQUESTURI")
+	if requestUrl == "" {
+		return "", errors.New("SYSTEM_OIDCREQUESTURI must be set when using Azure Pipelines OIDC authentication.")
+	}
+	accessToken := os.Getenv("SYSTEM_ACCESSTOKEN")
+	if accessToken == "" {
+		return "", errors.New("SYSTEM_ACCESSTOKEN must be set when using Azure Pipelines OIDC authentication. Map it from $(System.AccessToken) in the pipeline step.")
+	}
+	client := &http.Client{}
+
+	parsedUrl, err := url.Parse(requestUrl)
+	if err != nil {
+		return "", err
+	}
+	query := parsedUrl.Query()
+	query.Set("api-version", "7.1")
+	query.Set("serviceConnectionId", d.Get("sp_oidc_azure_pipelines_service_connection_id").(string))
+	parsedUrl.RawQuery = query.Encode()
+
+	req, err := http.NewRequest("POST", parsedUrl.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Add("Authorization", "Bearer "+accessToken)
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("Content-Type", "application/json")
+
+	response, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Azure Pipelines OIDC token request failed with status %s", response.Status)
+	}
+	response_interface := AzurePipelinesIdTokenResponse{}
+	err = json.NewDecoder(response.Body).Decode(&response_interface)
+	if err != nil {
+		return "", err
+	}
+	if response_interface.OidcToken == "" {
+		return "", errors.New("Azure Pipelines OIDC token response did not contain a token")
+	}
+
+	return response_interface.OidcToken, nil
+}
+
 type TokenGetter interface {
 	GetToken(ctx context.Context, opts policy.TokenRequestOptions) (azcore.AccessToken, error)
 }
@@ -347,6 +484,8 @@ type AzIdentityFuncs interface {
 	NewClientAssertionCredential(tenantID, clientID string, getAssertion func(context.Context) (string, error), options *azidentity.ClientAssertionCredentialOptions) (TokenGetter, error)
 	NewClientCertificateCredential(tenantID string, clientID string, certs []*x509.Certificate, key crypto.PrivateKey, options *azidentity.ClientCertificateCredentialOptions) (TokenGetter, error)
 	NewClientSecretCredential(tenantID string, clientID string, clientSecret string, options *azidentity.ClientSecretCredentialOptions) (TokenGetter, error)
+	NewManagedIdentityCredential(options *azidentity.ManagedIdentityCredentialOptions) (TokenGetter, error)
+	NewAzureCLICredential(options *azidentity.AzureCLICredentialOptions) (TokenGetter, error)
 }
 
 type AzIdentityFuncsReal struct{}
@@ -363,6 +502,87 @@ func (a AzIdentityFuncsReal) NewClientSecretCredential(tenantID string, clientID
 	return azidentity.NewClientSecretCredential(tenantID, clientID, clientSecret, options)
 }
 
+func (a AzIdentityFuncsReal) NewManagedIdentityCredential(options *azidentity.ManagedIdentityCredentialOptions) (TokenGetter, error) {
+	return azidentity.NewManagedIdentityCredential(options)
+}
+
+func (a AzIdentityFuncsReal) NewAzureCLICredential(options *azidentity.AzureCLICredentialOptions) (TokenGetter, error) {
+	return azidentity.NewAzureCLICredential(options)
+}
+
+// getHCPRunPhase extracts the run phase from the claims of a Terraform Cloud workload identity token
+func getHCPRunPhase(workloadIdentityToken string) (string, error) {
+	workloadIdentityTokenUnmarshalled := HCPWorkloadToken{}
+	jwtParts := strings.Split(workloadIdentityToken, ".")
+	if len(jwtParts) != 3 {
+		return "", errors.New("Unable to split TFC_WORKLOAD_IDENTITY_TOKEN jwt")
+	}
+	jwtClaims := jwtParts[1]
+	if i := len(jwtClaims) % 4; i != 0 {
+		jwtClaims += strings.Repeat("=", 4-i)
+	}
+	tokenClaims, err := base64.StdEncoding.DecodeString(jwtClaims)
+	if err != nil {
+		return "", err
+	}
+	err = json.Unmarshal(tokenClaims, &workloadIdentityTokenUnmarshalled)
+	if err != nil {
+		return "", err
+	}
+	return workloadIdentityTokenUnmarshalled.RunPhase, nil
+}
+
+// usesRunPhaseIdentities reports whether plan & apply phases are configured to use different identities
+func usesRunPhaseIdentities(d *schema.ResourceData) bool {
+	for _, field := range []string{"sp_client_id_plan", "sp_tenant_id_plan", "sp_client_id_apply", "sp_tenant_id_apply"} {
+		if _, ok := d.GetOk(field); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// getRunPhaseIdentity returns the client and tenant id to use during the given run phase. Values not configured
+// for the phase fall back to sp_client_id and sp_tenant_id.
+func getRunPhaseIdentity(d *schema.ResourceData, runPhase string) (string, string, error) {
+	var suffix string
+	if strings.EqualFold(runPhase, "apply") {
+		suffix = "_apply"
+	} else if strings.EqualFold(runPhase, "plan") {
+		suffix = "_plan"
+	} else {
+		return "", "", fmt.Errorf("Unrecognized workspace run phase: %s", runPhase)
+	}
+
+	clientId := d.Get("sp_client_id").(string)
+	if v, ok := d.GetOk("sp_client_id" + suffix); ok {
+		clientId = v.(string)
+	}
+	tenantId := d.Get("sp_tenant_id").(string)
+	if v, ok := d.GetOk("sp_tenant_id" + suffix); ok {
+		tenantId = v.(string)
+	}
+	return clientId, tenantId, nil
+}
+
+// getRunPhaseIdentityFromEnvironment selects the plan or apply identity based on the Terraform Cloud workload
+// identity token of the current run, if different identities are configured for the phases.
+func getRunPhaseIdentityFromEnvironment(d *schema.ResourceData, clientId string, tenantId string) (string, string, error) {
+	if !usesRunPhaseIdentities(d) {
+		return clientId, tenantId, nil
+	}
+
+	workloadIdentityToken := os.Getenv("TFC_WORKLOAD_IDENTITY_TOKEN")
+	if workloadIdentityToken == "" {
+		return "", "", errors.New("TFC_WORKLOAD_IDENTITY_TOKEN must be set to detect the run phase when sp_client_id_plan, sp_client_id_apply, sp_tenant_id_plan or sp_tenant_id_apply are used.")
+	}
+	runPhase, err := getHCPRunPhase(workloadIdentityToken)
+	if err != nil {
+		return "", "", err
+	}
+	return getRunPhaseIdentity(d, runPhase)
+}
+
 func GetAuthToken(ctx context.Context, d *schema.ResourceData, azIdentityFuncs AzIdentityFuncs) (string, error) {
 	// Personal Access Token
 	if personal_access_token, ok := d.GetOk("personal_access_token"); ok {
@@ -411,46 +631,39 @@ func GetAuthToken(ctx context.Context, d *schema.ResourceData, azIdentityFuncs A
 		}
 	}
 
+	// OIDC Token of a service connection in an Azure Pipelines job
+	if sp_oidc_azure_pipelines, ok := d.GetOk("sp_oidc_azure_pipelines"); ok && sp_oidc_azure_pipelines.(bool) {
+		azurePipelinesToken, err := getAzurePipelinesOIDCToken(d)
+		if err != nil {
+			return "", err
+		}
+		cred, err = azIdentityFuncs.NewClientAssertionCredential(tenantId, clientId, func(context.Context) (string, error) { return azurePipelinesToken, nil }, nil)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	// OIDC Token in a HashiCorp Vault run
 	if sp_oidc_hcp, ok := d.GetOk("sp_oidc_hcp"); ok && sp_oidc_hcp.(bool) {
 		workloadIdentityToken := os.Getenv("TFC_WORKLOAD_IDENTITY_TOKEN")
 
 		// Check if plan & apply phases use different service principals
-		if clientIdPlan, ok := d.GetOk("sp_client_id_plan"); ok {
-			clientIdApply := d.Get("sp_client_id_apply").(string)
-			tenantIdPlan := d.Get("sp_tenant_id_plan").(string)
-			tenantIdApply := d.Get("sp_tenant_id_apply").(string)
-
-			workloadIdentityTokenUnmarshalled := HCPWorkloadToken{}
-			jwtParts := strings.Split(workloadIdentityToken, ".")
-			if len(jwtParts) != 3 {
-				return "", errors.New("Unable to split TFC_WORKLOAD_IDENTITY_TOKEN jwt")
-			}
-			jwtClaims := jwtParts[1]
-			if i := len(jwtClaims) % 4; i != 0 {
-				jwtClaims += strings.Repeat("=", 4-i)
-			}
-			tokenClaims, err := base64.StdEncoding.DecodeString(jwtClaims)
+		if usesRunPhaseIdentities(d) {
+			runPhase, err := getHCPRunPhase(workloadIdentityToken)
 			if err != nil {
 				return "", err
 			}
-			err = json.Unmarshal(tokenClaims, &workloadIdentityTokenUnmarshalled)
+			clientId, tenantId, err = getRunPhaseIdentity(d, runPhase)
 			if err != nil {
 				return "", err
 			}
-
-			if strings.EqualFold(workloadIdentityTokenUnmarshalled.RunPhase, "apply") {
-				clientId = clientIdApply
-				tenantId = tenantIdApply
-			} else if strings.EqualFold(workloadIdentityTokenUnmarshalled.RunPhase, "plan") {
-				clientId = clientIdPlan.(string)
-				tenantId = tenantIdPlan
-			} else {
-				return "", errors.New(fmt.Sprintf("Unrecognized workspace run phase: %s", workloadIdentityTokenUnmarshalled.RunPhase))
-			}
-		} else if clientId == "" {
+		}
+		if clientId == "" {
 			return "", errors.New(fmt.Sprintf("Either sp_client_id or sp_client_id_plan must be set when using Terraform Cloud Workload Identity Token authentication."))
 		}
+		if tenantId == "" {
+			return "", errors.New("Either sp_tenant_id or sp_tenant_id_plan must be set when using Terraform Cloud Workload Identity Token authentication.")
+		}
 
 		cred, err = azIdentityFuncs.NewClientAssertionCredential(tenantId, clientId, func(context.Context) (string, error) { return workloadIdentityToken, nil }, nil)
 		if err != nil {
@@ -458,6 +671,34 @@ func GetAuthToken(ctx context.Context, d *schema.ResourceData, azIdentityFuncs A
 		}
 	}
 
+	// Managed Identity, system-assigned unless a client id is given
+	if use_msi, ok := d.GetOk("use_msi"); ok && use_msi.(bool) {
+		clientId, _, err = getRunPhaseIdentityFromEnvironment(d, clientId, tenantId)
+		if err != nil {
+			return "", err
+		}
+		options := &azidentity.ManagedIdentityCredentialOptions{}
+		if clientId != "" {
+			options.ID = azidentity.ClientID(clientId)
+		}
+		cred, err = azIdentityFuncs.NewManagedIdentityCredential(options)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// Azure CLI
+	if use_cli, ok := d.GetOk("use_cli"); ok && use_cli.(bool) {
+		_, tenantId, err = getRunPhaseIdentityFromEnvironment(d, clientId, tenantId)
+		if err != nil {
+			return "", err
+		}
+		cred, err = azIdentityFuncs.NewAzureCLICredential(&azidentity.AzureCLICredentialOptions{TenantID: tenantId})
+		if err != nil {
+			return "", err
+		}
+	}
+
 	// Certificate from a file on disk
 	if sp_client_certificate_path, ok := d.GetOk("sp_client_certificate_path"); ok {
 		fileBytes, err := ioutil.ReadFile(sp_client_certificate_path.(string))
@@ -544,7 +785,14 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			return nil, diag.FromErr(err)
 		}
 
-		azdo_client, err := client.GetAzdoClient(token, d.Get("org_service_url").(string), terraformVersion)
+		retryOptions := &client.RetryOptions{
+			MaxRetries:            d.Get("max_retries").(int),
+			MinBackoff:            time.Duration(d.Get("retry_min_backoff").(int)) * time.Second,
+			MaxBackoff:            time.Duration(d.Get("retry_max_backoff").(int)) * time.Second,
+			MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+		}
+
+		azdo_client, err := client.GetAzdoClient(token, d.Get("org_service_url").(string), terraformVersion, retryOptions, d.Get("enable_read_cache").(bool))
 
 		return azdo_client, diag.FromErr(err)
 	}
@@ -23,6 +23,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/golang/mock/gomock"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/microsoft/terraform-provider-azuredevops/azuredevops"
 	mock_azuredevops "github.com/microsoft/terraform-provider-azuredevops/mocks"
 	"github.com/stretchr/testify/assert"
@@ -32,8 +33,12 @@ import (
 func TestProvider_HasChildResources(t *testing.T) {
 	expectedResources := []string{
 		"azuredevops_resource_authorization",
+		"azuredevops_pipeline_authorization",
+		"azuredevops_pipeline_run",
 		"azuredevops_build_definition",
 		"azuredevops_build_definition_permissions",
+		"azuredevops_build_definition_tags",
+		"azuredevops_release_definition",
 		"azuredevops_branch_policy_build_validation",
 		"azuredevops_branch_policy_min_reviewers",
 		"azuredevops_branch_policy_auto_reviewers",
@@ -45,8 +50,14 @@ func TestProvider_HasChildResources(t *testing.T) {
 		"azuredevops_project",
 		"azuredevops_project_features",
 		"azuredevops_project_pipeline_settings",
+		"azuredevops_check_approval",
+		"azuredevops_check_azure_function",
 		"azuredevops_check_branch_control",
 		"azuredevops_check_business_hours",
+		"azuredevops_check_exclusive_lock",
+		"azuredevops_check_query_azure_monitor_alerts",
+		"azuredevops_check_required_template",
+		"azuredevops_check_rest_api",
 		"azuredevops_serviceendpoint_github",
 		"azuredevops_serviceendpoint_github_enterprise",
 		"azuredevops_serviceendpoint_dockerregistry",
@@ -75,6 +86,7 @@ func TestProvider_HasChildResources(t *testing.T) {
 		"azuredevops_serviceendpoint_jfrog_xray_v2",
 		"azuredevops_serviceendpoint_externaltfs",
 		"azuredevops_variable_group",
+		"azuredevops_secure_file",
 		"azuredevops_repository_policy_author_email_pattern",
 		"azuredevops_repository_policy_case_enforcement",
 		"azuredevops_repository_policy_file_path_pattern",
@@ -85,6 +97,9 @@ func TestProvider_HasChildResources(t *testing.T) {
 		"azuredevops_git_repository",
 		"azuredevops_git_repository_branch",
 		"azuredevops_git_repository_file",
+		"azuredevops_git_repository_files",
+		"azuredevops_git_repository_settings",
+		"azuredevops_git_repository_tag",
 		"azuredevops_user_entitlement",
 		"azuredevops_group_membership",
 		"azuredevops_group",
@@ -101,6 +116,7 @@ func TestProvider_HasChildResources(t *testing.T) {
 		"azuredevops_serviceendpoint_permissions",
 		"azuredevops_servicehook_permissions",
 		"azuredevops_variable_group_permissions",
+		"azuredevops_secure_file_permissions",
 		"azuredevops_tagging_permissions",
 		"azuredevops_environment",
 		"azuredevops_build_folder",
@@ -119,23 +135,28 @@ func TestProvider_HasChildResources(t *testing.T) {
 
 func TestProvider_HasChildDataSources(t *testing.T) {
 	expectedDataSources := []string{
+		"azuredevops_build",
 		"azuredevops_build_definition",
+		"azuredevops_build_folders",
 		"azuredevops_client_config",
 		"azuredevops_group",
 		"azuredevops_project",
 		"azuredevops_projects",
 		"azuredevops_git_repositories",
 		"azuredevops_git_repository",
+		"azuredevops_git_repository_refs",
 		"azuredevops_users",
 		"azuredevops_agent_pool",
 		"azuredevops_agent_pools",
 		"azuredevops_agent_queue",
+		"azuredevops_checks",
 		"azuredevops_area",
 		"azuredevops_iteration",
 		"azuredevops_team",
 		"azuredevops_teams",
 		"azuredevops_groups",
 		"azuredevops_variable_group",
+		"azuredevops_secure_file",
 		"azuredevops_serviceendpoint_azurerm",
 		"azuredevops_serviceendpoint_github",
 	}
@@ -160,6 +181,11 @@ func TestProvider_SchemaIsValid(t *testing.T) {
 	tests := []testParams{
 		{"org_service_url", false, "AZDO_ORG_SERVICE_URL", false},
 		{"personal_access_token", false, "AZDO_PERSONAL_ACCESS_TOKEN", true},
+		{"max_retries", false, "", false},
+		{"retry_min_backoff", false, "", false},
+		{"retry_max_backoff", false, "", false},
+		{"max_concurrent_requests", false, "", false},
+		{"enable_read_cache", false, "AZDO_ENABLE_READ_CACHE", false},
 		{"sp_client_id", false, "AZDO_SP_CLIENT_ID", false},
 		{"sp_tenant_id", false, "AZDO_SP_TENANT_ID", false},
 		{"sp_client_id_plan", false, "AZDO_SP_CLIENT_ID_PLAN", false},
@@ -172,7 +198,11 @@ func TestProvider_SchemaIsValid(t *testing.T) {
 		{"sp_oidc_token_path", false, "AZDO_SP_OIDC_TOKEN_PATH", false},
 		{"sp_oidc_github_actions", false, "AZDO_SP_OIDC_GITHUB_ACTIONS", false},
 		{"sp_oidc_github_actions_audience", false, "AZDO_SP_OIDC_GITHUB_ACTIONS_AUDIENCE", false},
+		{"sp_oidc_azure_pipelines", false, "AZDO_SP_OIDC_AZURE_PIPELINES", false},
+		{"sp_oidc_azure_pipelines_service_connection_id", false, "AZDO_SP_OIDC_AZURE_PIPELINES_SERVICE_CONNECTION_ID", false},
 		{"sp_oidc_hcp", false, "AZDO_SP_OIDC_HCP", false},
+		{"use_msi", false, "AZDO_USE_MSI", false},
+		{"use_cli", false, "AZDO_USE_CLI", false},
 		{"sp_client_certificate_path", false, "AZDO_SP_CLIENT_CERTIFICATE_PATH", false},
 		{"sp_client_certificate", false, "AZDO_SP_CLIENT_CERTIFICATE", true},
 		{"sp_client_certificate_password", false, "AZDO_SP_CLIENT_CERTIFICATE_PASSWORD", true},
@@ -405,6 +435,114 @@ func TestAuthTrfmPlanApply(t *testing.T) {
 	assert.Equal(t, accessToken, resp)
 }
 
+func TestAuthMSI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockIdentityClient := mock_azuredevops.NewMockAzIdentityFuncs(ctrl)
+	accessToken := "thepassword"
+
+	resourceData := schema.TestResourceDataRaw(t, azuredevops.Provider().Schema, nil)
+	resourceData.Set("use_msi", true)
+
+	mockIdentityClient.EXPECT().NewManagedIdentityCredential(&azidentity.ManagedIdentityCredentialOptions{}).Return(&simpleTokenGetter{token: accessToken}, nil).Times(1)
+	resp, err := azuredevops.GetAuthToken(context.Background(), resourceData, mockIdentityClient)
+	assert.Nil(t, err)
+	assert.Equal(t, accessToken, resp)
+}
+
+func TestAuthMSIUserAssigned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockIdentityClient := mock_azuredevops.NewMockAzIdentityFuncs(ctrl)
+	clientId := "00000000-0000-0000-0000-000000000001"
+	accessToken := "thepassword"
+
+	resourceData := schema.TestResourceDataRaw(t, azuredevops.Provider().Schema, nil)
+	resourceData.Set("sp_client_id", clientId)
+	resourceData.Set("use_msi", true)
+
+	expectedOptions := &azidentity.ManagedIdentityCredentialOptions{ID: azidentity.ClientID(clientId)}
+	mockIdentityClient.EXPECT().NewManagedIdentityCredential(expectedOptions).Return(&simpleTokenGetter{token: accessToken}, nil).Times(1)
+	resp, err := azuredevops.GetAuthToken(context.Background(), resourceData, mockIdentityClient)
+	assert.Nil(t, err)
+	assert.Equal(t, accessToken, resp)
+}
+
+// verifies that a user-assigned managed identity does not require a tenant id, while the plan and apply
+// identities must be configured together
+func TestAuthMSI_ValidateConfig(t *testing.T) {
+	validate := func(config map[string]interface{}) bool {
+		return !azuredevops.Provider().Validate(terraform.NewResourceConfigRaw(config)).HasError()
+	}
+
+	assert.True(t, validate(map[string]interface{}{
+		"org_service_url": "https://dev.azure.com/org",
+		"use_msi":         true,
+		"sp_client_id":    "00000000-0000-0000-0000-000000000001",
+	}))
+	assert.False(t, validate(map[string]interface{}{
+		"org_service_url":    "https://dev.azure.com/org",
+		"use_msi":            true,
+		"sp_client_id_plan":  "00000000-0000-0000-0000-000000000005",
+		"sp_client_id_apply": "00000000-0000-0000-0000-000000000003",
+	}))
+	assert.False(t, validate(map[string]interface{}{
+		"org_service_url":  "https://dev.azure.com/org",
+		"sp_client_secret": "thepassword",
+		"sp_client_id":     "00000000-0000-0000-0000-000000000001",
+	}))
+}
+
+func TestAuthMSIPlanApply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockIdentityClient := mock_azuredevops.NewMockAzIdentityFuncs(ctrl)
+	clientId_apply := "00000000-0000-0000-0000-000000000003"
+	clientId_plan := "00000000-0000-0000-0000-000000000005"
+	trfm_fake_token_plan := fmt.Sprintf("header.%s.signature", base64.StdEncoding.EncodeToString([]byte("{\"terraform_run_phase\":\"plan\"}")))
+	trfm_fake_token_apply := fmt.Sprintf("header.%s.signature", base64.StdEncoding.EncodeToString([]byte("{\"terraform_run_phase\":\"apply\"}")))
+	accessToken := "thepassword"
+
+	resourceData := schema.TestResourceDataRaw(t, azuredevops.Provider().Schema, nil)
+	resourceData.Set("sp_client_id_apply", clientId_apply)
+	resourceData.Set("sp_tenant_id_apply", "00000000-0000-0000-0000-000000000004")
+	resourceData.Set("sp_client_id_plan", clientId_plan)
+	resourceData.Set("sp_tenant_id_plan", "00000000-0000-0000-0000-000000000006")
+	resourceData.Set("use_msi", true)
+
+	// Apply phase test
+	os.Setenv("TFC_WORKLOAD_IDENTITY_TOKEN", trfm_fake_token_apply)
+	mockIdentityClient.EXPECT().NewManagedIdentityCredential(&azidentity.ManagedIdentityCredentialOptions{ID: azidentity.ClientID(clientId_apply)}).Return(&simpleTokenGetter{token: accessToken}, nil).Times(1)
+	resp, err := azuredevops.GetAuthToken(context.Background(), resourceData, mockIdentityClient)
+	assert.Nil(t, err)
+	assert.Equal(t, accessToken, resp)
+
+	// Plan phase test
+	os.Setenv("TFC_WORKLOAD_IDENTITY_TOKEN", trfm_fake_token_plan)
+	mockIdentityClient.EXPECT().NewManagedIdentityCredential(&azidentity.ManagedIdentityCredentialOptions{ID: azidentity.ClientID(clientId_plan)}).Return(&simpleTokenGetter{token: accessToken}, nil).Times(1)
+	resp, err = azuredevops.GetAuthToken(context.Background(), resourceData, mockIdentityClient)
+	assert.Nil(t, err)
+	assert.Equal(t, accessToken, resp)
+
+	// The run phase cannot be detected without a workload identity token
+	os.Unsetenv("TFC_WORKLOAD_IDENTITY_TOKEN")
+	_, err = azuredevops.GetAuthToken(context.Background(), resourceData, mockIdentityClient)
+	assert.NotNil(t, err)
+}
+
+func TestAuthCLI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockIdentityClient := mock_azuredevops.NewMockAzIdentityFuncs(ctrl)
+	tenantId := "00000000-0000-0000-0000-000000000002"
+	accessToken := "thepassword"
+
+	resourceData := schema.TestResourceDataRaw(t, azuredevops.Provider().Schema, nil)
+	resourceData.Set("sp_tenant_id", tenantId)
+	resourceData.Set("use_cli", true)
+
+	mockIdentityClient.EXPECT().NewAzureCLICredential(&azidentity.AzureCLICredentialOptions{TenantID: tenantId}).Return(&simpleTokenGetter{token: accessToken}, nil).Times(1)
+	resp, err := azuredevops.GetAuthToken(context.Background(), resourceData, mockIdentityClient)
+	assert.Nil(t, err)
+	assert.Equal(t, accessToken, resp)
+}
+
 func generateCert() []byte {
 	priv, err := rsa.GenerateKey(rand.Reader, 2048)
 
@@ -545,3 +683,70 @@ func TestGHActionsNoAudience(t *testing.T) {
 		assert.Equal(t, accessToken, resp)
 	}
 }
+
+func TestAzurePipelinesOIDC(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockIdentityClient := mock_azuredevops.NewMockAzIdentityFuncs(ctrl)
+	clientId := "00000000-0000-0000-0000-000000000003"
+	tenantId := "00000000-0000-0000-0000-000000000004"
+	serviceConnectionId := "00000000-0000-0000-0000-000000000007"
+	resourceData := schema.TestResourceDataRaw(t, azuredevops.Provider().Schema, nil)
+	accessToken := "thepassword"
+	systemAccessToken := "system_access_token"
+	oidcToken := "azp_oidc_token"
+	resourceData.Set("sp_client_id", clientId)
+	resourceData.Set("sp_tenant_id", tenantId)
+	resourceData.Set("sp_oidc_azure_pipelines", true)
+	resourceData.Set("sp_oidc_azure_pipelines_service_connection_id", serviceConnectionId)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/oidctoken", r.URL.Path)
+		assert.Equal(t, serviceConnectionId, r.URL.Query().Get("serviceConnectionId"))
+		assert.NotEmpty(t, r.URL.Query().Get("api-version"))
+		assert.Equal(t, "Bearer "+systemAccessToken, r.Header.Get("Authorization"))
+		w.Header().Add("content-type", "application/json")
+		fmt.Fprintln(w, "{\"oidcToken\":\""+oidcToken+"\"}")
+	}))
+	defer ts.Close()
+
+	os.Setenv("SYSTEM_OIDCREQUESTURI", ts.URL+"/oidctoken")
+	os.Setenv("SYSTEM_ACCESSTOKEN", systemAccessToken)
+	defer os.Unsetenv("SYSTEM_OIDCREQUESTURI")
+	defer os.Unsetenv("SYSTEM_ACCESSTOKEN")
+
+	mockIdentityClient.EXPECT().NewClientAssertionCredential(tenantId, clientId, gomock.Any(), nil).DoAndReturn(
+		func(tenantID, clientID string, getAssertion func(context.Context) (string, error), options *azidentity.ClientAssertionCredentialOptions) (*simpleTokenGetter, error) {
+			assertion, err := getAssertion(context.Background())
+			assert.Nil(t, err)
+			assert.Equal(t, oidcToken, assertion)
+			getter := simpleTokenGetter{token: accessToken}
+			return &getter, nil
+		}).Times(1)
+	resp, err := azuredevops.GetAuthToken(context.Background(), resourceData, mockIdentityClient)
+	assert.Nil(t, err)
+	assert.Equal(t, accessToken, resp)
+}
+
+func TestAzurePipelinesOIDC_RequestFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockIdentityClient := mock_azuredevops.NewMockAzIdentityFuncs(ctrl)
+	resourceData := schema.TestResourceDataRaw(t, azuredevops.Provider().Schema, nil)
+	resourceData.Set("sp_client_id", "00000000-0000-0000-0000-000000000003")
+	resourceData.Set("sp_tenant_id", "00000000-0000-0000-0000-000000000004")
+	resourceData.Set("sp_oidc_azure_pipelines", true)
+	resourceData.Set("sp_oidc_azure_pipelines_service_connection_id", "00000000-0000-0000-0000-000000000007")
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	os.Setenv("SYSTEM_OIDCREQUESTURI", ts.URL)
+	os.Setenv("SYSTEM_ACCESSTOKEN", "system_access_token")
+	defer os.Unsetenv("SYSTEM_OIDCREQUESTURI")
+	defer os.Unsetenv("SYSTEM_ACCESSTOKEN")
+
+	_, err := azuredevops.GetAuthToken(context.Background(), resourceData, mockIdentityClient)
+	assert.NotNil(t, err)
+}
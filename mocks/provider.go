@@ -122,3 +122,33 @@ func (mr *MockAzIdentityFuncsMockRecorder) NewClientSecretCredential(tenantID, c
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewClientSecretCredential", reflect.TypeOf((*MockAzIdentityFuncs)(nil).NewClientSecretCredential), tenantID, clientID, clientSecret, options)
 }
+
+// NewManagedIdentityCredential mocks base method.
+func (m *MockAzIdentityFuncs) NewManagedIdentityCredential(options *azidentity.ManagedIdentityCredentialOptions) (azuredevops.TokenGetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewManagedIdentityCredential", options)
+	ret0, _ := ret[0].(azuredevops.TokenGetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewManagedIdentityCredential indicates an expected call of NewManagedIdentityCredential.
+func (mr *MockAzIdentityFuncsMockRecorder) NewManagedIdentityCredential(options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewManagedIdentityCredential", reflect.TypeOf((*MockAzIdentityFuncs)(nil).NewManagedIdentityCredential), options)
+}
+
+// NewAzureCLICredential mocks base method.
+func (m *MockAzIdentityFuncs) NewAzureCLICredential(options *azidentity.AzureCLICredentialOptions) (azuredevops.TokenGetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewAzureCLICredential", options)
+	ret0, _ := ret[0].(azuredevops.TokenGetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewAzureCLICredential indicates an expected call of NewAzureCLICredential.
+func (mr *MockAzIdentityFuncsMockRecorder) NewAzureCLICredential(options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewAzureCLICredential", reflect.TypeOf((*MockAzIdentityFuncs)(nil).NewAzureCLICredential), options)
+}
@@ -74,9 +74,32 @@ function generate_mock_clients() {
     done
 }
 
+function generate_single_mock_extras_client() {
+    info "Generating mock client: $3"
+
+    # The extras clients cover APIs that are missing from the Azure DevOps Go SDK. Their interfaces use
+    # types of the extras package, which is internal to the provider and can not be imported from
+    # $MOCK_PKG_NAME. The mocks are therefore generated into a mocks package next to the extras package.
+    PACKAGE_DIR="$SOURCE_DIR/azuredevops/internal/utils/$1"
+    mockgen \
+        -source "$PACKAGE_DIR/$2" \
+        -package "mock_$1" \
+        -destination "$PACKAGE_DIR/mocks/${2%.go}_mock.go" \
+        -mock_names "Client=$3"
+}
+
+function generate_mock_extras_clients() {
+    info "Generating mock extras clients"
+
+    generate_single_mock_extras_client "pipelineschecksextras" "pipelineschecks_extras.go" "PipelinesChecksClientExtrasV5"
+    generate_single_mock_extras_client "taskagentextras" "taskagent_extras.go" "TaskAgentClientExtras"
+    generate_single_mock_extras_client "gitextras" "git_extras.go" "GitClientExtras"
+}
+
 function generate_mocks() {
     check_gomock
     generate_mock_clients
+    generate_mock_extras_clients
     info "Mocks generated successfully"
 }
 